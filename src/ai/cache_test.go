@@ -0,0 +1,95 @@
+package ai
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testMockProvider(t *testing.T) *MockProvider {
+	t.Helper()
+
+	provider, err := NewMockProvider("")
+	if err != nil {
+		t.Fatalf("NewMockProvider: %v", err)
+	}
+	return provider
+}
+
+// countingProvider counts the autocomplete calls that reach the provider.
+type countingProvider struct {
+	*MockProvider
+	calls atomic.Int32
+	delay time.Duration
+}
+
+func (p *countingProvider) AutocompleteQuiz(ctx context.Context, req QuizAutocompleteRequest) (string, error) {
+	p.calls.Add(1)
+	time.Sleep(p.delay)
+	return p.MockProvider.AutocompleteQuiz(ctx, req)
+}
+
+func TestCachedProvider(t *testing.T) {
+	counting := &countingProvider{MockProvider: testMockProvider(t)}
+	provider := NewCachedProvider(counting, NewMemoryCache(10), time.Minute)
+
+	first, err := provider.AutocompleteQuiz(context.Background(), QuizAutocompleteRequest{Category: "Artes", Partial: "Grandes  pintores"})
+	if err != nil {
+		t.Fatalf("AutocompleteQuiz: %v", err)
+	}
+	second, err := provider.AutocompleteQuiz(context.Background(), QuizAutocompleteRequest{Category: "Artes", Partial: "Grandes pintores"})
+	if err != nil {
+		t.Fatalf("AutocompleteQuiz: %v", err)
+	}
+
+	if first != second {
+		t.Errorf("got %q from the cache, want %q", second, first)
+	}
+	if calls := counting.calls.Load(); calls != 1 {
+		t.Errorf("the provider was called %d times, want once", calls)
+	}
+}
+
+func TestCachedProviderCoalescesCalls(t *testing.T) {
+	counting := &countingProvider{MockProvider: testMockProvider(t), delay: 50 * time.Millisecond}
+	provider := NewCachedProvider(counting, NewMemoryCache(10), time.Minute)
+
+	results := make(chan error)
+	for range 5 {
+		go func() {
+			_, err := provider.AutocompleteQuiz(context.Background(), QuizAutocompleteRequest{Category: "Artes", Partial: "Grandes"})
+			results <- err
+		}()
+	}
+	for range 5 {
+		if err := <-results; err != nil {
+			t.Fatalf("AutocompleteQuiz: %v", err)
+		}
+	}
+
+	if calls := counting.calls.Load(); calls != 1 {
+		t.Errorf("the provider was called %d times, want once", calls)
+	}
+}
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	cache := NewMemoryCache(2)
+
+	cache.Set(ctx, "a", "1", time.Minute)
+	cache.Set(ctx, "b", "2", time.Minute)
+	cache.Get(ctx, "a")
+	cache.Set(ctx, "c", "3", time.Minute)
+
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true} {
+		if _, found, _ := cache.Get(ctx, key); found != want {
+			t.Errorf("key %q found: %v, want %v", key, found, want)
+		}
+	}
+
+	cache.Set(ctx, "expired", "4", -time.Second)
+	if _, found, _ := cache.Get(ctx, "expired"); found {
+		t.Error("an expired entry was found")
+	}
+}
@@ -0,0 +1,54 @@
+package ai
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	ProviderOpenAI           = "openai"
+	ProviderOpenAICompatible = "openai-compatible"
+	ProviderMock             = "mock"
+)
+
+var ErrProviderNotConfigured = errors.New("no AI provider configured")
+
+type Config struct {
+	Provider     string
+	Model        string
+	BaseURL      string
+	APIKey       string
+	FixturesPath string
+	Resilience   ResilienceConfig
+}
+
+// NewProvider builds the provider selected by the configuration. Without an
+// explicit provider, OpenAI is used when an API key is set.
+func NewProvider(config Config) (AIProvider, error) {
+	provider := config.Provider
+	if provider == "" && config.APIKey != "" {
+		provider = ProviderOpenAI
+	}
+
+	switch provider {
+	case "":
+		return nil, ErrProviderNotConfigured
+	case ProviderOpenAI:
+		if config.APIKey == "" {
+			return nil, errors.New("the openai provider requires an API key")
+		}
+		return NewOpenAIProvider(config.APIKey, config.BaseURL, config.Model, config.Resilience), nil
+	case ProviderOpenAICompatible:
+		if config.BaseURL == "" {
+			return nil, errors.New("the openai-compatible provider requires a base URL")
+		}
+		if config.Model == "" {
+			return nil, errors.New("the openai-compatible provider requires a model")
+		}
+		return NewOpenAIProvider(config.APIKey, config.BaseURL, config.Model, config.Resilience), nil
+	case ProviderMock:
+		return NewMockProvider(config.FixturesPath)
+	}
+
+	return nil, fmt.Errorf("unknown AI provider %q", provider)
+}
@@ -0,0 +1,321 @@
+package ai
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+//go:embed fixtures/mock.json
+var defaultMockFixtures []byte
+
+// MockFixtures are the canned answers of the mock provider. The {category},
+// {topic} and {quiz_title} placeholders are replaced with the request values,
+// {excerpt} with a sentence of the source material and {answer} with the
+// correct answer of the explained question.
+type MockFixtures struct {
+	Quiz                      GeneratedQuiz     `json:"quiz"`
+	Question                  GeneratedQuestion `json:"question"`
+	QuizCompletion            string            `json:"quiz_completion"`
+	QuestionCompletion        string            `json:"question_completion"`
+	CorrectChoiceCompletion   string            `json:"correct_choice_completion"`
+	IncorrectChoiceCompletion string            `json:"incorrect_choice_completion"`
+	SourceQuizTitle           string            `json:"source_quiz_title"`
+	SourceQuestion            GeneratedQuestion `json:"source_question"`
+	ReviewSummary             string            `json:"review_summary"`
+	Explanation               string            `json:"explanation"`
+}
+
+// MockProvider answers from fixtures without calling any model, so the same
+// request always gets the same answer. It is meant for tests and offline
+// development.
+type MockProvider struct {
+	fixtures MockFixtures
+}
+
+// NewMockProvider loads the fixtures from fixturesPath, or the built-in ones
+// when the path is empty.
+func NewMockProvider(fixturesPath string) (*MockProvider, error) {
+	data := defaultMockFixtures
+	if fixturesPath != "" {
+		var err error
+		data, err = os.ReadFile(fixturesPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var fixtures MockFixtures
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return nil, err
+	}
+
+	return &MockProvider{fixtures: fixtures}, nil
+}
+
+func (p *MockProvider) GenerateQuiz(ctx context.Context, req QuizRequest) (*GeneratedQuiz, error) {
+	req = req.WithDefaults()
+	if len(p.fixtures.Quiz.Questions) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	topic := req.Topic
+	if topic == "" {
+		topic = req.Category
+	}
+	replacer := strings.NewReplacer("{category}", req.Category, "{topic}", topic)
+
+	// The fixture questions are cycled through until the requested count is
+	// reached, and their choices are trimmed to the requested range
+	quiz := GeneratedQuiz{
+		QuizTitle: replacer.Replace(p.fixtures.Quiz.QuizTitle),
+		Questions: make([]GeneratedQuestion, req.QuestionCount),
+	}
+	for i := range quiz.Questions {
+		question := fillQuestion(p.fixtures.Quiz.Questions[i%len(p.fixtures.Quiz.Questions)], replacer)
+		quiz.Questions[i] = limitChoices(question, req.MaxChoices)
+	}
+	recordMockUsage(ctx, quizPromptTemplate, req, quiz)
+
+	return &quiz, nil
+}
+
+func (p *MockProvider) GenerateQuestion(ctx context.Context, req QuestionRequest) (*GeneratedQuestion, error) {
+	replacer := strings.NewReplacer("{category}", req.Category, "{quiz_title}", req.QuizTitle)
+	question := fillQuestion(p.fixtures.Question, replacer)
+	recordMockUsage(ctx, questionPromptTemplate, req, question)
+
+	return &question, nil
+}
+
+func (p *MockProvider) AutocompleteQuiz(ctx context.Context, req QuizAutocompleteRequest) (string, error) {
+	replacer := strings.NewReplacer("{category}", req.Category)
+	completion := req.Partial + replacer.Replace(p.fixtures.QuizCompletion)
+	recordMockUsage(ctx, quizAutocompletePromptTemplate, req, suggestion{SuggestedContent: completion})
+
+	return completion, nil
+}
+
+func (p *MockProvider) AutocompleteQuestion(ctx context.Context, req QuestionAutocompleteRequest) (string, error) {
+	replacer := strings.NewReplacer("{category}", req.Category, "{quiz_title}", req.QuizTitle)
+	completion := req.Partial + replacer.Replace(p.fixtures.QuestionCompletion)
+	recordMockUsage(ctx, questionAutocompletePromptTemplate, req, suggestion{SuggestedContent: completion})
+
+	return completion, nil
+}
+
+func (p *MockProvider) AutocompleteChoice(ctx context.Context, req ChoiceAutocompleteRequest) (string, error) {
+	completion := p.fixtures.IncorrectChoiceCompletion
+	if req.IsCorrect {
+		completion = p.fixtures.CorrectChoiceCompletion
+	}
+	completion = req.Partial + completion
+	recordMockUsage(ctx, choiceAutocompletePromptTemplate, req, suggestion{SuggestedContent: completion})
+
+	return completion, nil
+}
+
+// GenerateQuizFromSource takes the request as given, like the other providers,
+// since the defaults are applied by sourceChunkRequests before the text is
+// split into chunks asking for as few as one question each.
+func (p *MockProvider) GenerateQuizFromSource(ctx context.Context, req SourceQuizRequest) (*GeneratedSourceQuiz, error) {
+	sentences := sourceSentences(req.Source)
+	if len(sentences) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	quiz := GeneratedSourceQuiz{
+		QuizTitle: strings.NewReplacer("{category}", req.Category, "{topic}", req.Topic).Replace(p.fixtures.SourceQuizTitle),
+		Questions: make([]GeneratedSourceQuestion, req.QuestionCount),
+	}
+	for i := range quiz.Questions {
+		excerpt := sentences[i%len(sentences)]
+		replacer := strings.NewReplacer("{category}", req.Category, "{topic}", req.Topic, "{excerpt}", excerpt)
+		question := limitChoices(fillQuestion(p.fixtures.SourceQuestion, replacer), req.MaxChoices)
+
+		quiz.Questions[i] = GeneratedSourceQuestion{
+			QuestionContent: question.QuestionContent,
+			Choices:         question.Choices,
+			SourceExcerpt:   excerpt,
+		}
+	}
+	recordMockUsage(ctx, sourceQuizPromptTemplate, req, quiz)
+
+	return &quiz, nil
+}
+
+func (p *MockProvider) StreamQuiz(ctx context.Context, req QuizRequest, stream QuizStream[GeneratedQuestion]) error {
+	quiz, err := p.GenerateQuiz(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return replayQuiz(ctx, quiz.QuizTitle, quiz.Questions, stream)
+}
+
+func (p *MockProvider) StreamQuizFromSource(ctx context.Context, req SourceQuizRequest, stream QuizStream[GeneratedSourceQuestion]) error {
+	quiz, err := p.GenerateQuizFromSource(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return replayQuiz(ctx, quiz.QuizTitle, quiz.Questions, stream)
+}
+
+// ReviewQuiz only looks for mechanical problems: text that is not capitalized
+// or has stray whitespace, and choices repeating each other.
+func (p *MockProvider) ReviewQuiz(ctx context.Context, req QuizReviewRequest) (*QuizReview, error) {
+	review := QuizReview{
+		Summary:   strings.NewReplacer("{category}", req.Category, "{quiz_title}", req.QuizTitle).Replace(p.fixtures.ReviewSummary),
+		Questions: make([]QuestionReview, len(req.Questions)),
+	}
+	for i, question := range req.Questions {
+		review.Questions[i] = QuestionReview{QuestionID: question.ID, Issues: []ReviewIssue{}}
+
+		if fixed := tidyText(question.Content); fixed != question.Content {
+			review.Questions[i].Issues = append(review.Questions[i].Issues, ReviewIssue{
+				Kind:             IssueSpelling,
+				Description:      "O enunciado tem espaços sobrando ou não começa com letra maiúscula.",
+				SuggestedContent: fixed,
+			})
+		}
+
+		seen := make(map[string]bool)
+		for _, choice := range question.Choices {
+			if fixed := tidyText(choice.Content); fixed != choice.Content {
+				review.Questions[i].Issues = append(review.Questions[i].Issues, ReviewIssue{
+					Kind:             IssueSpelling,
+					ChoiceID:         choice.ID,
+					Description:      "A alternativa tem espaços sobrando ou não começa com letra maiúscula.",
+					SuggestedContent: fixed,
+				})
+			}
+
+			normalized := strings.ToLower(tidyText(choice.Content))
+			if seen[normalized] {
+				review.Questions[i].Issues = append(review.Questions[i].Issues, ReviewIssue{
+					Kind:        IssueMultiplePlausibleAnswer,
+					ChoiceID:    choice.ID,
+					Description: "A alternativa repete outra alternativa da questão.",
+				})
+			}
+			seen[normalized] = true
+		}
+	}
+	recordMockUsage(ctx, quizReviewPromptTemplate, req, review)
+
+	return &review, nil
+}
+
+func (p *MockProvider) GenerateExplanation(ctx context.Context, req ExplanationRequest) (string, error) {
+	var answers []string
+	for _, choice := range req.Question.Choices {
+		if choice.IsCorrect || req.Question.Type == "ordering" {
+			answers = append(answers, choice.Content)
+		}
+	}
+	if req.Question.NumericAnswer != nil {
+		answers = append(answers, strconv.FormatFloat(*req.Question.NumericAnswer, 'f', -1, 64))
+	}
+
+	replacer := strings.NewReplacer("{category}", req.Category, "{quiz_title}", req.QuizTitle, "{answer}", strings.Join(answers, ", "))
+	result := explanation{Explanation: replacer.Replace(p.fixtures.Explanation)}
+	recordMockUsage(ctx, explanationPromptTemplate, req, result)
+
+	return result.Explanation, nil
+}
+
+// tidyText collapses the whitespace of the text and capitalizes it.
+func tidyText(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	for _, r := range text {
+		return string(unicode.ToUpper(r)) + text[utf8.RuneLen(r):]
+	}
+
+	return text
+}
+
+// replayQuiz sends an already generated quiz through the stream callbacks.
+func replayQuiz[Q any](ctx context.Context, title string, questions []Q, stream QuizStream[Q]) error {
+	if stream.OnTitle != nil {
+		if err := stream.OnTitle(title); err != nil {
+			return err
+		}
+	}
+
+	for _, question := range questions {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if stream.OnQuestion != nil {
+			if err := stream.OnQuestion(question); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// recordMockUsage records the usage a real model would roughly have had for
+// the prompt and the answer, so quotas can be exercised with the mock.
+func recordMockUsage(ctx context.Context, promptTemplate string, req any, result any) {
+	prompt, _ := renderPrompt(promptTemplate, req)
+	answer, _ := json.Marshal(result)
+
+	recordUsage(ctx, Usage{
+		PromptTokens:     estimateTokens(prompt),
+		CompletionTokens: estimateTokens(string(answer)),
+	})
+}
+
+// sourceSentences splits the source material into sentences, roughly.
+func sourceSentences(source string) []string {
+	var sentences []string
+	for _, field := range strings.FieldsFunc(source, func(r rune) bool { return strings.ContainsRune(".!?\n", r) }) {
+		if sentence := strings.Join(strings.Fields(field), " "); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+	}
+
+	return sentences
+}
+
+func fillQuestion(question GeneratedQuestion, replacer *strings.Replacer) GeneratedQuestion {
+	filled := GeneratedQuestion{
+		QuestionContent: replacer.Replace(question.QuestionContent),
+		Choices:         make([]GeneratedChoice, len(question.Choices)),
+	}
+	for i, choice := range question.Choices {
+		filled.Choices[i] = GeneratedChoice{
+			Content:   replacer.Replace(choice.Content),
+			IsCorrect: choice.IsCorrect,
+		}
+	}
+
+	return filled
+}
+
+// limitChoices keeps at most maxChoices choices, making sure the correct one is
+// among them.
+func limitChoices(question GeneratedQuestion, maxChoices int) GeneratedQuestion {
+	if len(question.Choices) <= maxChoices {
+		return question
+	}
+
+	choices := question.Choices[:maxChoices]
+	if !slices.ContainsFunc(choices, func(choice GeneratedChoice) bool { return choice.IsCorrect }) {
+		if i := slices.IndexFunc(question.Choices, func(choice GeneratedChoice) bool { return choice.IsCorrect }); i >= 0 {
+			choices[maxChoices-1] = question.Choices[i]
+		}
+	}
+	question.Choices = choices
+
+	return question
+}
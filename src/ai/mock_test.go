@@ -0,0 +1,151 @@
+package ai
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMockGenerateQuiz(t *testing.T) {
+	provider := testMockProvider(t)
+	req := QuizRequest{Category: "História", QuestionCount: 7, MaxChoices: 3}
+
+	quiz, err := provider.GenerateQuiz(context.Background(), req)
+	if err != nil {
+		t.Fatalf("GenerateQuiz: %v", err)
+	}
+
+	if !strings.Contains(quiz.QuizTitle, "História") {
+		t.Errorf("the title %q does not mention the category", quiz.QuizTitle)
+	}
+	if len(quiz.Questions) != req.QuestionCount {
+		t.Fatalf("got %d questions, want %d", len(quiz.Questions), req.QuestionCount)
+	}
+	for i, question := range quiz.Questions {
+		if strings.Contains(question.QuestionContent, "{") {
+			t.Errorf("question %d has a placeholder left: %q", i+1, question.QuestionContent)
+		}
+		if len(question.Choices) < MinChoices || len(question.Choices) > req.MaxChoices {
+			t.Errorf("question %d has %d choices, want between %d and %d", i+1, len(question.Choices), MinChoices, req.MaxChoices)
+		}
+
+		correct := 0
+		for _, choice := range question.Choices {
+			if choice.IsCorrect {
+				correct++
+			}
+		}
+		if correct != 1 {
+			t.Errorf("question %d has %d correct choices, want 1", i+1, correct)
+		}
+	}
+
+	again, err := provider.GenerateQuiz(context.Background(), req)
+	if err != nil {
+		t.Fatalf("GenerateQuiz: %v", err)
+	}
+	if !reflect.DeepEqual(quiz, again) {
+		t.Error("the same request got different quizzes")
+	}
+}
+
+func TestMockGenerateQuizFromSource(t *testing.T) {
+	provider := testMockProvider(t)
+	source := "The Nile is the longest river. It flows through eleven countries!"
+
+	quiz, err := provider.GenerateQuizFromSource(context.Background(), SourceQuizRequest{
+		QuizRequest: QuizRequest{QuestionCount: 3}.WithDefaults(),
+		Source:      source,
+	})
+	if err != nil {
+		t.Fatalf("GenerateQuizFromSource: %v", err)
+	}
+	if len(quiz.Questions) != 3 {
+		t.Fatalf("got %d questions, want 3", len(quiz.Questions))
+	}
+
+	excerpts := []string{"The Nile is the longest river", "It flows through eleven countries", "The Nile is the longest river"}
+	for i, question := range quiz.Questions {
+		if question.SourceExcerpt != excerpts[i] {
+			t.Errorf("question %d quotes %q, want %q", i+1, question.SourceExcerpt, excerpts[i])
+		}
+	}
+
+	// Chunks of the text can ask for a single question, below the minimum of
+	// whole quizzes
+	chunk := SourceQuizRequest{QuizRequest: QuizRequest{QuestionCount: 3}.WithDefaults(), Source: source}
+	chunk.QuestionCount = 1
+	quiz, err = provider.GenerateQuizFromSource(context.Background(), chunk)
+	if err != nil {
+		t.Fatalf("GenerateQuizFromSource: %v", err)
+	}
+	if len(quiz.Questions) != 1 {
+		t.Errorf("got %d questions for a chunk asking for 1", len(quiz.Questions))
+	}
+
+	if _, err := provider.GenerateQuizFromSource(context.Background(), SourceQuizRequest{Source: " \n "}); err != ErrEmptyResponse {
+		t.Errorf("got error %v for an empty source, want %v", err, ErrEmptyResponse)
+	}
+}
+
+func TestMockStreamQuiz(t *testing.T) {
+	provider := testMockProvider(t)
+	req := QuizRequest{Category: "Ciências", QuestionCount: 4}
+
+	var title string
+	var questions []GeneratedQuestion
+	err := provider.StreamQuiz(context.Background(), req, QuizStream[GeneratedQuestion]{
+		OnTitle:    func(t string) error { title = t; return nil },
+		OnQuestion: func(q GeneratedQuestion) error { questions = append(questions, q); return nil },
+	})
+	if err != nil {
+		t.Fatalf("StreamQuiz: %v", err)
+	}
+
+	quiz, _ := provider.GenerateQuiz(context.Background(), req)
+	if title != quiz.QuizTitle || !reflect.DeepEqual(questions, quiz.Questions) {
+		t.Error("the streamed quiz differs from the generated one")
+	}
+}
+
+func TestMockReviewQuiz(t *testing.T) {
+	provider := testMockProvider(t)
+
+	review, err := provider.ReviewQuiz(context.Background(), QuizReviewRequest{
+		Questions: []AuthoredQuestion{
+			{ID: "tidy", Content: "Is this fine?", Choices: []AuthoredChoice{{ID: "a", Content: "Yes"}, {ID: "b", Content: "No"}}},
+			{ID: "messy", Content: "  is  this fine?", Choices: []AuthoredChoice{{ID: "a", Content: "Yes"}, {ID: "b", Content: "yes"}}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ReviewQuiz: %v", err)
+	}
+
+	if issues := review.Questions[0].Issues; len(issues) != 0 {
+		t.Errorf("got issues %+v for a tidy question", issues)
+	}
+
+	var kinds []string
+	for _, issue := range review.Questions[1].Issues {
+		kinds = append(kinds, issue.Kind)
+	}
+	want := []string{IssueSpelling, IssueSpelling, IssueMultiplePlausibleAnswer}
+	if !reflect.DeepEqual(kinds, want) {
+		t.Errorf("got issues %v, want %v", kinds, want)
+	}
+}
+
+func TestMockRecordsUsage(t *testing.T) {
+	provider := testMockProvider(t)
+	ctx, tracker := TrackUsage(context.Background())
+
+	if _, err := provider.AutocompleteQuiz(ctx, QuizAutocompleteRequest{Category: "Artes", Partial: "Grandes"}); err != nil {
+		t.Fatalf("AutocompleteQuiz: %v", err)
+	}
+
+	usage, calls := tracker.Usage()
+	if calls != 1 || usage.PromptTokens == 0 || usage.CompletionTokens == 0 {
+		t.Errorf("got usage %+v of %d calls, want the usage of one call", usage, calls)
+	}
+}
@@ -0,0 +1,301 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	openai "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/jsonschema"
+)
+
+// OpenAIProvider talks to the OpenAI API or to any server implementing its
+// chat completions endpoint, such as Ollama or vLLM.
+type OpenAIProvider struct {
+	client  *openai.Client
+	model   string
+	retrier *retrier
+}
+
+// NewOpenAIProvider creates a provider for the OpenAI API. A non-empty baseURL
+// points it to an OpenAI-compatible server instead. Failed calls are retried
+// and timed out according to resilience.
+func NewOpenAIProvider(apiKey, baseURL, model string, resilience ResilienceConfig) *OpenAIProvider {
+	config := openai.DefaultConfig(apiKey)
+	if baseURL != "" {
+		config.BaseURL = baseURL
+	}
+	config.HTTPClient = retryAfterRecorder{client: config.HTTPClient}
+	if model == "" {
+		model = openai.GPT4oMini
+	}
+
+	return &OpenAIProvider{
+		client:  openai.NewClientWithConfig(config),
+		model:   model,
+		retrier: newRetrier(resilience),
+	}
+}
+
+func (p *OpenAIProvider) GenerateQuiz(ctx context.Context, req QuizRequest) (*GeneratedQuiz, error) {
+	req = req.WithDefaults()
+
+	var result GeneratedQuiz
+	if err := p.complete(ctx, "generate-full-quiz", quizPromptTemplate, req, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (p *OpenAIProvider) GenerateQuestion(ctx context.Context, req QuestionRequest) (*GeneratedQuestion, error) {
+	var result GeneratedQuestion
+	if err := p.complete(ctx, "generate-full-question", questionPromptTemplate, req, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (p *OpenAIProvider) AutocompleteQuiz(ctx context.Context, req QuizAutocompleteRequest) (string, error) {
+	var result suggestion
+	err := p.complete(ctx, "autocompletion-quiz-title", quizAutocompletePromptTemplate, req, &result)
+
+	return result.SuggestedContent, err
+}
+
+func (p *OpenAIProvider) AutocompleteQuestion(ctx context.Context, req QuestionAutocompleteRequest) (string, error) {
+	var result suggestion
+	err := p.complete(ctx, "autocompletion-question-content", questionAutocompletePromptTemplate, req, &result)
+
+	return result.SuggestedContent, err
+}
+
+func (p *OpenAIProvider) AutocompleteChoice(ctx context.Context, req ChoiceAutocompleteRequest) (string, error) {
+	var result suggestion
+	err := p.complete(ctx, "autocompletion-choice-content", choiceAutocompletePromptTemplate, req, &result)
+
+	return result.SuggestedContent, err
+}
+
+func (p *OpenAIProvider) StreamQuiz(ctx context.Context, req QuizRequest, stream QuizStream[GeneratedQuestion]) error {
+	req = req.WithDefaults()
+
+	reader, err := p.stream(ctx, "generate-full-quiz", quizPromptTemplate, req, &GeneratedQuiz{})
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	return drainAfter(reader, decodeQuizStream(reader, stream))
+}
+
+func (p *OpenAIProvider) StreamQuizFromSource(ctx context.Context, req SourceQuizRequest, stream QuizStream[GeneratedSourceQuestion]) error {
+	reader, err := p.stream(ctx, "generate-quiz-from-source", sourceQuizPromptTemplate, req, &GeneratedSourceQuiz{})
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	return drainAfter(reader, decodeQuizStream(reader, stream))
+}
+
+func (p *OpenAIProvider) GenerateQuizFromSource(ctx context.Context, req SourceQuizRequest) (*GeneratedSourceQuiz, error) {
+	var result GeneratedSourceQuiz
+	if err := p.complete(ctx, "generate-quiz-from-source", sourceQuizPromptTemplate, req, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (p *OpenAIProvider) ReviewQuiz(ctx context.Context, req QuizReviewRequest) (*QuizReview, error) {
+	var result QuizReview
+	if err := p.complete(ctx, "review-quiz", quizReviewPromptTemplate, req, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (p *OpenAIProvider) GenerateExplanation(ctx context.Context, req ExplanationRequest) (string, error) {
+	var result explanation
+	err := p.complete(ctx, "generate-explanation", explanationPromptTemplate, req, &result)
+
+	return result.Explanation, err
+}
+
+// complete renders the prompt template with the request and sends it asking for
+// a JSON answer matching the schema of result, then unmarshals the answer into
+// it. Answers that do not match the schema are asked for again.
+func (p *OpenAIProvider) complete(ctx context.Context, name, promptTemplate string, req any, result any) error {
+	request, schema, err := p.completionRequest(name, promptTemplate, req, result)
+	if err != nil {
+		return err
+	}
+
+	return p.retrier.do(ctx, func(ctx context.Context) error {
+		callCtx, cancel := context.WithTimeout(ctx, p.retrier.config.CallTimeout)
+		defer cancel()
+
+		response, err := p.client.CreateChatCompletion(callCtx, request)
+		if err != nil {
+			return err
+		}
+		recordUsage(ctx, Usage{
+			PromptTokens:     response.Usage.PromptTokens,
+			CompletionTokens: response.Usage.CompletionTokens,
+		})
+
+		if len(response.Choices) == 0 {
+			return ErrEmptyResponse
+		}
+
+		if err := schema.Unmarshal(response.Choices[0].Message.Content, result); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+		}
+
+		return nil
+	})
+}
+
+// stream sends the same request as complete, but returns the answer as it is
+// generated. Only opening the stream is retried, as the answer may already be
+// in use when it breaks. The reader fails with the context error once ctx is
+// done or the stream times out, and must be closed to stop the stream early.
+func (p *OpenAIProvider) stream(ctx context.Context, name, promptTemplate string, req any, result any) (io.ReadCloser, error) {
+	request, _, err := p.completionRequest(name, promptTemplate, req, result)
+	if err != nil {
+		return nil, err
+	}
+	request.Stream = true
+	// The usage comes in a last chunk without choices
+	request.StreamOptions = &openai.StreamOptions{IncludeUsage: true}
+
+	ctx, cancel := context.WithTimeout(ctx, p.retrier.config.StreamTimeout)
+	var stream *openai.ChatCompletionStream
+	err = p.retrier.do(ctx, func(ctx context.Context) error {
+		var err error
+		stream, err = p.client.CreateChatCompletionStream(ctx, request)
+		return err
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	reader, writer := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer cancel()
+		defer stream.Close()
+
+		// A stream that ends early, such as when the client goes away, never
+		// gets the usage chunk, so its usage is estimated from the prompt and
+		// what was streamed so far
+		var streamed strings.Builder
+		usageRecorded := false
+		defer func() {
+			if !usageRecorded {
+				recordUsage(ctx, Usage{
+					PromptTokens:     estimateTokens(request.Messages[0].Content),
+					CompletionTokens: estimateTokens(streamed.String()),
+				})
+			}
+		}()
+
+		for {
+			response, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				writer.Close()
+				return
+			}
+			if err != nil {
+				if ctx.Err() != nil {
+					err = ctx.Err()
+				}
+				writer.CloseWithError(err)
+				return
+			}
+
+			if response.Usage != nil {
+				recordUsage(ctx, Usage{
+					PromptTokens:     response.Usage.PromptTokens,
+					CompletionTokens: response.Usage.CompletionTokens,
+				})
+				usageRecorded = true
+			}
+			if len(response.Choices) == 0 {
+				continue
+			}
+			streamed.WriteString(response.Choices[0].Delta.Content)
+			// Fails once the reader is closed, which ends the stream
+			if _, err := io.WriteString(writer, response.Choices[0].Delta.Content); err != nil {
+				return
+			}
+		}
+	}()
+
+	return &streamReader{PipeReader: reader, cancel: cancel, done: done}, nil
+}
+
+// streamReader reads the answer of a stream. Closing it stops the stream and
+// waits for its usage to be recorded, so the caller is charged for it before
+// it returns.
+type streamReader struct {
+	*io.PipeReader
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func (r *streamReader) Close() error {
+	err := r.PipeReader.Close()
+	r.cancel()
+	<-r.done
+
+	return err
+}
+
+// drainAfter reads what is left of a successfully decoded stream, so the usage
+// sent at its end is recorded before the call returns.
+func drainAfter(reader io.Reader, err error) error {
+	if err != nil {
+		return err
+	}
+	io.Copy(io.Discard, reader)
+
+	return nil
+}
+
+func (p *OpenAIProvider) completionRequest(name, promptTemplate string, req any, result any) (openai.ChatCompletionRequest, *jsonschema.Definition, error) {
+	prompt, err := renderPrompt(promptTemplate, req)
+	if err != nil {
+		return openai.ChatCompletionRequest{}, nil, fmt.Errorf("rendering prompt: %w", err)
+	}
+
+	schema, err := jsonschema.GenerateSchemaForType(result)
+	if err != nil {
+		return openai.ChatCompletionRequest{}, nil, fmt.Errorf("generating JSON schema: %w", err)
+	}
+
+	return openai.ChatCompletionRequest{
+		Model: p.model,
+		Messages: []openai.ChatCompletionMessage{
+			{
+				Role:    openai.ChatMessageRoleUser,
+				Content: prompt,
+			},
+		},
+		ResponseFormat: &openai.ChatCompletionResponseFormat{
+			Type: openai.ChatCompletionResponseFormatTypeJSONSchema,
+			JSONSchema: &openai.ChatCompletionResponseFormatJSONSchema{
+				Name:   name,
+				Schema: schema,
+				Strict: true,
+			},
+		},
+	}, schema, nil
+}
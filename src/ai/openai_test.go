@@ -0,0 +1,77 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// testStreamServer answers chat completion streams with the chunks of content,
+// then with the usage chunk when usage is set, or else it hangs until the
+// client goes away.
+func testStreamServer(t *testing.T, content []string, usage *Usage) *OpenAIProvider {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+
+		send := func(chunk map[string]any) {
+			data, _ := json.Marshal(chunk)
+			fmt.Fprintf(w, "data: %s\n\n", data)
+			w.(http.Flusher).Flush()
+		}
+		for _, part := range content {
+			send(map[string]any{"choices": []map[string]any{{"index": 0, "delta": map[string]any{"content": part}}}})
+		}
+
+		if usage == nil {
+			<-r.Context().Done()
+			return
+		}
+		send(map[string]any{"choices": []any{}, "usage": map[string]any{
+			"prompt_tokens":     usage.PromptTokens,
+			"completion_tokens": usage.CompletionTokens,
+			"total_tokens":      usage.TotalTokens(),
+		}})
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	t.Cleanup(server.Close)
+
+	return NewOpenAIProvider("test", server.URL+"/v1", "", ResilienceConfig{MaxAttempts: 1})
+}
+
+func TestOpenAIStreamRecordsUsage(t *testing.T) {
+	want := Usage{PromptTokens: 120, CompletionTokens: 40}
+	provider := testStreamServer(t, []string{`{"quiz_title": "Arte", `, `"questions": []}`}, &want)
+
+	ctx, tracker := TrackUsage(context.Background())
+	if err := provider.StreamQuiz(ctx, QuizRequest{Category: "Artes"}, QuizStream[GeneratedQuestion]{}); err != nil {
+		t.Fatalf("StreamQuiz: %v", err)
+	}
+
+	if usage, calls := tracker.Usage(); usage != want || calls != 1 {
+		t.Errorf("recorded %+v over %d calls, want %+v over 1", usage, calls, want)
+	}
+}
+
+func TestOpenAIStreamEstimatesUsageWhenStoppedEarly(t *testing.T) {
+	provider := testStreamServer(t, []string{`{"quiz_title": "Arte", "questions": [`}, nil)
+	errStop := errors.New("client went away")
+
+	ctx, tracker := TrackUsage(context.Background())
+	err := provider.StreamQuiz(ctx, QuizRequest{Category: "Artes"}, QuizStream[GeneratedQuestion]{
+		OnTitle: func(title string) error { return errStop },
+	})
+	if !errors.Is(err, errStop) {
+		t.Fatalf("got error %v, want %v", err, errStop)
+	}
+
+	usage, calls := tracker.Usage()
+	if calls != 1 || usage.PromptTokens == 0 || usage.CompletionTokens == 0 {
+		t.Errorf("recorded %+v over %d calls, want an estimate of the prompt and the streamed answer", usage, calls)
+	}
+}
@@ -0,0 +1,61 @@
+package ai
+
+import (
+	"embed"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const (
+	quizPromptTemplate                 = "quiz.tmpl"
+	questionPromptTemplate             = "question.tmpl"
+	quizAutocompletePromptTemplate     = "quiz_autocomplete.tmpl"
+	questionAutocompletePromptTemplate = "question_autocomplete.tmpl"
+	choiceAutocompletePromptTemplate   = "choice_autocomplete.tmpl"
+	sourceQuizPromptTemplate           = "source_quiz.tmpl"
+	quizReviewPromptTemplate           = "quiz_review.tmpl"
+	explanationPromptTemplate          = "explanation.tmpl"
+)
+
+//go:embed prompts/*.tmpl
+var promptFiles embed.FS
+
+var prompts = template.Must(template.New("prompts").Funcs(template.FuncMap{
+	"choiceRange":  choiceRange,
+	"difficulty":   difficultyRule,
+	"isPortuguese": isPortuguese,
+}).ParseFS(promptFiles, "prompts/*.tmpl"))
+
+// renderPrompt executes the prompt template with the request as its data.
+func renderPrompt(name string, data any) (string, error) {
+	var sb strings.Builder
+	if err := prompts.ExecuteTemplate(&sb, name, data); err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
+
+func choiceRange(minChoices, maxChoices int) string {
+	if minChoices == maxChoices {
+		return strconv.Itoa(minChoices)
+	}
+	return strconv.Itoa(minChoices) + " a " + strconv.Itoa(maxChoices)
+}
+
+func difficultyRule(difficulty string) string {
+	switch difficulty {
+	case DifficultyEasy:
+		return "todas as questões devem ser de dificuldade fácil, com conhecimentos básicos da categoria;"
+	case DifficultyMedium:
+		return "todas as questões devem ser de dificuldade média, exigindo algum conhecimento da categoria;"
+	case DifficultyHard:
+		return "todas as questões devem ser de dificuldade difícil, exigindo conhecimento aprofundado da categoria;"
+	}
+	return "as questões devem variar em dificuldade, de fáceis a difíceis;"
+}
+
+func isPortuguese(language string) bool {
+	return strings.EqualFold(language, "pt") || strings.HasPrefix(strings.ToLower(language), "pt-")
+}
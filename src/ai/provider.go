@@ -0,0 +1,175 @@
+package ai
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrEmptyResponse   = errors.New("AI provider returned no content")
+	ErrInvalidResponse = errors.New("AI provider returned an invalid response")
+)
+
+// AIProvider generates, autocompletes and reviews quiz content.
+// Implementations return ErrEmptyResponse or ErrInvalidResponse when the model
+// answers with nothing usable, and any other error when the provider could not
+// be reached. The streaming methods stop with the context error once ctx is
+// done.
+type AIProvider interface {
+	GenerateQuiz(ctx context.Context, req QuizRequest) (*GeneratedQuiz, error)
+	GenerateQuestion(ctx context.Context, req QuestionRequest) (*GeneratedQuestion, error)
+	AutocompleteQuiz(ctx context.Context, req QuizAutocompleteRequest) (string, error)
+	AutocompleteQuestion(ctx context.Context, req QuestionAutocompleteRequest) (string, error)
+	AutocompleteChoice(ctx context.Context, req ChoiceAutocompleteRequest) (string, error)
+	GenerateQuizFromSource(ctx context.Context, req SourceQuizRequest) (*GeneratedSourceQuiz, error)
+	StreamQuiz(ctx context.Context, req QuizRequest, stream QuizStream[GeneratedQuestion]) error
+	StreamQuizFromSource(ctx context.Context, req SourceQuizRequest, stream QuizStream[GeneratedSourceQuestion]) error
+	ReviewQuiz(ctx context.Context, req QuizReviewRequest) (*QuizReview, error)
+	GenerateExplanation(ctx context.Context, req ExplanationRequest) (string, error)
+}
+
+const (
+	DifficultyEasy   = "easy"
+	DifficultyMedium = "medium"
+	DifficultyHard   = "hard"
+	DifficultyMixed  = "mixed"
+
+	DefaultQuestionCount = 5
+	DefaultLanguage      = "pt-BR"
+	MinQuestionCount     = 2
+	MaxQuestionCount     = 50
+	MinChoices           = 2
+	MaxChoices           = 6
+)
+
+// QuizRequest describes the quiz to generate. Zero values fall back to the
+// defaults: five questions of mixed difficulty in pt-BR, each with two to six
+// choices.
+type QuizRequest struct {
+	Category      string
+	Topic         string
+	QuestionCount int
+	Difficulty    string
+	Language      string
+	MinChoices    int
+	MaxChoices    int
+}
+
+// WithDefaults fills the unset parameters of the request and clamps the
+// others to the supported bounds.
+func (req QuizRequest) WithDefaults() QuizRequest {
+	if req.QuestionCount == 0 {
+		req.QuestionCount = DefaultQuestionCount
+	}
+	req.QuestionCount = max(MinQuestionCount, min(MaxQuestionCount, req.QuestionCount))
+
+	if req.Difficulty == "" {
+		req.Difficulty = DifficultyMixed
+	}
+	if req.Language == "" {
+		req.Language = DefaultLanguage
+	}
+
+	if req.MinChoices == 0 {
+		req.MinChoices = MinChoices
+	}
+	if req.MaxChoices == 0 {
+		req.MaxChoices = MaxChoices
+	}
+	req.MinChoices = max(MinChoices, min(MaxChoices, req.MinChoices))
+	req.MaxChoices = max(req.MinChoices, min(MaxChoices, req.MaxChoices))
+
+	return req
+}
+
+// SourceQuizRequest asks for questions grounded in a chunk of source material.
+// The category is optional for these requests.
+type SourceQuizRequest struct {
+	QuizRequest
+	Source string
+}
+
+type QuestionRequest struct {
+	Category  string
+	QuizTitle string
+}
+
+type QuizAutocompleteRequest struct {
+	Category string
+	Partial  string
+}
+
+type QuestionAutocompleteRequest struct {
+	Category  string
+	QuizTitle string
+	Partial   string
+}
+
+type ChoiceAutocompleteRequest struct {
+	Category        string
+	QuizTitle       string
+	QuestionContent string
+	IsCorrect       bool
+	Partial         string
+}
+
+// AuthoredQuestion is a question of an existing quiz. Ordering questions list
+// their choices in the correct order, and free-text questions list their
+// accepted answers as correct choices.
+type AuthoredQuestion struct {
+	ID               string
+	Type             string
+	Content          string
+	Choices          []AuthoredChoice
+	NumericAnswer    *float64
+	NumericTolerance *float64
+}
+
+type AuthoredChoice struct {
+	ID        string
+	Content   string
+	IsCorrect bool
+}
+
+// ExplanationRequest asks why the correct answer of a question is correct.
+type ExplanationRequest struct {
+	Category  string
+	QuizTitle string
+	Question  AuthoredQuestion
+}
+
+type GeneratedChoice struct {
+	Content   string `json:"content"`
+	IsCorrect bool   `json:"is_correct"`
+}
+
+type GeneratedQuestion struct {
+	QuestionContent string            `json:"question_content"`
+	Choices         []GeneratedChoice `json:"choices"`
+}
+
+type GeneratedQuiz struct {
+	QuizTitle string              `json:"quiz_title"`
+	Questions []GeneratedQuestion `json:"questions"`
+}
+
+// GeneratedSourceQuestion is a question generated from source material along
+// with the excerpt of the material it is based on.
+type GeneratedSourceQuestion struct {
+	QuestionContent string            `json:"question_content"`
+	Choices         []GeneratedChoice `json:"choices"`
+	SourceExcerpt   string            `json:"source_excerpt"`
+}
+
+type GeneratedSourceQuiz struct {
+	QuizTitle string                    `json:"quiz_title"`
+	Questions []GeneratedSourceQuestion `json:"questions"`
+}
+
+type suggestion struct {
+	SuggestedContent string `json:"suggested_content"`
+}
+
+type explanation struct {
+	Explanation string `json:"explanation"`
+}
@@ -0,0 +1,273 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// ErrProviderUnavailable is returned when the provider keeps failing, either
+// because retrying did not help or because the circuit breaker is open.
+var ErrProviderUnavailable = errors.New("AI provider is temporarily unavailable")
+
+const (
+	DefaultMaxAttempts      = 3
+	DefaultCallTimeout      = 60 * time.Second
+	DefaultStreamTimeout    = 5 * time.Minute
+	DefaultBreakerThreshold = 5
+	DefaultBreakerCooldown  = 30 * time.Second
+
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 8 * time.Second
+	// Longer Retry-After waits are not worth holding the request for
+	maxRetryAfter = 20 * time.Second
+)
+
+// ResilienceConfig sets how calls to the provider are retried and timed out.
+// Zero values fall back to the defaults.
+type ResilienceConfig struct {
+	// MaxAttempts counts the first call, so 1 disables retries
+	MaxAttempts int
+	// CallTimeout bounds each attempt of a completion
+	CallTimeout time.Duration
+	// StreamTimeout bounds a whole stream, retries included
+	StreamTimeout time.Duration
+	// BreakerThreshold consecutive failures open the circuit breaker for
+	// BreakerCooldown
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+func (config ResilienceConfig) withDefaults() ResilienceConfig {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = DefaultMaxAttempts
+	}
+	if config.CallTimeout <= 0 {
+		config.CallTimeout = DefaultCallTimeout
+	}
+	if config.StreamTimeout <= 0 {
+		config.StreamTimeout = DefaultStreamTimeout
+	}
+	if config.BreakerThreshold <= 0 {
+		config.BreakerThreshold = DefaultBreakerThreshold
+	}
+	if config.BreakerCooldown <= 0 {
+		config.BreakerCooldown = DefaultBreakerCooldown
+	}
+
+	return config
+}
+
+// retrier retries failed calls with exponential backoff and stops calling the
+// provider for a while when it keeps failing.
+type retrier struct {
+	config  ResilienceConfig
+	breaker circuitBreaker
+}
+
+func newRetrier(config ResilienceConfig) *retrier {
+	config = config.withDefaults()
+
+	return &retrier{
+		config: config,
+		breaker: circuitBreaker{
+			threshold: config.BreakerThreshold,
+			cooldown:  config.BreakerCooldown,
+		},
+	}
+}
+
+// do makes the call until it succeeds or fails with an error retrying cannot
+// fix, up to the maximum number of attempts. Invalid and empty responses are
+// retried too, as the model may answer correctly the next time. Transient
+// errors are wrapped in ErrProviderUnavailable once the attempts run out.
+func (r *retrier) do(ctx context.Context, call func(ctx context.Context) error) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		if !r.breaker.allow() {
+			return errors.Join(ErrProviderUnavailable, err)
+		}
+
+		var retryAfter time.Duration
+		err = call(context.WithValue(ctx, retryAfterKey{}, &retryAfter))
+		if ctx.Err() != nil {
+			// The caller went away, which says nothing about the provider
+			r.breaker.release()
+			return ctx.Err()
+		}
+		r.breaker.record(isProviderFailure(err))
+		if err == nil {
+			return nil
+		}
+
+		transient := isTransient(err)
+		if !transient && !errors.Is(err, ErrInvalidResponse) && !errors.Is(err, ErrEmptyResponse) {
+			return err
+		}
+		if attempt >= r.config.MaxAttempts || retryAfter > maxRetryAfter {
+			if transient {
+				return errors.Join(ErrProviderUnavailable, err)
+			}
+			return err
+		}
+
+		delay := max(backoffDelay(attempt), retryAfter)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// backoffDelay doubles the delay after every attempt, with some jitter so
+// concurrent requests do not retry all at once.
+func backoffDelay(attempt int) time.Duration {
+	delay := min(retryMaxDelay, retryBaseDelay<<(attempt-1))
+
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// isTransient reports whether the error may go away by trying again: rate
+// limits, server errors, timeouts and network failures.
+func isTransient(err error) bool {
+	if statusCode, ok := httpStatusCode(err); ok {
+		if statusCode == http.StatusTooManyRequests {
+			// Running out of credits is not solved by waiting
+			var apiErr *openai.APIError
+			return !errors.As(err, &apiErr) || apiErr.Code != "insufficient_quota"
+		}
+		return statusCode >= http.StatusInternalServerError
+	}
+
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.ErrUnexpectedEOF) || errors.As(err, &netErr)
+}
+
+// isProviderFailure reports whether the error means the provider is down,
+// which is what the circuit breaker counts. Rate limits mean it is up.
+func isProviderFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	if statusCode, ok := httpStatusCode(err); ok {
+		return statusCode >= http.StatusInternalServerError
+	}
+
+	return isTransient(err)
+}
+
+func httpStatusCode(err error) (int, bool) {
+	var apiErr *openai.APIError
+	if errors.As(err, &apiErr) && apiErr.HTTPStatusCode != 0 {
+		return apiErr.HTTPStatusCode, true
+	}
+	var requestErr *openai.RequestError
+	if errors.As(err, &requestErr) && requestErr.HTTPStatusCode != 0 {
+		return requestErr.HTTPStatusCode, true
+	}
+
+	return 0, false
+}
+
+// circuitBreaker opens after threshold consecutive failures, rejecting calls
+// until the cooldown is over. Then a single trial call is let through: the
+// breaker closes if it succeeds and opens again if it fails.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openUntil time.Time
+	trial     bool
+}
+
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return true
+	}
+	if b.trial || time.Now().Before(b.openUntil) {
+		return false
+	}
+	b.trial = true
+
+	return true
+}
+
+func (b *circuitBreaker) record(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+	if !failed {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.cooldown)
+	}
+}
+
+// release ends a call without counting it, letting another trial through if
+// it was one.
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+}
+
+type retryAfterKey struct{}
+
+// retryAfterRecorder reads the Retry-After header of failed responses into the
+// request context, as the client errors do not carry the headers.
+type retryAfterRecorder struct {
+	client openai.HTTPDoer
+}
+
+func (r retryAfterRecorder) Do(req *http.Request) (*http.Response, error) {
+	resp, err := r.client.Do(req)
+	if resp != nil && resp.StatusCode >= http.StatusBadRequest {
+		if retryAfter, ok := req.Context().Value(retryAfterKey{}).(*time.Duration); ok {
+			*retryAfter = parseRetryAfter(resp.Header)
+		}
+	}
+
+	return resp, err
+}
+
+// parseRetryAfter reads the retry-after-ms header sent by OpenAI, or the
+// standard Retry-After header in seconds or as a date.
+func parseRetryAfter(header http.Header) time.Duration {
+	if value := header.Get("Retry-After-Ms"); value != "" {
+		if ms, err := strconv.ParseFloat(value, 64); err == nil && ms > 0 {
+			return time.Duration(ms * float64(time.Millisecond))
+		}
+	}
+
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(0, time.Until(date))
+	}
+
+	return 0
+}
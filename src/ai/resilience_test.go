@@ -0,0 +1,189 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		want   time.Duration
+	}{
+		{"none", http.Header{}, 0},
+		{"milliseconds", http.Header{"Retry-After-Ms": {"1500"}}, 1500 * time.Millisecond},
+		{"milliseconds first", http.Header{"Retry-After-Ms": {"250"}, "Retry-After": {"3"}}, 250 * time.Millisecond},
+		{"invalid milliseconds", http.Header{"Retry-After-Ms": {"soon"}, "Retry-After": {"3"}}, 3 * time.Second},
+		{"seconds", http.Header{"Retry-After": {"3"}}, 3 * time.Second},
+		{"negative seconds", http.Header{"Retry-After": {"-3"}}, 0},
+		{"past date", http.Header{"Retry-After": {"Wed, 21 Oct 2015 07:28:00 GMT"}}, 0},
+		{"invalid", http.Header{"Retry-After": {"later"}}, 0},
+	}
+
+	for _, test := range tests {
+		if got := parseRetryAfter(test.header); got != test.want {
+			t.Errorf("%s: parseRetryAfter(%v) = %v, want %v", test.name, test.header, got, test.want)
+		}
+	}
+
+	date := time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)
+	if got := parseRetryAfter(http.Header{"Retry-After": {date}}); got <= 8*time.Second || got > 10*time.Second {
+		t.Errorf("parseRetryAfter(%q) = %v, want about 10s", date, got)
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"server error", &openai.APIError{HTTPStatusCode: http.StatusInternalServerError}, true},
+		{"unavailable request", &openai.RequestError{HTTPStatusCode: http.StatusServiceUnavailable}, true},
+		{"rate limit", &openai.APIError{HTTPStatusCode: http.StatusTooManyRequests, Code: "rate_limit_exceeded"}, true},
+		{"insufficient quota", &openai.APIError{HTTPStatusCode: http.StatusTooManyRequests, Code: "insufficient_quota"}, false},
+		{"wrapped insufficient quota", fmt.Errorf("completing: %w", &openai.APIError{HTTPStatusCode: http.StatusTooManyRequests, Code: "insufficient_quota"}), false},
+		{"bad request", &openai.APIError{HTTPStatusCode: http.StatusBadRequest}, false},
+		{"unauthorized", &openai.RequestError{HTTPStatusCode: http.StatusUnauthorized}, false},
+		{"timeout", context.DeadlineExceeded, true},
+		{"cut response", io.ErrUnexpectedEOF, true},
+		{"network", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, true},
+		{"invalid response", ErrInvalidResponse, false},
+		{"other", errors.New("boom"), false},
+	}
+
+	for _, test := range tests {
+		if got := isTransient(test.err); got != test.want {
+			t.Errorf("%s: isTransient(%v) = %v, want %v", test.name, test.err, got, test.want)
+		}
+	}
+}
+
+// fakeCall fails with the errors in turn, then succeeds, counting its calls.
+type fakeCall struct {
+	errs  []error
+	calls int
+}
+
+func (f *fakeCall) call(ctx context.Context) error {
+	f.calls++
+	if len(f.errs) == 0 {
+		return nil
+	}
+
+	err := f.errs[0]
+	f.errs = f.errs[1:]
+	return err
+}
+
+var (
+	errServer     = &openai.APIError{HTTPStatusCode: http.StatusInternalServerError}
+	errBadRequest = &openai.APIError{HTTPStatusCode: http.StatusBadRequest}
+)
+
+func TestRetrierRetriesServerErrors(t *testing.T) {
+	r := newRetrier(ResilienceConfig{MaxAttempts: 2})
+	call := &fakeCall{errs: []error{errServer}}
+
+	if err := r.do(context.Background(), call.call); err != nil {
+		t.Fatalf("do: %v", err)
+	}
+	if call.calls != 2 {
+		t.Errorf("made %d calls, want 2", call.calls)
+	}
+}
+
+func TestRetrierDoesNotRetryClientErrors(t *testing.T) {
+	r := newRetrier(ResilienceConfig{MaxAttempts: 3})
+	call := &fakeCall{errs: []error{errBadRequest}}
+
+	err := r.do(context.Background(), call.call)
+	if !errors.Is(err, errBadRequest) || errors.Is(err, ErrProviderUnavailable) {
+		t.Errorf("got error %v, want the client error alone", err)
+	}
+	if call.calls != 1 {
+		t.Errorf("made %d calls, want 1", call.calls)
+	}
+}
+
+func TestRetrierGivesUpAfterMaxAttempts(t *testing.T) {
+	r := newRetrier(ResilienceConfig{MaxAttempts: 2})
+	call := &fakeCall{errs: []error{errServer, errServer, errServer}}
+
+	err := r.do(context.Background(), call.call)
+	if !errors.Is(err, ErrProviderUnavailable) || !errors.Is(err, errServer) {
+		t.Errorf("got error %v, want %v wrapping the server error", err, ErrProviderUnavailable)
+	}
+	if call.calls != 2 {
+		t.Errorf("made %d calls, want 2", call.calls)
+	}
+}
+
+func TestRetrierCircuitBreaker(t *testing.T) {
+	cooldown := 50 * time.Millisecond
+	r := newRetrier(ResilienceConfig{MaxAttempts: 1, BreakerThreshold: 2, BreakerCooldown: cooldown})
+	failing := &fakeCall{errs: []error{errServer, errServer, errServer}}
+
+	for range 2 {
+		if err := r.do(context.Background(), failing.call); !errors.Is(err, ErrProviderUnavailable) {
+			t.Fatalf("got error %v, want %v", err, ErrProviderUnavailable)
+		}
+	}
+
+	// The breaker is open, so the provider is not called
+	if err := r.do(context.Background(), failing.call); !errors.Is(err, ErrProviderUnavailable) {
+		t.Errorf("got error %v with the breaker open, want %v", err, ErrProviderUnavailable)
+	}
+	if failing.calls != 2 {
+		t.Fatalf("made %d calls, want 2 as the breaker opened", failing.calls)
+	}
+
+	time.Sleep(cooldown)
+
+	// A single trial call is let through after the cooldown
+	if !r.breaker.allow() {
+		t.Fatal("the breaker rejected the trial call after the cooldown")
+	}
+	if r.breaker.allow() {
+		t.Error("the breaker let a second call through during the trial")
+	}
+	r.breaker.release()
+
+	succeeding := &fakeCall{}
+	if err := r.do(context.Background(), succeeding.call); err != nil {
+		t.Fatalf("do after the cooldown: %v", err)
+	}
+	if err := r.do(context.Background(), succeeding.call); err != nil {
+		t.Errorf("do after a successful trial: %v", err)
+	}
+	if succeeding.calls != 2 {
+		t.Errorf("made %d calls, want 2 as the breaker closed", succeeding.calls)
+	}
+}
+
+func TestCircuitBreakerReopensAfterFailedTrial(t *testing.T) {
+	b := circuitBreaker{threshold: 1, cooldown: 50 * time.Millisecond}
+	b.record(true)
+	if b.allow() {
+		t.Fatal("the breaker let a call through while open")
+	}
+
+	time.Sleep(b.cooldown)
+	if !b.allow() {
+		t.Fatal("the breaker rejected the trial call after the cooldown")
+	}
+	b.record(true)
+
+	if b.allow() {
+		t.Error("the breaker let a call through after a failed trial")
+	}
+}
@@ -0,0 +1,37 @@
+package ai
+
+const (
+	IssueAmbiguousWording        = "ambiguous_wording"
+	IssueFactualDoubt            = "factual_doubt"
+	IssueMultiplePlausibleAnswer = "multiple_plausible_answers"
+	IssueObviousDistractor       = "obvious_distractor"
+	IssueSpelling                = "spelling"
+)
+
+// QuizReviewRequest holds a quiz as written by its author, so the model can
+// point out problems in it.
+type QuizReviewRequest struct {
+	Category  string
+	QuizTitle string
+	Questions []AuthoredQuestion
+}
+
+// QuizReview is the feedback on a quiz. Issues about the question itself have
+// an empty ChoiceID, and an empty SuggestedContent means there is no rewrite
+// to suggest.
+type QuizReview struct {
+	Summary   string           `json:"summary"`
+	Questions []QuestionReview `json:"questions"`
+}
+
+type QuestionReview struct {
+	QuestionID string        `json:"question_id"`
+	Issues     []ReviewIssue `json:"issues"`
+}
+
+type ReviewIssue struct {
+	Kind             string `json:"kind" enum:"ambiguous_wording,factual_doubt,multiple_plausible_answers,obvious_distractor,spelling"`
+	ChoiceID         string `json:"choice_id"`
+	Description      string `json:"description"`
+	SuggestedContent string `json:"suggested_content"`
+}
@@ -0,0 +1,250 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ledongthuc/pdf"
+)
+
+const (
+	// SourceChunkSize is the maximum number of characters of source material
+	// sent to the model at once
+	SourceChunkSize = 6000
+	// MaxSourceLength is the maximum number of characters of source material
+	// accepted for a quiz
+	MaxSourceLength = 200000
+)
+
+var (
+	ErrUnsupportedSource = errors.New("unsupported source file type")
+	ErrEmptySource       = errors.New("source material has no text")
+)
+
+// ExtractSourceText reads the text of an uploaded PDF, Markdown or plain text
+// file, picking the reader by the file extension.
+func ExtractSourceText(filename string, content []byte) (string, error) {
+	var text string
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".pdf":
+		extracted, err := extractPDFText(content)
+		if err != nil {
+			return "", err
+		}
+		text = extracted
+	case ".md", ".markdown", ".txt", ".text":
+		if !utf8.Valid(content) {
+			return "", ErrUnsupportedSource
+		}
+		text = string(bytes.TrimPrefix(content, []byte("\ufeff")))
+	default:
+		return "", ErrUnsupportedSource
+	}
+
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n"))
+	if text == "" {
+		return "", ErrEmptySource
+	}
+
+	return text, nil
+}
+
+// extractPDFText reads the text of every page. The PDF reader panics on some
+// malformed files, which is reported as an error instead.
+func extractPDFText(content []byte) (text string, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("reading PDF: %v", recovered)
+		}
+	}()
+
+	reader, err := pdf.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return "", err
+	}
+	plainText, err := reader.GetPlainText()
+	if err != nil {
+		return "", err
+	}
+	extracted, err := io.ReadAll(plainText)
+	if err != nil {
+		return "", err
+	}
+
+	return string(extracted), nil
+}
+
+// ChunkText splits the text into chunks of at most size characters, breaking
+// between paragraphs, then between lines or sentences, and only cutting words
+// when nothing else fits.
+func ChunkText(text string, size int) []string {
+	var chunks []string
+	var current strings.Builder
+	flush := func() {
+		if chunk := strings.TrimSpace(current.String()); chunk != "" {
+			chunks = append(chunks, chunk)
+		}
+		current.Reset()
+	}
+
+	for _, paragraph := range splitSource(text, size) {
+		if current.Len() > 0 && utf8.RuneCountInString(current.String())+utf8.RuneCountInString(paragraph)+2 > size {
+			flush()
+		}
+		if current.Len() > 0 {
+			current.WriteString("\n\n")
+		}
+		current.WriteString(paragraph)
+	}
+	flush()
+
+	return chunks
+}
+
+// splitSource splits the text into paragraphs no longer than size characters.
+func splitSource(text string, size int) []string {
+	var pieces []string
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+		pieces = append(pieces, splitLongText(paragraph, size)...)
+	}
+
+	return pieces
+}
+
+func splitLongText(text string, size int) []string {
+	runes := []rune(text)
+	if len(runes) <= size {
+		return []string{text}
+	}
+
+	// Prefer the last line or sentence break of the first size characters
+	cut := size
+	for i := size - 1; i > size/2; i-- {
+		if runes[i] == '\n' || (runes[i] == ' ' && strings.ContainsRune(".!?;", runes[i-1])) {
+			cut = i + 1
+			break
+		}
+	}
+	if cut == size {
+		for i := size - 1; i > size/2; i-- {
+			if runes[i] == ' ' {
+				cut = i + 1
+				break
+			}
+		}
+	}
+
+	head := strings.TrimSpace(string(runes[:cut]))
+	return append([]string{head}, splitLongText(strings.TrimSpace(string(runes[cut:])), size)...)
+}
+
+// GenerateQuizFromSource splits the source material into chunks and asks the
+// provider for questions grounded in each of them.
+func GenerateQuizFromSource(ctx context.Context, provider AIProvider, text string, req SourceQuizRequest) (*GeneratedSourceQuiz, error) {
+	chunkRequests, err := sourceChunkRequests(text, req)
+	if err != nil {
+		return nil, err
+	}
+
+	quiz := GeneratedSourceQuiz{}
+	for _, chunkRequest := range chunkRequests {
+		result, err := provider.GenerateQuizFromSource(ctx, chunkRequest)
+		if err != nil {
+			return nil, err
+		}
+
+		if quiz.QuizTitle == "" {
+			quiz.QuizTitle = result.QuizTitle
+		}
+		quiz.Questions = append(quiz.Questions, result.Questions[:min(len(result.Questions), chunkRequest.QuestionCount)]...)
+	}
+
+	if len(quiz.Questions) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	return &quiz, nil
+}
+
+// StreamQuizFromSource is the streaming version of GenerateQuizFromSource. The
+// title of the first chunk is the title of the quiz, and every question is
+// sent as soon as it is generated.
+func StreamQuizFromSource(ctx context.Context, provider AIProvider, text string, req SourceQuizRequest, stream QuizStream[GeneratedSourceQuestion]) error {
+	chunkRequests, err := sourceChunkRequests(text, req)
+	if err != nil {
+		return err
+	}
+
+	hasTitle, questionCount := false, 0
+	for _, chunkRequest := range chunkRequests {
+		chunkQuestionCount := 0
+		err := provider.StreamQuizFromSource(ctx, chunkRequest, QuizStream[GeneratedSourceQuestion]{
+			OnTitle: func(title string) error {
+				if hasTitle || stream.OnTitle == nil {
+					return nil
+				}
+				hasTitle = true
+				return stream.OnTitle(title)
+			},
+			OnQuestion: func(question GeneratedSourceQuestion) error {
+				// Models sometimes go past the requested count
+				if chunkQuestionCount >= chunkRequest.QuestionCount || stream.OnQuestion == nil {
+					return nil
+				}
+				chunkQuestionCount++
+				questionCount++
+				return stream.OnQuestion(question)
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if questionCount == 0 {
+		return ErrEmptyResponse
+	}
+
+	return nil
+}
+
+// sourceChunkRequests splits the source material into chunks and spreads the
+// questions between them. When there are more chunks than questions, evenly
+// spaced chunks are used so the quiz covers the whole material.
+func sourceChunkRequests(text string, req SourceQuizRequest) ([]SourceQuizRequest, error) {
+	req.QuizRequest = req.QuizRequest.WithDefaults()
+
+	chunks := ChunkText(text, SourceChunkSize)
+	if len(chunks) == 0 {
+		return nil, ErrEmptySource
+	}
+	if len(chunks) > req.QuestionCount {
+		selected := make([]string, req.QuestionCount)
+		for i := range selected {
+			selected[i] = chunks[i*len(chunks)/req.QuestionCount]
+		}
+		chunks = selected
+	}
+
+	chunkRequests := make([]SourceQuizRequest, len(chunks))
+	for i, chunk := range chunks {
+		chunkRequests[i] = req
+		chunkRequests[i].Source = chunk
+		chunkRequests[i].QuestionCount = req.QuestionCount / len(chunks)
+		if i < req.QuestionCount%len(chunks) {
+			chunkRequests[i].QuestionCount++
+		}
+	}
+
+	return chunkRequests, nil
+}
@@ -0,0 +1,73 @@
+package ai
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestChunkText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		size int
+		want []string
+	}{
+		{
+			name: "short text",
+			text: "One paragraph.",
+			size: 100,
+			want: []string{"One paragraph."},
+		},
+		{
+			name: "empty text",
+			text: " \n\n \n\n",
+			size: 100,
+		},
+		{
+			name: "paragraphs that fit together",
+			text: "First.\n\nSecond.\n\n\n\nThird.",
+			size: 100,
+			want: []string{"First.\n\nSecond.\n\nThird."},
+		},
+		{
+			name: "paragraphs split between chunks",
+			text: "First paragraph.\n\nSecond paragraph.\n\nThird paragraph.",
+			size: 40,
+			want: []string{"First paragraph.\n\nSecond paragraph.", "Third paragraph."},
+		},
+		{
+			name: "long paragraph split between sentences",
+			text: "The first sentence is here. The second one follows. The third ends it.",
+			size: 40,
+			want: []string{"The first sentence is here.", "The second one follows.", "The third ends it."},
+		},
+		{
+			name: "long word cut",
+			text: strings.Repeat("a", 25),
+			size: 10,
+			want: []string{strings.Repeat("a", 10), strings.Repeat("a", 10), strings.Repeat("a", 5)},
+		},
+		{
+			name: "sizes counted in characters",
+			text: "ação ação ação",
+			size: 9,
+			want: []string{"ação ação", "ação"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ChunkText(test.text, test.size)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("got %q, want %q", got, test.want)
+			}
+			for _, chunk := range got {
+				if utf8.RuneCountInString(chunk) > test.size {
+					t.Errorf("chunk %q is longer than %d characters", chunk, test.size)
+				}
+			}
+		})
+	}
+}
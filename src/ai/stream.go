@@ -0,0 +1,99 @@
+package ai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// QuizStream receives the parts of a quiz as soon as they are generated: the
+// title first, then every question. Returning an error from a callback stops
+// the generation.
+type QuizStream[Q any] struct {
+	OnTitle    func(title string) error
+	OnQuestion func(question Q) error
+}
+
+// decodeQuizStream reads a quiz JSON object as it is being written, calling
+// the stream callbacks for the title and for every question of the questions
+// array as soon as each is complete.
+func decodeQuizStream[Q any](r io.Reader, stream QuizStream[Q]) error {
+	decoder := json.NewDecoder(r)
+
+	if err := expectDelim(decoder, '{'); err != nil {
+		if errors.Is(err, io.EOF) {
+			return ErrEmptyResponse
+		}
+		return err
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return invalidStreamError(err)
+		}
+
+		switch token {
+		case "quiz_title":
+			var title string
+			if err := decoder.Decode(&title); err != nil {
+				return invalidStreamError(err)
+			}
+			if stream.OnTitle != nil {
+				if err := stream.OnTitle(title); err != nil {
+					return err
+				}
+			}
+		case "questions":
+			if err := expectDelim(decoder, '['); err != nil {
+				return err
+			}
+			for decoder.More() {
+				var question Q
+				if err := decoder.Decode(&question); err != nil {
+					return invalidStreamError(err)
+				}
+				if stream.OnQuestion != nil {
+					if err := stream.OnQuestion(question); err != nil {
+						return err
+					}
+				}
+			}
+			if err := expectDelim(decoder, ']'); err != nil {
+				return err
+			}
+		default:
+			var skipped json.RawMessage
+			if err := decoder.Decode(&skipped); err != nil {
+				return invalidStreamError(err)
+			}
+		}
+	}
+
+	return expectDelim(decoder, '}')
+}
+
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return invalidStreamError(err)
+	}
+	if token != delim {
+		return fmt.Errorf("%w: expected %v, got %v", ErrInvalidResponse, delim, token)
+	}
+
+	return nil
+}
+
+// invalidStreamError marks malformed answers as invalid, keeping the errors of
+// the stream itself, such as a canceled context, as they are.
+func invalidStreamError(err error) error {
+	var syntaxError *json.SyntaxError
+	var typeError *json.UnmarshalTypeError
+	if errors.As(err, &syntaxError) || errors.As(err, &typeError) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+
+	return err
+}
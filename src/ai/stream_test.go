@@ -0,0 +1,112 @@
+package ai
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+const testQuizStream = `{
+	"quiz_title": "Grandes pintores",
+	"notes": {"skipped": [1, 2, {"deep": true}]},
+	"questions": [
+		{"question_content": "Quem pintou a Mona Lisa?", "choices": [{"content": "Leonardo da Vinci", "is_correct": true}, {"content": "Michelangelo", "is_correct": false}]},
+		{"question_content": "Quem pintou Guernica?", "choices": [{"content": "Pablo Picasso", "is_correct": true}, {"content": "Salvador Dalí", "is_correct": false}]}
+	],
+	"language": "pt"
+}`
+
+// recordedStream records what the stream callbacks receive.
+type recordedStream struct {
+	titles    []string
+	questions []GeneratedQuestion
+}
+
+func (r *recordedStream) stream() QuizStream[GeneratedQuestion] {
+	return QuizStream[GeneratedQuestion]{
+		OnTitle: func(title string) error {
+			r.titles = append(r.titles, title)
+			return nil
+		},
+		OnQuestion: func(question GeneratedQuestion) error {
+			r.questions = append(r.questions, question)
+			return nil
+		},
+	}
+}
+
+func TestDecodeQuizStream(t *testing.T) {
+	var recorded recordedStream
+	// Reading a byte at a time splits the object at every possible boundary
+	if err := decodeQuizStream(iotest.OneByteReader(strings.NewReader(testQuizStream)), recorded.stream()); err != nil {
+		t.Fatalf("decodeQuizStream: %v", err)
+	}
+
+	if len(recorded.titles) != 1 || recorded.titles[0] != "Grandes pintores" {
+		t.Errorf("got titles %q, want only %q", recorded.titles, "Grandes pintores")
+	}
+	if len(recorded.questions) != 2 {
+		t.Fatalf("got %d questions, want 2", len(recorded.questions))
+	}
+	if got := recorded.questions[1].QuestionContent; got != "Quem pintou Guernica?" {
+		t.Errorf("got second question %q, want %q", got, "Quem pintou Guernica?")
+	}
+	if choices := recorded.questions[0].Choices; len(choices) != 2 || !choices[0].IsCorrect || choices[1].Content != "Michelangelo" {
+		t.Errorf("got choices %+v for the first question", choices)
+	}
+}
+
+func TestDecodeQuizStreamInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  error
+	}{
+		{"empty", "", ErrEmptyResponse},
+		{"blank", " \n\t", ErrEmptyResponse},
+		{"truncated question", testQuizStream[:strings.Index(testQuizStream, "Guernica")], ErrInvalidResponse},
+		{"truncated object", `{"quiz_title": "Grandes pintores"`, ErrInvalidResponse},
+		{"truncated key", `{"quiz_title": "Grandes pintores", "quest`, ErrInvalidResponse},
+		{"not an object", `["Grandes pintores"]`, ErrInvalidResponse},
+		{"questions not an array", `{"questions": {}}`, ErrInvalidResponse},
+		{"wrong title type", `{"quiz_title": 3}`, ErrInvalidResponse},
+	}
+
+	for _, test := range tests {
+		var recorded recordedStream
+		err := decodeQuizStream(strings.NewReader(test.input), recorded.stream())
+		if !errors.Is(err, test.want) {
+			t.Errorf("%s: got error %v, want %v", test.name, err, test.want)
+		}
+	}
+}
+
+func TestDecodeQuizStreamCallbackError(t *testing.T) {
+	errStop := errors.New("client went away")
+
+	var questions int
+	err := decodeQuizStream(strings.NewReader(testQuizStream), QuizStream[GeneratedQuestion]{
+		OnQuestion: func(question GeneratedQuestion) error {
+			questions++
+			return errStop
+		},
+	})
+	if !errors.Is(err, errStop) {
+		t.Errorf("got error %v, want %v", err, errStop)
+	}
+	if questions != 1 {
+		t.Errorf("got %d questions, want decoding to stop after the first", questions)
+	}
+
+	err = decodeQuizStream(strings.NewReader(testQuizStream), QuizStream[GeneratedQuestion]{
+		OnTitle: func(title string) error { return errStop },
+		OnQuestion: func(question GeneratedQuestion) error {
+			t.Error("a question was decoded after the title callback failed")
+			return nil
+		},
+	})
+	if !errors.Is(err, errStop) {
+		t.Errorf("got error %v, want %v", err, errStop)
+	}
+}
@@ -0,0 +1,78 @@
+package ai
+
+import (
+	"context"
+	"sync"
+	"unicode/utf8"
+)
+
+// Usage is the number of tokens spent by one or more model calls.
+type Usage struct {
+	PromptTokens     int
+	CompletionTokens int
+}
+
+func (u Usage) TotalTokens() int {
+	return u.PromptTokens + u.CompletionTokens
+}
+
+// Budget holds the token quotas and prices of AI calls. A zero quota means no
+// limit, and prices are per million tokens.
+type Budget struct {
+	DailyTokens          int64
+	MonthlyTokens        int64
+	GlobalMonthlyTokens  int64
+	PromptTokenPrice     float64
+	CompletionTokenPrice float64
+}
+
+// Cost returns the price of the usage according to the budget prices.
+func (b Budget) Cost(usage Usage) float64 {
+	return (float64(usage.PromptTokens)*b.PromptTokenPrice + float64(usage.CompletionTokens)*b.CompletionTokenPrice) / 1_000_000
+}
+
+// UsageTracker adds up the usage of every model call made with a context
+// returned by TrackUsage. It is safe for concurrent use.
+type UsageTracker struct {
+	mu    sync.Mutex
+	usage Usage
+	calls int
+}
+
+type usageTrackerKey struct{}
+
+// TrackUsage returns a context whose model calls are recorded in the returned
+// tracker.
+func TrackUsage(ctx context.Context) (context.Context, *UsageTracker) {
+	tracker := &UsageTracker{}
+
+	return context.WithValue(ctx, usageTrackerKey{}, tracker), tracker
+}
+
+// Usage returns the usage recorded so far and the number of calls it comes from.
+func (t *UsageTracker) Usage() (Usage, int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.usage, t.calls
+}
+
+// recordUsage adds the usage of a model call to the tracker of ctx, if any.
+func recordUsage(ctx context.Context, usage Usage) {
+	tracker, ok := ctx.Value(usageTrackerKey{}).(*UsageTracker)
+	if !ok {
+		return
+	}
+
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	tracker.usage.PromptTokens += usage.PromptTokens
+	tracker.usage.CompletionTokens += usage.CompletionTokens
+	tracker.calls++
+}
+
+// estimateTokens roughly counts the tokens of a text, for providers that do
+// not report usage.
+func estimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + 3) / 4
+}
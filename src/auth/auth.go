@@ -18,14 +18,26 @@ type Tokens struct {
 	ExpAcc   time.Time
 	ExpRef   time.Time
 	UserID   string
+	FamilyID string
 	Issuer   string
 	Audience string
 }
 
-func IssueTokens(userID string) (*Tokens, error) {
+// Claims are the claims carried by both access and refresh tokens. SessionID
+// holds the session family the token was issued for, so revoking the family
+// invalidates every token minted from the same login. Role is only set on
+// access tokens, refreshing reads it again from the user.
+type Claims struct {
+	SessionID string `json:"sid"`
+	Role      string `json:"role,omitempty"`
+	jwt.RegisteredClaims
+}
+
+func IssueTokens(userID, role, familyID string) (*Tokens, error) {
 	now := time.Now().UTC()
 	t := &Tokens{
 		UserID:   userID,
+		FamilyID: familyID,
 		JTIAcc:   uuid.NewString(),
 		JTIRef:   uuid.NewString(),
 		ExpAcc:   now.Add(2 * time.Hour),
@@ -34,22 +46,29 @@ func IssueTokens(userID string) (*Tokens, error) {
 		Audience: "intelliquiz-client",
 	}
 
-	acc := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
-		Subject:   userID,
-		ID:        t.JTIAcc,
-		Issuer:    t.Issuer,
-		Audience:  jwt.ClaimStrings{t.Audience},
-		IssuedAt:  jwt.NewNumericDate(now),
-		ExpiresAt: jwt.NewNumericDate(t.ExpAcc),
+	acc := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		SessionID: familyID,
+		Role:      role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID,
+			ID:        t.JTIAcc,
+			Issuer:    t.Issuer,
+			Audience:  jwt.ClaimStrings{t.Audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(t.ExpAcc),
+		},
 	})
 
-	ref := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
-		Subject:   userID,
-		ID:        t.JTIRef,
-		Issuer:    t.Issuer,
-		Audience:  jwt.ClaimStrings{t.Audience},
-		IssuedAt:  jwt.NewNumericDate(now),
-		ExpiresAt: jwt.NewNumericDate(t.ExpRef),
+	ref := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		SessionID: familyID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID,
+			ID:        t.JTIRef,
+			Issuer:    t.Issuer,
+			Audience:  jwt.ClaimStrings{t.Audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(t.ExpRef),
+		},
 	})
 
 	var err error
@@ -64,24 +83,24 @@ func IssueTokens(userID string) (*Tokens, error) {
 	return t, nil
 }
 
-func ParseAccess(tokenStr string) (*jwt.RegisteredClaims, error) {
+func ParseAccess(tokenStr string) (*Claims, error) {
 	secret := os.Getenv("JWT_SECRET")
 	return parseWithSecret(tokenStr, secret)
 }
 
-func ParseRefresh(tokenStr string) (*jwt.RegisteredClaims, error) {
+func ParseRefresh(tokenStr string) (*Claims, error) {
 	secret := os.Getenv("JWT_REFRESH_SECRET")
 	return parseWithSecret(tokenStr, secret)
 }
 
-func parseWithSecret(tokenStr, secret string) (*jwt.RegisteredClaims, error) {
+func parseWithSecret(tokenStr, secret string) (*Claims, error) {
 	if secret == "" {
 		return nil, errors.New("jwt secret not configured")
 	}
 
 	parser := jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
 
-	token, err := parser.ParseWithClaims(tokenStr, &jwt.RegisteredClaims{}, func(t *jwt.Token) (interface{}, error) {
+	token, err := parser.ParseWithClaims(tokenStr, &Claims{}, func(t *jwt.Token) (interface{}, error) {
 		// Extra safety: ensure HMAC family
 		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.New("unexpected signing method")
@@ -92,7 +111,7 @@ func parseWithSecret(tokenStr, secret string) (*jwt.RegisteredClaims, error) {
 		return nil, err
 	}
 
-	claims, ok := token.Claims.(*jwt.RegisteredClaims)
+	claims, ok := token.Claims.(*Claims)
 	if !ok || !token.Valid {
 		return nil, errors.New("invalid token")
 	}
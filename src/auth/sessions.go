@@ -0,0 +1,154 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"intelliquiz/src/database/schemas"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrSessionNotFound    = errors.New("session not found")
+	ErrSessionRevoked     = errors.New("session revoked")
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+	ErrUserSuspended      = errors.New("user suspended")
+)
+
+// StartSession opens a new session family for the user and returns its first
+// pair of tokens, the access token carrying the user role.
+func StartSession(ctx context.Context, db *gorm.DB, userID, role string) (*Tokens, error) {
+	tokens, err := IssueTokens(userID, role, uuid.NewString())
+	if err != nil {
+		return nil, err
+	}
+
+	session := schemas.Session{
+		ID:        tokens.JTIRef,
+		FamilyID:  tokens.FamilyID,
+		UserID:    userID,
+		ExpiresAt: tokens.ExpRef,
+	}
+	if err := gorm.G[schemas.Session](db).Create(ctx, &session); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// RotateSession exchanges a refresh token for a new pair of tokens in the same
+// family. Presenting a refresh token that was already rotated is treated as a
+// replay: the whole family is revoked and ErrRefreshTokenReused is returned.
+// The new access token carries the current role of the user, so role changes
+// apply on the next refresh.
+func RotateSession(ctx context.Context, db *gorm.DB, claims *Claims) (*Tokens, error) {
+	var tokens *Tokens
+	err := db.Transaction(func(tx *gorm.DB) error {
+		session, err := gorm.G[schemas.Session](tx, clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", claims.ID).
+			First(ctx)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrSessionNotFound
+			}
+			return err
+		}
+
+		user, err := gorm.G[schemas.User](tx).
+			Where("id = ?", session.UserID).
+			Select("id, role, suspended_at").
+			First(ctx)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrSessionNotFound
+			}
+			return err
+		}
+		if err := checkRotation(session, user, claims); err != nil {
+			return err
+		}
+
+		tokens, err = IssueTokens(session.UserID, user.Role, session.FamilyID)
+		if err != nil {
+			return err
+		}
+
+		next := schemas.Session{
+			ID:        tokens.JTIRef,
+			FamilyID:  session.FamilyID,
+			UserID:    session.UserID,
+			ExpiresAt: tokens.ExpRef,
+		}
+		if err := gorm.G[schemas.Session](tx).Create(ctx, &next); err != nil {
+			return err
+		}
+
+		_, err = gorm.G[schemas.Session](tx).
+			Where("id = ?", session.ID).
+			Update(ctx, "replaced_by", next.ID)
+		return err
+	})
+	if errors.Is(err, ErrRefreshTokenReused) {
+		if revokeErr := RevokeFamily(ctx, db, claims.SessionID); revokeErr != nil {
+			return nil, revokeErr
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// checkRotation reports why the session of the claims can't be rotated for
+// the user, if it can't.
+func checkRotation(session schemas.Session, user schemas.User, claims *Claims) error {
+	switch {
+	case session.UserID != claims.Subject || session.FamilyID != claims.SessionID:
+		return ErrSessionNotFound
+	case session.RevokedAt != nil:
+		return ErrSessionRevoked
+	case session.ReplacedBy != nil:
+		return ErrRefreshTokenReused
+	case user.SuspendedAt != nil:
+		return ErrUserSuspended
+	}
+
+	return nil
+}
+
+// RevokeFamily revokes every refresh token issued from the same login.
+func RevokeFamily(ctx context.Context, db *gorm.DB, familyID string) error {
+	_, err := gorm.G[schemas.Session](db).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update(ctx, "revoked_at", time.Now())
+	return err
+}
+
+// RevokeUserSessions revokes every session that belongs to the user.
+func RevokeUserSessions(ctx context.Context, db *gorm.DB, userID string) error {
+	_, err := gorm.G[schemas.Session](db).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update(ctx, "revoked_at", time.Now())
+	return err
+}
+
+// IsSessionActive reports whether the session family still holds a refresh
+// token that is neither revoked nor expired.
+func IsSessionActive(ctx context.Context, db *gorm.DB, familyID string) (bool, error) {
+	if familyID == "" {
+		return false, nil
+	}
+
+	count, err := gorm.G[schemas.Session](db).
+		Where("family_id = ? AND revoked_at IS NULL AND expires_at > ?", familyID, time.Now()).
+		Count(ctx, "id")
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"intelliquiz/src/database/schemas"
+	"intelliquiz/src/database/testdb"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// testSessionUser connects to the test database, see testdb.Open, and seeds a
+// user removed with its sessions after the test.
+func testSessionUser(t *testing.T) (*gorm.DB, schemas.User) {
+	t.Helper()
+
+	db := testdb.Open(t)
+	t.Setenv("JWT_SECRET", "test-access-secret")
+	t.Setenv("JWT_REFRESH_SECRET", "test-refresh-secret")
+
+	suffix := uuid.NewString()[:8]
+	user := schemas.User{
+		Username: "sessions-" + suffix,
+		Password: "not a hash",
+		Email:    "sessions-" + suffix + "@example.com",
+		Name:     "Sessions",
+	}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("seeding the user: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Where("user_id = ?", user.ID).Delete(&schemas.Session{})
+		db.Unscoped().Delete(&user)
+	})
+
+	return db, user
+}
+
+func TestCheckRotation(t *testing.T) {
+	now := time.Now()
+	replacedBy := uuid.NewString()
+	claims := &Claims{SessionID: "family", RegisteredClaims: jwt.RegisteredClaims{ID: "session", Subject: "user"}}
+	active := schemas.Session{ID: "session", FamilyID: "family", UserID: "user"}
+
+	tests := []struct {
+		name    string
+		session schemas.Session
+		user    schemas.User
+		want    error
+	}{
+		{"active", active, schemas.User{ID: "user"}, nil},
+		{"other user", schemas.Session{ID: "session", FamilyID: "family", UserID: "other"}, schemas.User{ID: "other"}, ErrSessionNotFound},
+		{"other family", schemas.Session{ID: "session", FamilyID: "other", UserID: "user"}, schemas.User{ID: "user"}, ErrSessionNotFound},
+		{"revoked", schemas.Session{ID: "session", FamilyID: "family", UserID: "user", RevokedAt: &now}, schemas.User{ID: "user"}, ErrSessionRevoked},
+		{"replaced", schemas.Session{ID: "session", FamilyID: "family", UserID: "user", ReplacedBy: &replacedBy}, schemas.User{ID: "user"}, ErrRefreshTokenReused},
+		{"replaced and revoked", schemas.Session{ID: "session", FamilyID: "family", UserID: "user", ReplacedBy: &replacedBy, RevokedAt: &now}, schemas.User{ID: "user"}, ErrSessionRevoked},
+		{"suspended", active, schemas.User{ID: "user", SuspendedAt: &now}, ErrUserSuspended},
+		{"replaced and suspended", schemas.Session{ID: "session", FamilyID: "family", UserID: "user", ReplacedBy: &replacedBy}, schemas.User{ID: "user", SuspendedAt: &now}, ErrRefreshTokenReused},
+	}
+
+	for _, test := range tests {
+		if err := checkRotation(test.session, test.user, claims); !errors.Is(err, test.want) {
+			t.Errorf("%s: got error %v, want %v", test.name, err, test.want)
+		}
+	}
+}
+
+// testRotate rotates the session of the refresh token.
+func testRotate(t *testing.T, db *gorm.DB, refresh string) (*Tokens, error) {
+	t.Helper()
+
+	claims, err := ParseRefresh(refresh)
+	if err != nil {
+		t.Fatalf("ParseRefresh: %v", err)
+	}
+	return RotateSession(context.Background(), db, claims)
+}
+
+func TestRotateSessionRevokesFamilyOnReuse(t *testing.T) {
+	db, user := testSessionUser(t)
+	ctx := context.Background()
+
+	first, err := StartSession(ctx, db, user.ID, user.Role)
+	if err != nil {
+		t.Fatalf("StartSession: %v", err)
+	}
+
+	second, err := testRotate(t, db, first.Refresh)
+	if err != nil {
+		t.Fatalf("RotateSession: %v", err)
+	}
+	if second.FamilyID != first.FamilyID {
+		t.Errorf("rotating moved the session to family %q, want %q", second.FamilyID, first.FamilyID)
+	}
+	if active, err := IsSessionActive(ctx, db, first.FamilyID); err != nil || !active {
+		t.Fatalf("IsSessionActive after rotating = %v, %v, want true", active, err)
+	}
+
+	// Replaying the rotated token is taken for a stolen one
+	if _, err := testRotate(t, db, first.Refresh); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("rotating the same token twice: got error %v, want %v", err, ErrRefreshTokenReused)
+	}
+
+	sessions, err := gorm.G[schemas.Session](db).Where("family_id = ?", first.FamilyID).Find(ctx)
+	if err != nil {
+		t.Fatalf("retrieving the sessions: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("got %d sessions in the family, want 2", len(sessions))
+	}
+	for _, session := range sessions {
+		if session.RevokedAt == nil {
+			t.Errorf("session %s was not revoked with its family", session.ID)
+		}
+	}
+
+	if active, err := IsSessionActive(ctx, db, first.FamilyID); err != nil || active {
+		t.Errorf("IsSessionActive after the reuse = %v, %v, want false", active, err)
+	}
+	if _, err := testRotate(t, db, second.Refresh); !errors.Is(err, ErrSessionRevoked) {
+		t.Errorf("rotating the latest token after the reuse: got error %v, want %v", err, ErrSessionRevoked)
+	}
+}
+
+func TestRotateSessionRejectsSuspendedUser(t *testing.T) {
+	db, user := testSessionUser(t)
+	ctx := context.Background()
+
+	tokens, err := StartSession(ctx, db, user.ID, user.Role)
+	if err != nil {
+		t.Fatalf("StartSession: %v", err)
+	}
+
+	if err := db.Model(&user).Update("suspended_at", time.Now()).Error; err != nil {
+		t.Fatalf("suspending the user: %v", err)
+	}
+
+	if _, err := testRotate(t, db, tokens.Refresh); !errors.Is(err, ErrUserSuspended) {
+		t.Errorf("got error %v, want %v", err, ErrUserSuspended)
+	}
+}
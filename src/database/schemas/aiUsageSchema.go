@@ -0,0 +1,29 @@
+package schemas
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AIUsage records the tokens spent by one request to an AI endpoint, which may
+// have made several model calls.
+type AIUsage struct {
+	ID               string     `json:"id,omitempty" gorm:"type:uuid;primaryKey"`
+	UserID           string     `json:"user_id,omitempty" gorm:"type:uuid;not null;index:idx_ai_usage_user_created,priority:1"`
+	User             *User      `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	Endpoint         string     `json:"endpoint" gorm:"size:100;not null"`
+	PromptTokens     int        `json:"prompt_tokens" gorm:"not null"`
+	CompletionTokens int        `json:"completion_tokens" gorm:"not null"`
+	TotalTokens      int        `json:"total_tokens" gorm:"not null"`
+	Cost             float64    `json:"cost" gorm:"not null"`
+	CreatedAt        *time.Time `json:"created_at,omitempty" gorm:"index;index:idx_ai_usage_user_created,priority:2"`
+}
+
+func (u *AIUsage) BeforeCreate(tx *gorm.DB) (err error) {
+	if u.ID == "" {
+		u.ID = uuid.New().String()
+	}
+	return
+}
@@ -7,13 +7,23 @@ import (
 	"gorm.io/gorm"
 )
 
+// Categories are organized in two levels: top-level categories and their
+// subcategories. Slugs identify the categories in URLs.
 type Category struct {
-	ID        string          `json:"id,omitempty" gorm:"type:uuid;primaryKey"`
-	Name      string          `json:"name,omitempty" gorm:"size:40;not null"`
-	Quizzes   []Quiz          `json:"quizzes,omitempty"`
-	CreatedAt *time.Time      `json:"created_at,omitempty"`
-	UpdatedAt *time.Time      `json:"updated_at,omitempty"`
-	DeletedAt *gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
+	ID           string          `json:"id,omitempty" gorm:"type:uuid;primaryKey"`
+	Name         string          `json:"name,omitempty" gorm:"size:40;not null"`
+	Slug         string          `json:"slug,omitempty" gorm:"size:60;uniqueIndex:idx_categories_slug,where:deleted_at IS NULL"`
+	Description  string          `json:"description,omitempty" gorm:"type:text"`
+	IconUrl      string          `json:"icon_url,omitempty"`
+	ImageUrl     string          `json:"image_url,omitempty"`
+	ParentID     *string         `json:"parent_id,omitempty" gorm:"type:uuid;index"`
+	Parent       *Category       `json:"parent,omitempty"`
+	Children     []Category      `json:"children,omitempty" gorm:"foreignKey:ParentID"`
+	Quizzes      []Quiz          `json:"quizzes,omitempty"`
+	QuizzesCount int             `json:"quizzes_count" gorm:"->;-:migration"`
+	CreatedAt    *time.Time      `json:"created_at,omitempty"`
+	UpdatedAt    *time.Time      `json:"updated_at,omitempty"`
+	DeletedAt    *gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
 }
 
 func (c *Category) BeforeCreate(tx *gorm.DB) (err error) {
@@ -13,6 +13,7 @@ type Choice struct {
 	Question   *Question       `json:"question,omitempty"`
 	Content    string          `json:"content,omitempty" gorm:"not null"`
 	IsCorrect  *bool           `json:"is_correct,omitempty" gorm:"not null;default:false"`
+	Position   *uint8          `json:"position,omitempty"`
 	CreatedAt  *time.Time      `json:"created_at,omitempty"`
 	UpdatedAt  *time.Time      `json:"updated_at,omitempty"`
 	DeletedAt  *gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
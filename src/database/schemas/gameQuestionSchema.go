@@ -8,19 +8,23 @@ import (
 )
 
 type GameQuestion struct {
-	ID           string     `json:"id,omitempty" gorm:"type:uuid;primaryKey"`
-	GameID       string     `json:"game_id,omitempty" gorm:"not null"`
-	Game         *Game      `json:"game,omitempty"`
-	QuestionID   string     `json:"question_id,omitempty" gorm:"not null"`
-	Question     *Question  `json:"question,omitempty"`
-	ChoiceID     *string    `json:"choice_id,omitempty"`
-	Choice       *Choice    `json:"choice,omitempty"`
-	Position     uint8      `json:"position" gorm:"not null"`
-	AnsweredAt   *time.Time `json:"answered_at,omitempty"`
-	SecondsTaken uint       `json:"seconds_taken" gorm:"-"`
-	IsCorrect    bool       `json:"is_correct" gorm:"not null default:false"`
-	CreatedAt    *time.Time `json:"created_at,omitempty"`
-	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+	ID             string     `json:"id,omitempty" gorm:"type:uuid;primaryKey"`
+	GameID         string     `json:"game_id,omitempty" gorm:"not null"`
+	Game           *Game      `json:"game,omitempty"`
+	QuestionID     string     `json:"question_id,omitempty" gorm:"not null"`
+	Question       *Question  `json:"question,omitempty"`
+	ChoiceID       *string    `json:"choice_id,omitempty"`
+	Choice         *Choice    `json:"choice,omitempty"`
+	Answer         *string    `json:"answer,omitempty"`
+	Position       uint8      `json:"position" gorm:"not null"`
+	AnsweredAt     *time.Time `json:"answered_at,omitempty"`
+	SecondsTaken   uint       `json:"seconds_taken" gorm:"-"`
+	CorrectChoices []Choice   `json:"correct_choices,omitempty" gorm:"-"`
+	IsCorrect      bool       `json:"is_correct" gorm:"not null default:false"`
+	TimedOut       bool       `json:"timed_out" gorm:"not null;default:false"`
+	Points         uint       `json:"points" gorm:"not null;default:0"`
+	CreatedAt      *time.Time `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
 }
 
 func (q *GameQuestion) BeforeCreate(tx *gorm.DB) (err error) {
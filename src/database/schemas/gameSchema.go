@@ -9,16 +9,17 @@ import (
 
 type Game struct {
 	ID                string          `json:"id,omitempty" gorm:"type:uuid;primaryKey"`
-	UserID            string          `json:"user_id,omitempty" gorm:"not null"`
+	UserID            string          `json:"user_id,omitempty" gorm:"not null;index"`
 	User              *User           `json:"user,omitempty"`
-	QuizID            string          `json:"quiz_id,omitempty" gorm:"not null"`
+	QuizID            string          `json:"quiz_id,omitempty" gorm:"not null;index:idx_games_quiz_id_created_at,priority:1"`
 	Quiz              *Quiz           `json:"quiz,omitempty"`
 	FinishedAt        *time.Time      `json:"finished_at,omitempty"`
 	GameQuestions     []GameQuestion  `json:"game_questions,omitempty"`
 	TotalQuestions    uint            `json:"total_questions" gorm:"-"`
 	CorrectAnswers    uint            `json:"correct_answers" gorm:"-"`
 	TotalSecondsTaken uint            `json:"total_seconds_taken" gorm:"-"`
-	CreatedAt         *time.Time      `json:"created_at,omitempty"`
+	Score             uint            `json:"score" gorm:"not null;default:0"`
+	CreatedAt         *time.Time      `json:"created_at,omitempty" gorm:"index:idx_games_quiz_id_created_at,priority:2"`
 	UpdatedAt         *time.Time      `json:"updated_at,omitempty"`
 	DeletedAt         *gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
 }
@@ -17,6 +17,12 @@ func Run(db *gorm.DB, fresh *bool) error {
 			&Game{},
 			&GameQuestion{},
 			&Choice{},
+			&Session{},
+			&QuizDraft{},
+			&AIUsage{},
+			&Report{},
+			&Notification{},
+			&QuizSimilarity{},
 		)
 		if err != nil {
 			fmt.Println("Error dropping tables:", err)
@@ -33,6 +39,12 @@ func Run(db *gorm.DB, fresh *bool) error {
 		&Game{},
 		&GameQuestion{},
 		&Choice{},
+		&Session{},
+		&QuizDraft{},
+		&AIUsage{},
+		&Report{},
+		&Notification{},
+		&QuizSimilarity{},
 	)
 	if err != nil {
 		fmt.Println("Error during auto migration:", err)
@@ -45,5 +57,10 @@ func Run(db *gorm.DB, fresh *bool) error {
 		return err
 	}
 
+	if err := setupQuizSearch(db); err != nil {
+		fmt.Println("Error setting up quiz search:", err)
+		return err
+	}
+
 	return nil
 }
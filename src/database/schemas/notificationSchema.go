@@ -0,0 +1,34 @@
+package schemas
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	NotificationReportResolved = "report_resolved"
+	NotificationQuizModerated  = "quiz_moderated"
+)
+
+// Notification is a message for a user about something that happened to their
+// content or reports, shown until it is read.
+type Notification struct {
+	ID        string     `json:"id,omitempty" gorm:"type:uuid;primaryKey"`
+	UserID    string     `json:"user_id,omitempty" gorm:"type:uuid;not null;index:idx_notifications_user_created,priority:1"`
+	User      *User      `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	Kind      string     `json:"kind,omitempty" gorm:"size:30;not null"`
+	Message   string     `json:"message,omitempty" gorm:"size:1000;not null"`
+	ReportID  *string    `json:"report_id,omitempty" gorm:"type:uuid"`
+	QuizID    *string    `json:"quiz_id,omitempty" gorm:"type:uuid"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty" gorm:"index:idx_notifications_user_created,priority:2"`
+}
+
+func (n *Notification) BeforeCreate(tx *gorm.DB) (err error) {
+	if n.ID == "" {
+		n.ID = uuid.New().String()
+	}
+	return
+}
@@ -8,21 +8,46 @@ import (
 	"gorm.io/gorm/clause"
 )
 
+const (
+	QuestionTypeSingleChoice   = "single_choice"
+	QuestionTypeMultipleSelect = "multiple_select"
+	QuestionTypeTrueFalse      = "true_false"
+	QuestionTypeOrdering       = "ordering"
+	QuestionTypeFreeText       = "free_text"
+	QuestionTypeNumeric        = "numeric"
+)
+
+// Question choices are interpreted according to the question type: ordering
+// questions use the choice positions as the correct order and free-text
+// questions store their accepted answers as correct choices.
 type Question struct {
-	ID        string          `json:"id,omitempty" gorm:"type:uuid;primaryKey"`
-	Content   string          `json:"content,omitempty" gorm:"not null"`
-	QuizID    string          `json:"quiz_id,omitempty" gorm:"not null"`
-	Quiz      *Quiz           `json:"quiz,omitempty"`
-	Choices   []Choice        `json:"choices,omitempty"`
-	CreatedAt *time.Time      `json:"created_at,omitempty"`
-	UpdatedAt *time.Time      `json:"updated_at,omitempty"`
-	DeletedAt *gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
+	ID               string          `json:"id,omitempty" gorm:"type:uuid;primaryKey"`
+	Content          string          `json:"content,omitempty" gorm:"not null"`
+	Type             string          `json:"type,omitempty" gorm:"size:20;not null;default:single_choice"`
+	QuizID           string          `json:"quiz_id,omitempty" gorm:"not null;index"`
+	Quiz             *Quiz           `json:"quiz,omitempty"`
+	Choices          []Choice        `json:"choices,omitempty"`
+	NumericAnswer    *float64        `json:"numeric_answer,omitempty"`
+	NumericTolerance *float64        `json:"numeric_tolerance,omitempty"`
+	Explanation      string          `json:"explanation,omitempty" gorm:"type:text"`
+	CreatedAt        *time.Time      `json:"created_at,omitempty"`
+	UpdatedAt        *time.Time      `json:"updated_at,omitempty"`
+	DeletedAt        *gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
+}
+
+// HasHiddenChoices reports whether the question choices must not be shown to
+// players, as they hold the accepted answers.
+func (q *Question) HasHiddenChoices() bool {
+	return q.Type == QuestionTypeFreeText || q.Type == QuestionTypeNumeric
 }
 
 func (q *Question) BeforeCreate(tx *gorm.DB) (err error) {
 	if q.ID == "" {
 		q.ID = uuid.New().String()
 	}
+	if q.Type == "" {
+		q.Type = QuestionTypeSingleChoice
+	}
 	return
 }
 
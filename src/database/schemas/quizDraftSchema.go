@@ -0,0 +1,60 @@
+package schemas
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	QuizDraftSourceQuizAI     = "generate-quiz"
+	QuizDraftSourceQuestionAI = "generate-question"
+	QuizDraftSourceTextAI     = "generate-quiz-from-text"
+)
+
+// QuizDraft holds AI generated quiz content until its author edits and
+// publishes it as a quiz. Drafts are not validated until they are published,
+// so any field may be incomplete.
+type QuizDraft struct {
+	ID                string              `json:"id,omitempty" gorm:"type:uuid;primaryKey"`
+	CreatedBy         string              `json:"created_by,omitempty" gorm:"type:uuid;not null;index"`
+	User              *User               `json:"user,omitempty" gorm:"foreignKey:CreatedBy"`
+	Source            string              `json:"source,omitempty" gorm:"size:30;not null"`
+	Name              string              `json:"name" gorm:"size:60"`
+	CategoryID        *string             `json:"category_id,omitempty" gorm:"type:uuid"`
+	ImageUrl          string              `json:"image_url,omitempty"`
+	QuestionTimeLimit *uint               `json:"question_time_limit,omitempty"`
+	Questions         []QuizDraftQuestion `json:"questions" gorm:"type:jsonb;serializer:json;not null"`
+	CreatedAt         *time.Time          `json:"created_at,omitempty"`
+	UpdatedAt         *time.Time          `json:"updated_at,omitempty"`
+	DeletedAt         *gorm.DeletedAt     `json:"deleted_at,omitempty" gorm:"index"`
+}
+
+// QuizDraftQuestion mirrors the question payload of quiz creation, plus the
+// source excerpt of questions generated from source material.
+type QuizDraftQuestion struct {
+	Content          string            `json:"content"`
+	Type             string            `json:"type,omitempty"`
+	Choices          []QuizDraftChoice `json:"choices,omitempty"`
+	AcceptedAnswers  []string          `json:"accepted_answers,omitempty"`
+	NumericAnswer    *float64          `json:"numeric_answer,omitempty"`
+	NumericTolerance *float64          `json:"numeric_tolerance,omitempty"`
+	Explanation      string            `json:"explanation,omitempty"`
+	SourceExcerpt    string            `json:"source_excerpt,omitempty"`
+}
+
+type QuizDraftChoice struct {
+	Content   string `json:"content"`
+	IsCorrect bool   `json:"is_correct"`
+}
+
+func (d *QuizDraft) BeforeCreate(tx *gorm.DB) (err error) {
+	if d.ID == "" {
+		d.ID = uuid.New().String()
+	}
+	if d.Questions == nil {
+		d.Questions = []QuizDraftQuestion{}
+	}
+	return
+}
@@ -8,24 +8,43 @@ import (
 	"gorm.io/gorm/clause"
 )
 
+const (
+	ModerationApproved = "approved"
+	ModerationFlagged  = "flagged"
+	ModerationHidden   = "hidden"
+)
+
+// Quiz content flagged by moderation is kept out of the public listings until
+// it is reviewed, and quizzes hidden by an admin stay out of them.
+//
+// Likes, GamesPlayed, GamesFinished and RecentPlays are counters kept up to
+// date as quizzes are liked and played, never written along with the rest of
+// the quiz. QuestionsCount is only written along with a new quiz, and kept up
+// to date as questions are added and deleted.
 type Quiz struct {
-	ID          string          `json:"id,omitempty" gorm:"type:uuid;primaryKey"`
-	Name        string          `json:"name,omitempty" gorm:"size:60;not null"`
-	CategoryID  string          `json:"category_id,omitempty" gorm:"not null"`
-	Category    *Category       `json:"category,omitempty"`
-	CreatedBy   string          `json:"created_by,omitempty"`
-	User        *User           `json:"user,omitempty" gorm:"foreignKey:CreatedBy"`
-	UserLikes   []*User         `json:"user_likes,omitempty" gorm:"many2many:quiz_user_likes;"`
-	Likes       int             `json:"likes" gorm:"->;-:migration"`
-	Score       float32         `json:"score,omitempty" gorm:"->;-:migration"`
-	CuratorPick bool            `json:"curator_pick" gorm:"not null;default:false"`
-	Questions   []Question      `json:"questions,omitempty"`
-	Games       []Game          `json:"games,omitempty"`
-	GamesPlayed int             `json:"games_played" gorm:"->;-:migration"`
-	ImageUrl    string          `json:"image_url,omitempty"`
-	CreatedAt   *time.Time      `json:"created_at,omitempty"`
-	UpdatedAt   *time.Time      `json:"updated_at,omitempty"`
-	DeletedAt   *gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
+	ID                string          `json:"id,omitempty" gorm:"type:uuid;primaryKey"`
+	Name              string          `json:"name,omitempty" gorm:"size:60;not null;index"`
+	CategoryID        string          `json:"category_id,omitempty" gorm:"not null;index"`
+	Category          *Category       `json:"category,omitempty"`
+	CreatedBy         string          `json:"created_by,omitempty" gorm:"index"`
+	User              *User           `json:"user,omitempty" gorm:"foreignKey:CreatedBy"`
+	UserLikes         []*User         `json:"user_likes,omitempty" gorm:"many2many:quiz_user_likes;"`
+	Likes             int             `json:"likes" gorm:"not null;default:0;<-:false;index"`
+	Score             float32         `json:"score,omitempty" gorm:"->;-:migration"`
+	CuratorPick       bool            `json:"curator_pick" gorm:"not null;default:false;index:,where:curator_pick"`
+	ModerationStatus  string          `json:"moderation_status,omitempty" gorm:"size:20;not null;default:approved;index"`
+	ModerationReason  string          `json:"moderation_reason,omitempty" gorm:"type:text"`
+	Questions         []Question      `json:"questions,omitempty"`
+	Games             []Game          `json:"games,omitempty"`
+	GamesPlayed       int             `json:"games_played" gorm:"not null;default:0;<-:false;index"`
+	GamesFinished     int             `json:"-" gorm:"not null;default:0;<-:false;index"`
+	RecentPlays       int             `json:"-" gorm:"not null;default:0;<-:false;index"`
+	QuestionsCount    int             `json:"-" gorm:"not null;default:0;<-:create;index"`
+	ImageUrl          string          `json:"image_url,omitempty"`
+	QuestionTimeLimit *uint           `json:"question_time_limit,omitempty"`
+	CreatedAt         *time.Time      `json:"created_at,omitempty" gorm:"index"`
+	UpdatedAt         *time.Time      `json:"updated_at,omitempty"`
+	DeletedAt         *gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
 }
 
 func (q *Quiz) BeforeCreate(tx *gorm.DB) (err error) {
@@ -0,0 +1,131 @@
+package schemas
+
+import (
+	"gorm.io/gorm"
+)
+
+// Quizzes are searched through the search_vector column, a tsvector of the
+// quiz name, its author, its category and the content of its questions. The
+// text is indexed with the Portuguese and English configurations to match
+// the words in both languages, plus the simple one to match names and words
+// no dictionary knows, all of them ignoring accents.
+//
+// The column is kept up to date by triggers, as questions, authors and
+// categories change in their own tables.
+var quizSearchMigrations = []string{
+	`CREATE EXTENSION IF NOT EXISTS unaccent`,
+
+	`DO $$
+	DECLARE
+		config record;
+	BEGIN
+		FOR config IN SELECT * FROM (VALUES ('simple', 'simple'), ('portuguese', 'portuguese_stem'), ('english', 'english_stem')) AS configs(name, dictionary) LOOP
+			IF NOT EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = 'intelliquiz_' || config.name) THEN
+				EXECUTE format('CREATE TEXT SEARCH CONFIGURATION intelliquiz_%s (COPY = %s)', config.name, config.name);
+				EXECUTE format('ALTER TEXT SEARCH CONFIGURATION intelliquiz_%s ALTER MAPPING FOR hword, hword_part, word WITH unaccent, %s', config.name, config.dictionary);
+			END IF;
+		END LOOP;
+	END
+	$$`,
+
+	`CREATE OR REPLACE FUNCTION quiz_search_text(content text) RETURNS tsvector
+	LANGUAGE sql IMMUTABLE AS $$
+		SELECT to_tsvector('intelliquiz_simple', coalesce(content, ''))
+			|| to_tsvector('intelliquiz_portuguese', coalesce(content, ''))
+			|| to_tsvector('intelliquiz_english', coalesce(content, ''))
+	$$`,
+
+	`CREATE OR REPLACE FUNCTION quiz_search_document(quiz_id uuid, quiz_name text, quiz_category_id uuid, quiz_created_by uuid) RETURNS tsvector
+	LANGUAGE sql STABLE AS $$
+		SELECT setweight(quiz_search_text(quiz_name), 'A')
+			|| setweight(quiz_search_text((SELECT concat_ws(' ', username, name) FROM users WHERE id = quiz_created_by)), 'B')
+			|| setweight(quiz_search_text((SELECT name FROM categories WHERE id = quiz_category_id)), 'B')
+			|| setweight(quiz_search_text((
+				SELECT string_agg(content, ' ')
+				FROM questions
+				WHERE questions.quiz_id = quiz_search_document.quiz_id AND questions.deleted_at IS NULL
+			)), 'C')
+	$$`,
+
+	`CREATE OR REPLACE FUNCTION refresh_quiz_search_vector(quiz uuid) RETURNS void
+	LANGUAGE sql AS $$
+		UPDATE quizzes
+		SET search_vector = quiz_search_document(id, name, category_id, created_by)
+		WHERE id = quiz
+	$$`,
+
+	`ALTER TABLE quizzes ADD COLUMN IF NOT EXISTS search_vector tsvector`,
+	`CREATE INDEX IF NOT EXISTS idx_quizzes_search_vector ON quizzes USING GIN (search_vector)`,
+
+	`CREATE OR REPLACE FUNCTION quizzes_search_vector_trigger() RETURNS trigger
+	LANGUAGE plpgsql AS $$
+	BEGIN
+		NEW.search_vector := quiz_search_document(NEW.id, NEW.name, NEW.category_id, NEW.created_by);
+		RETURN NEW;
+	END
+	$$`,
+	`DROP TRIGGER IF EXISTS quizzes_search_vector ON quizzes`,
+	`CREATE TRIGGER quizzes_search_vector
+	BEFORE INSERT OR UPDATE OF name, category_id, created_by ON quizzes
+	FOR EACH ROW EXECUTE FUNCTION quizzes_search_vector_trigger()`,
+
+	`CREATE OR REPLACE FUNCTION questions_search_vector_trigger() RETURNS trigger
+	LANGUAGE plpgsql AS $$
+	BEGIN
+		IF TG_OP <> 'INSERT' THEN
+			PERFORM refresh_quiz_search_vector(OLD.quiz_id);
+		END IF;
+		IF TG_OP = 'INSERT' OR (TG_OP = 'UPDATE' AND NEW.quiz_id IS DISTINCT FROM OLD.quiz_id) THEN
+			PERFORM refresh_quiz_search_vector(NEW.quiz_id);
+		END IF;
+		RETURN NULL;
+	END
+	$$`,
+	`DROP TRIGGER IF EXISTS questions_search_vector ON questions`,
+	`CREATE TRIGGER questions_search_vector
+	AFTER INSERT OR DELETE OR UPDATE OF content, quiz_id, deleted_at ON questions
+	FOR EACH ROW EXECUTE FUNCTION questions_search_vector_trigger()`,
+
+	`CREATE OR REPLACE FUNCTION users_search_vector_trigger() RETURNS trigger
+	LANGUAGE plpgsql AS $$
+	BEGIN
+		UPDATE quizzes
+		SET search_vector = quiz_search_document(id, name, category_id, created_by)
+		WHERE created_by = NEW.id;
+		RETURN NULL;
+	END
+	$$`,
+	`DROP TRIGGER IF EXISTS users_search_vector ON users`,
+	`CREATE TRIGGER users_search_vector
+	AFTER UPDATE OF name, username ON users
+	FOR EACH ROW EXECUTE FUNCTION users_search_vector_trigger()`,
+
+	`CREATE OR REPLACE FUNCTION categories_search_vector_trigger() RETURNS trigger
+	LANGUAGE plpgsql AS $$
+	BEGIN
+		UPDATE quizzes
+		SET search_vector = quiz_search_document(id, name, category_id, created_by)
+		WHERE category_id = NEW.id;
+		RETURN NULL;
+	END
+	$$`,
+	`DROP TRIGGER IF EXISTS categories_search_vector ON categories`,
+	`CREATE TRIGGER categories_search_vector
+	AFTER UPDATE OF name ON categories
+	FOR EACH ROW EXECUTE FUNCTION categories_search_vector_trigger()`,
+
+	// Fills the column of the quizzes created before it existed
+	`UPDATE quizzes
+	SET search_vector = quiz_search_document(id, name, category_id, created_by)
+	WHERE search_vector IS NULL`,
+}
+
+func setupQuizSearch(db *gorm.DB) error {
+	for _, migration := range quizSearchMigrations {
+		if err := db.Exec(migration).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
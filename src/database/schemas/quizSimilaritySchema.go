@@ -0,0 +1,16 @@
+package schemas
+
+import (
+	"time"
+)
+
+// QuizSimilarity is how alike a quiz is to another one by the users who liked
+// or finished both, from 0 to 1. It is recomputed in the background, keeping
+// only the most similar quizzes of each quiz.
+type QuizSimilarity struct {
+	QuizID        string    `json:"quiz_id" gorm:"type:uuid;primaryKey"`
+	SimilarQuizID string    `json:"similar_quiz_id" gorm:"type:uuid;primaryKey;index"`
+	Score         float64   `json:"score" gorm:"not null"`
+	Users         int       `json:"users" gorm:"not null"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
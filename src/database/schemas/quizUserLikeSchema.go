@@ -8,7 +8,7 @@ import (
 
 type QuizUserLike struct {
 	QuizID    string    `json:"quiz_id" gorm:"type:uuid;primaryKey;not null"`
-	UserID    string    `json:"user_id" gorm:"type:uuid;primaryKey;not null"`
+	UserID    string    `json:"user_id" gorm:"type:uuid;primaryKey;not null;index"`
 	CreatedAt time.Time `json:"created_at" gorm:"not null"`
 }
 
@@ -0,0 +1,53 @@
+package schemas
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	ReportReasonWrongAnswer = "wrong_answer"
+	ReportReasonOffensive   = "offensive"
+	ReportReasonSpam        = "spam"
+	ReportReasonCopyright   = "copyright"
+	ReportReasonOther       = "other"
+
+	ReportStatusOpen      = "open"
+	ReportStatusResolved  = "resolved"
+	ReportStatusDismissed = "dismissed"
+
+	ReportActionNone   = "none"
+	ReportActionHide   = "hide"
+	ReportActionDelete = "delete"
+)
+
+// Report is a player complaint about a quiz, or about one of its questions
+// when QuestionID is set, waiting for an admin to resolve it.
+type Report struct {
+	ID             string          `json:"id,omitempty" gorm:"type:uuid;primaryKey"`
+	ReporterID     string          `json:"reporter_id,omitempty" gorm:"type:uuid;not null;index"`
+	Reporter       *User           `json:"reporter,omitempty" gorm:"foreignKey:ReporterID"`
+	QuizID         string          `json:"quiz_id,omitempty" gorm:"type:uuid;not null;index"`
+	Quiz           *Quiz           `json:"quiz,omitempty"`
+	QuestionID     *string         `json:"question_id,omitempty" gorm:"type:uuid;index"`
+	Question       *Question       `json:"question,omitempty"`
+	Reason         string          `json:"reason,omitempty" gorm:"size:20;not null"`
+	Comment        string          `json:"comment,omitempty" gorm:"size:500"`
+	Status         string          `json:"status,omitempty" gorm:"size:20;not null;default:open;index"`
+	Action         string          `json:"action,omitempty" gorm:"size:20"`
+	ResolutionNote string          `json:"resolution_note,omitempty" gorm:"size:500"`
+	ResolvedBy     *string         `json:"resolved_by,omitempty" gorm:"type:uuid"`
+	ResolvedAt     *time.Time      `json:"resolved_at,omitempty"`
+	CreatedAt      *time.Time      `json:"created_at,omitempty" gorm:"index"`
+	UpdatedAt      *time.Time      `json:"updated_at,omitempty"`
+	DeletedAt      *gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
+}
+
+func (r *Report) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	return
+}
@@ -0,0 +1,29 @@
+package schemas
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Session is a persisted refresh token. Its ID is the refresh token JTI and
+// every token rotated from the same login shares the same FamilyID.
+type Session struct {
+	ID         string     `json:"id,omitempty" gorm:"type:uuid;primaryKey"`
+	FamilyID   string     `json:"family_id,omitempty" gorm:"type:uuid;not null;index"`
+	UserID     string     `json:"user_id,omitempty" gorm:"type:uuid;not null;index"`
+	User       *User      `json:"user,omitempty"`
+	ReplacedBy *string    `json:"replaced_by,omitempty" gorm:"type:uuid"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"not null"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
+
+func (s *Session) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == "" {
+		s.ID = uuid.New().String()
+	}
+	return
+}
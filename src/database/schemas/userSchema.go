@@ -7,21 +7,35 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	RoleUser    = "user"
+	RoleCurator = "curator"
+	RoleAdmin   = "admin"
+)
+
+// Curators pick the featured quizzes and manage the categories, admins can
+// also moderate content and manage the users. Suspended users cannot log in.
 type User struct {
-	ID           string          `json:"id,omitempty" gorm:"type:uuid;primaryKey"`
-	Username     string          `json:"username,omitempty" gorm:"size:50;uniqueIndex;not null"`
-	Password     string          `json:"password,omitempty" gorm:"size:255;not null"`
-	Email        string          `json:"email,omitempty" gorm:"size:254;uniqueIndex;not null"`
-	Name         string          `json:"name,omitempty" gorm:"size:60;not null"`
-	QuizzesLiked []*Quiz         `json:"quizzes_liked,omitempty" gorm:"many2many:quiz_user_likes;"`
-	CreatedAt    *time.Time      `json:"created_at,omitempty"`
-	UpdatedAt    *time.Time      `json:"updated_at,omitempty"`
-	DeletedAt    *gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
+	ID               string          `json:"id,omitempty" gorm:"type:uuid;primaryKey"`
+	Username         string          `json:"username,omitempty" gorm:"size:50;uniqueIndex;not null"`
+	Password         string          `json:"password,omitempty" gorm:"size:255;not null"`
+	Email            string          `json:"email,omitempty" gorm:"size:254;uniqueIndex;not null"`
+	Name             string          `json:"name,omitempty" gorm:"size:60;not null"`
+	Role             string          `json:"role,omitempty" gorm:"size:20;not null;default:user"`
+	SuspendedAt      *time.Time      `json:"suspended_at,omitempty"`
+	SuspensionReason string          `json:"suspension_reason,omitempty" gorm:"type:text"`
+	QuizzesLiked     []*Quiz         `json:"quizzes_liked,omitempty" gorm:"many2many:quiz_user_likes;"`
+	CreatedAt        *time.Time      `json:"created_at,omitempty"`
+	UpdatedAt        *time.Time      `json:"updated_at,omitempty"`
+	DeletedAt        *gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
 }
 
 func (u *User) BeforeCreate(tx *gorm.DB) (err error) {
 	if u.ID == "" {
 		u.ID = uuid.New().String()
 	}
+	if u.Role == "" {
+		u.Role = RoleUser
+	}
 	return
 }
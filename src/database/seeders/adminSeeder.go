@@ -0,0 +1,20 @@
+package seeders
+
+import (
+	"intelliquiz/src/database/schemas"
+
+	"gorm.io/gorm"
+)
+
+// AdminsSeeding promotes the given users to admins, so the first admins can
+// be set up before anyone is able to change roles through the API.
+func AdminsSeeding(db *gorm.DB, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	return db.Model(&schemas.User{}).
+		Where("id IN ?", userIDs).
+		Update("role", schemas.RoleAdmin).
+		Error
+}
@@ -1,7 +1,9 @@
 package seeders
 
 import (
+	"context"
 	"intelliquiz/src/database/schemas"
+	"intelliquiz/src/utils"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -25,6 +27,37 @@ func CategoriesSeeding(db *gorm.DB) {
 		db.Create(&schemas.Category{
 			ID:   uuid.NewString(),
 			Name: name,
+			Slug: utils.Slugify(name),
 		})
 	}
 }
+
+// CategorySlugsSeeding gives a slug to the categories created before
+// categories had slugs.
+func CategorySlugsSeeding(db *gorm.DB) error {
+	ctx := context.Background()
+
+	categories, err := gorm.G[schemas.Category](db).
+		Where("slug IS NULL OR slug = ''").
+		Select("id, name").
+		Find(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, category := range categories {
+		slug, err := utils.UniqueCategorySlug(ctx, db, category.Name, category.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = gorm.G[schemas.Category](db).
+			Where("id = ?", category.ID).
+			Update(ctx, "slug", slug)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+// Package testdb connects the tests that need Postgres to a real database.
+package testdb
+
+import (
+	"intelliquiz/src/database/schemas"
+	"os"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Open connects to the database given by TEST_DATABASE_URL and migrates it,
+// skipping the test when the variable is not set. The tests clean up the
+// records they seed.
+func Open(t testing.TB) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("gorm.Open: %v", err)
+	}
+	fresh := false
+	if err := schemas.Run(db, &fresh); err != nil {
+		t.Fatalf("migrating the database: %v", err)
+	}
+
+	return db
+}
@@ -19,32 +19,35 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/ai/autocomplete-choice": {
+        "/admin/categories": {
             "post": {
-                "description": "Autocomplete the content of a quiz choice",
+                "description": "Create a new category, or a subcategory when given a parent. Subcategories cannot have subcategories of their own. The slug is made from the name when not given. Category names are unique among their siblings, ignoring case. Curators and admins only.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ai"
+                    "admin"
                 ],
-                "summary": "Autocomplete Choice Content",
+                "summary": "Create a category",
                 "parameters": [
                     {
-                        "description": "Autocomplete Choice Request Body",
+                        "description": "Category Request Body",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.AutocompleteChoiceRequestDTO"
+                            "$ref": "#/definitions/intelliquiz_src_types.CategoryRequestBody"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.AutocompleteChoiceSuccessResponseDTO"
+                            "$ref": "#/definitions/intelliquiz_src_types.CategorySuccessResponseStruct"
                         }
                     },
                     "400": {
@@ -68,32 +71,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/ai/autocomplete-question": {
-            "post": {
-                "description": "Autocomplete the content of a quiz question",
+        "/admin/categories/{categoryId}": {
+            "delete": {
+                "description": "Delete a category. Categories that still have quizzes or subcategories cannot be deleted. Curators and admins only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ai"
+                    "admin"
                 ],
-                "summary": "Autocomplete Question Content",
+                "summary": "Delete a category",
                 "parameters": [
                     {
-                        "description": "Autocomplete Question Request Body",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.AutocompleteQuestionRequestDTO"
-                        }
+                        "type": "string",
+                        "description": "Category ID",
+                        "name": "categoryId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.AutocompleteQuestionSuccessResponseDTO"
+                            "$ref": "#/definitions/intelliquiz_src_types.SuccessResponseStruct"
                         }
                     },
                     "400": {
@@ -108,6 +109,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -115,26 +122,34 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/ai/autocomplete-quiz": {
-            "post": {
-                "description": "Autocomplete the title of a quiz",
+            },
+            "patch": {
+                "description": "Update the given fields of a category. Renaming a category keeps its slug. Categories with subcategories cannot become subcategories. Curators and admins only.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ai"
+                    "admin"
                 ],
-                "summary": "Autocomplete Quiz Title",
+                "summary": "Update a category",
                 "parameters": [
                     {
-                        "description": "Autocomplete Quiz Request Body",
+                        "type": "string",
+                        "description": "Category ID",
+                        "name": "categoryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Category Request Body",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.AutocompleteQuizRequestDTO"
+                            "$ref": "#/definitions/intelliquiz_src_types.UpdateCategoryRequestBody"
                         }
                     }
                 ],
@@ -142,7 +157,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.AutocompleteQuizSuccessResponseDTO"
+                            "$ref": "#/definitions/intelliquiz_src_types.SuccessResponseStruct"
                         }
                     },
                     "400": {
@@ -157,6 +172,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -166,38 +187,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/ai/generate-question": {
-            "post": {
-                "description": "Generate a complete question with multiple choices using AI",
+        "/admin/quizzes/flagged": {
+            "get": {
+                "description": "Get the quizzes quarantined by automatic moderation and waiting for review, oldest first. Admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ai"
+                    "admin"
                 ],
-                "summary": "Generate Full Question with Choices",
+                "summary": "Get flagged quizzes",
                 "parameters": [
                     {
-                        "description": "Generate Question Request Body",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.GenerateQuestionRequestDTO"
-                        }
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Page number (starting from 0)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit of quizzes per page (min: 5, max: 50)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.GenerateQuestionSuccessResponseDTO"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.GetFlaggedQuizzesSuccessResponseStruct"
                         }
                     },
                     "403": {
@@ -206,12 +226,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -221,24 +235,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/ai/generate-quiz": {
-            "post": {
-                "description": "Generate a complete quiz with title, questions and choices using AI",
+        "/admin/quizzes/{quizId}/curator-pick": {
+            "patch": {
+                "description": "Feature a quiz as a curator pick or remove it from the picks. Only approved quizzes can be picked. Curators and admins only.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ai"
+                    "admin"
                 ],
-                "summary": "Generate Full Quiz with Questions and Choices",
+                "summary": "Set whether a quiz is a curator pick",
                 "parameters": [
                     {
-                        "description": "Generate Quiz Request Body",
+                        "type": "string",
+                        "description": "Quiz ID",
+                        "name": "quizId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Set Curator Pick Request Body",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.GenerateQuizRequestDTO"
+                            "$ref": "#/definitions/intelliquiz_src_types.SetCuratorPickRequestBody"
                         }
                     }
                 ],
@@ -246,7 +270,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.GenerateQuizSuccessResponseDTO"
+                            "$ref": "#/definitions/intelliquiz_src_types.SuccessResponseStruct"
                         }
                     },
                     "400": {
@@ -276,62 +300,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/categories": {
-            "get": {
-                "description": "Retrieve a list of all categories",
-                "produces": [
+        "/admin/quizzes/{quizId}/moderation": {
+            "patch": {
+                "description": "Approve a quiz, making it public again, or hide it from the public listings. The quiz author is notified of the decision. Admin only.",
+                "consumes": [
                     "application/json"
                 ],
-                "tags": [
-                    "categories"
-                ],
-                "summary": "Get all categories",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.GetCategoriesSuccessResponseStruct"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
-                        }
-                    }
-                }
-            }
-        },
-        "/categories/{categoryId}": {
-            "get": {
-                "description": "Retrieve a category by its ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "admin"
                 ],
-                "summary": "Get a category by ID",
+                "summary": "Review the moderation of a quiz",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Category ID",
-                        "name": "id",
+                        "description": "Quiz ID",
+                        "name": "quizId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Review Quiz Moderation Request Body",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ReviewQuizModerationRequestBody"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.GetCategorySuccessResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.SuccessResponseStruct"
                         }
                     },
                     "400": {
@@ -361,30 +365,49 @@ const docTemplate = `{
                 }
             }
         },
-        "/choices/{choiceId}": {
+        "/admin/reports": {
             "get": {
-                "description": "Retrieve a choice by its ID",
+                "description": "Get the reports with the given status, oldest first, along with the reported content and its reporter. Admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "choices"
+                    "admin"
                 ],
-                "summary": "Get a choice by ID",
+                "summary": "Get reports",
                 "parameters": [
                     {
+                        "enum": [
+                            "open",
+                            "resolved",
+                            "dismissed"
+                        ],
                         "type": "string",
-                        "description": "Choice ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": "open",
+                        "description": "Report status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Page number (starting from 0)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit of reports per page (min: 5, max: 50)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.GetChoiceSuccessResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.GetReportsSuccessResponseStruct"
                         }
                     },
                     "400": {
@@ -399,12 +422,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -412,30 +429,44 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a choice by its ID",
+            }
+        },
+        "/admin/reports/{reportId}/resolve": {
+            "post": {
+                "description": "Close a report, optionally hiding the reported quiz or deleting the reported quiz or question. Every open report on the same content is closed with it, and their reporters are notified of the outcome. The quiz author is notified when their content is hidden or deleted. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "choices"
+                    "admin"
                 ],
-                "summary": "Delete a choice by ID",
+                "summary": "Resolve a report",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Choice ID",
-                        "name": "id",
+                        "description": "Report ID",
+                        "name": "reportId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Resolve Report Request Body",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ResolveReportRequestBody"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.SuccessResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.ResolveReportSuccessResponseStruct"
                         }
                     },
                     "400": {
@@ -463,9 +494,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/users/{userId}/role": {
             "patch": {
-                "description": "Update a choice by its ID",
+                "description": "Change the role of a user. The user sessions are revoked when the role changes, so the new role applies right away. Admins cannot change their own role. Admin only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -473,24 +506,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "choices"
+                    "admin"
                 ],
-                "summary": "Update a choice by ID",
+                "summary": "Change the role of a user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Choice ID",
-                        "name": "id",
+                        "description": "User ID",
+                        "name": "userId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update Choice Request Body",
+                        "description": "Update User Role Request Body",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.UpdateChoiceRequestBody"
+                            "$ref": "#/definitions/intelliquiz_src_types.UpdateUserRoleRequestBody"
                         }
                     }
                 ],
@@ -528,37 +561,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/games/{gameId}/answer/{choiceId}": {
+        "/admin/users/{userId}/suspend": {
             "post": {
-                "description": "Submit an answer for the current question in a game session",
+                "description": "Suspend a user, revoking their sessions and keeping them from logging in until unsuspended. Admins cannot be suspended. Admin only.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "games"
+                    "admin"
                 ],
-                "summary": "Answer a question in a game",
+                "summary": "Suspend a user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Game ID",
-                        "name": "gameId",
+                        "description": "User ID",
+                        "name": "userId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Choice ID",
-                        "name": "choiceId",
-                        "in": "path",
-                        "required": true
+                        "description": "Suspend User Request Body",
+                        "name": "data",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.SuspendUserRequestBody"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.AnswerQuestionResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.SuccessResponseStruct"
                         }
                     },
                     "400": {
@@ -588,21 +625,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/games/{gameId}/result": {
-            "get": {
-                "description": "Retrieve the result of a finished game session",
+        "/admin/users/{userId}/unsuspend": {
+            "post": {
+                "description": "Lift the suspension of a user, letting them log in again. Admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "games"
+                    "admin"
                 ],
-                "summary": "Get game result",
+                "summary": "Lift the suspension of a user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Game ID",
-                        "name": "gameId",
+                        "description": "User ID",
+                        "name": "userId",
                         "in": "path",
                         "required": true
                     }
@@ -611,7 +648,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.GameResultDetailedResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.SuccessResponseStruct"
                         }
                     },
                     "400": {
@@ -641,21 +678,62 @@ const docTemplate = `{
                 }
             }
         },
-        "/homepage": {
-            "get": {
-                "description": "Retrieve quizzes for home page sections",
+        "/ai/autocomplete-choice": {
+            "post": {
+                "description": "Autocomplete the content of a quiz choice",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "homepage"
+                    "ai"
+                ],
+                "summary": "Autocomplete Choice Content",
+                "parameters": [
+                    {
+                        "description": "Autocomplete Choice Request Body",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.AutocompleteChoiceRequestDTO"
+                        }
+                    }
                 ],
-                "summary": "Get quizzes for home page",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.HomePageSuccessResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.AutocompleteChoiceSuccessResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.PaymentRequiredErrorResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.UnprocessableEntityErrorResponseStruct"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.TooManyRequestsErrorResponseStruct"
                         }
                     },
                     "500": {
@@ -663,31 +741,40 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ServiceUnavailableErrorResponseStruct"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.GatewayTimeoutErrorResponseStruct"
+                        }
                     }
                 }
             }
         },
-        "/login": {
+        "/ai/autocomplete-question": {
             "post": {
-                "description": "Authenticate a user and return access and refresh tokens",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Autocomplete the content of a quiz question",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "authentication"
+                    "ai"
                 ],
-                "summary": "Log in a user",
+                "summary": "Autocomplete Question Content",
                 "parameters": [
                     {
-                        "description": "Login Data",
+                        "description": "Autocomplete Question Request Body",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.LoginRequestBody"
+                            "$ref": "#/definitions/intelliquiz_src_types.AutocompleteQuestionRequestDTO"
                         }
                     }
                 ],
@@ -695,7 +782,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.LoginResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.AutocompleteQuestionSuccessResponseDTO"
                         }
                     },
                     "400": {
@@ -704,36 +791,89 @@ const docTemplate = `{
                             "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.PaymentRequiredErrorResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
                         }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.UnprocessableEntityErrorResponseStruct"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.TooManyRequestsErrorResponseStruct"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ServiceUnavailableErrorResponseStruct"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.GatewayTimeoutErrorResponseStruct"
+                        }
                     }
                 }
             }
         },
-        "/me": {
-            "get": {
-                "description": "Retrieve the authenticated user's data",
+        "/ai/autocomplete-quiz": {
+            "post": {
+                "description": "Autocomplete the title of a quiz",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "ai"
+                ],
+                "summary": "Autocomplete Quiz Title",
+                "parameters": [
+                    {
+                        "description": "Autocomplete Quiz Request Body",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.AutocompleteQuizRequestDTO"
+                        }
+                    }
                 ],
-                "summary": "Get own user data",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.GetOwnUserSuccessResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.AutocompleteQuizSuccessResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.PaymentRequiredErrorResponseStruct"
                         }
                     },
                     "403": {
@@ -742,10 +882,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.UnprocessableEntityErrorResponseStruct"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.TooManyRequestsErrorResponseStruct"
                         }
                     },
                     "500": {
@@ -753,25 +899,51 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ServiceUnavailableErrorResponseStruct"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.GatewayTimeoutErrorResponseStruct"
+                        }
                     }
                 }
             }
         },
-        "/me/games": {
-            "get": {
-                "description": "Retrieve all finished game sessions for the authenticated user",
+        "/ai/generate-explanation": {
+            "post": {
+                "description": "Generate an explanation of why the correct answer of an own question is correct. Players see the explanation after answering the question. The explanation is only stored in the question when save is true, otherwise it can be edited and applied with the question update endpoint.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "games"
+                    "ai"
+                ],
+                "summary": "Generate an explanation for a question",
+                "parameters": [
+                    {
+                        "description": "Generate Explanation Request Body",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.GenerateExplanationRequestDTO"
+                        }
+                    }
                 ],
-                "summary": "Get user's finished games",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.GamesResultsResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.GenerateExplanationSuccessResponseDTO"
                         }
                     },
                     "400": {
@@ -780,58 +952,95 @@ const docTemplate = `{
                             "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
                         }
                     },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.PaymentRequiredErrorResponseStruct"
+                        }
+                    },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.UnprocessableEntityErrorResponseStruct"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.TooManyRequestsErrorResponseStruct"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ServiceUnavailableErrorResponseStruct"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.GatewayTimeoutErrorResponseStruct"
+                        }
                     }
                 }
             }
         },
-        "/me/quizzes": {
-            "get": {
-                "description": "Retrieve a list of quizzes created by the authenticated user",
+        "/ai/generate-question": {
+            "post": {
+                "description": "Generate a complete question with multiple choices using AI. The question is appended to the draft given by draft_id, or saved as a new draft",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "quizzes"
+                    "ai"
                 ],
-                "summary": "Get own quizzes",
+                "summary": "Generate Full Question with Choices",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Limit of quizzes per page (min: 5, max: 50)",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Page number (0-indexed)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter quizzes by name, category name, user name, or username",
-                        "name": "name",
-                        "in": "query"
+                        "description": "Generate Question Request Body",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.GenerateQuestionRequestDTO"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.GetOwnQuizzesSuccessResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.GenerateQuestionSuccessResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.PaymentRequiredErrorResponseStruct"
                         }
                     },
                     "403": {
@@ -840,30 +1049,22 @@ const docTemplate = `{
                             "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
                         }
-                    }
-                }
-            }
-        },
-        "/questions": {
-            "get": {
-                "description": "Retrieve a list of all questions",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "questions"
-                ],
-                "summary": "Get all questions",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.GetQuestionsSuccessResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.UnprocessableEntityErrorResponseStruct"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.TooManyRequestsErrorResponseStruct"
                         }
                     },
                     "500": {
@@ -871,34 +1072,48 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ServiceUnavailableErrorResponseStruct"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.GatewayTimeoutErrorResponseStruct"
+                        }
                     }
                 }
-            },
+            }
+        },
+        "/ai/generate-quiz": {
             "post": {
-                "description": "Create a new question",
+                "description": "Generate a complete quiz with title, questions and choices using AI, saved as a draft of the user. The number of questions (default: 5), difficulty (default: mixed), language (default: pt-BR), choices per question (default: 2 to 6) and topic can be customized",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "questions"
+                    "ai"
                 ],
-                "summary": "Create a new question",
+                "summary": "Generate Full Quiz with Questions and Choices",
                 "parameters": [
                     {
-                        "description": "Create Question Request Body",
+                        "description": "Generate Quiz Request Body",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.CreateQuestionRequestBody"
+                            "$ref": "#/definitions/intelliquiz_src_types.GenerateQuizRequestDTO"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.CreateQuestionSuccessResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.GenerateQuizSuccessResponseDTO"
                         }
                     },
                     "400": {
@@ -907,39 +1122,142 @@ const docTemplate = `{
                             "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
                         }
                     },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.PaymentRequiredErrorResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.UnprocessableEntityErrorResponseStruct"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.TooManyRequestsErrorResponseStruct"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ServiceUnavailableErrorResponseStruct"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.GatewayTimeoutErrorResponseStruct"
+                        }
                     }
                 }
             }
         },
-        "/questions/{questionId}": {
-            "get": {
-                "description": "Retrieve a question by its ID",
+        "/ai/generate-quiz-from-text": {
+            "post": {
+                "description": "Generate a quiz grounded in pasted text or an uploaded PDF, Markdown or plain text file (max 10 MB). Each question carries the source excerpt it came from so it can be verified before publishing the quiz, and the result is saved as a draft of the user. The same fields can also be sent as a JSON body with the text",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "questions"
+                    "ai"
                 ],
-                "summary": "Get a question by ID",
+                "summary": "Generate Quiz from Source Material",
                 "parameters": [
+                    {
+                        "type": "file",
+                        "description": "PDF, Markdown or plain text file",
+                        "name": "file",
+                        "in": "formData"
+                    },
                     {
                         "type": "string",
-                        "description": "Question ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Source text, used when no file is uploaded",
+                        "name": "text",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category ID",
+                        "name": "category_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Topic",
+                        "name": "topic",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Number of questions (min: 2, max: 50)",
+                        "name": "question_count",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "easy",
+                            "medium",
+                            "hard",
+                            "mixed"
+                        ],
+                        "type": "string",
+                        "default": "mixed",
+                        "description": "Difficulty",
+                        "name": "difficulty",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "default": "pt-BR",
+                        "description": "Language",
+                        "name": "language",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 2,
+                        "description": "Minimum number of choices per question (min: 2, max: 6)",
+                        "name": "min_choices",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 6,
+                        "description": "Maximum number of choices per question (min: 2, max: 6)",
+                        "name": "max_choices",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.GetQuestionSuccessResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.GenerateQuizFromTextSuccessResponseDTO"
                         }
                     },
                     "400": {
@@ -948,43 +1266,142 @@ const docTemplate = `{
                             "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
                         }
                     },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.PaymentRequiredErrorResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
                         }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.UnprocessableEntityErrorResponseStruct"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.TooManyRequestsErrorResponseStruct"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ServiceUnavailableErrorResponseStruct"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.GatewayTimeoutErrorResponseStruct"
+                        }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a question by its ID",
+            }
+        },
+        "/ai/generate-quiz-from-text/stream": {
+            "post": {
+                "description": "Generate a quiz from source material like /ai/generate-quiz-from-text, streaming it as Server-Sent Events: \"title\" (QuizStreamTitleEventDTO), one \"question\" per question with its source excerpt (QuizStreamQuestionEventDTO), then \"done\" (QuizStreamDoneEventDTO) or \"error\" (QuizStreamErrorEventDTO)",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
-                    "application/json"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "questions"
+                    "ai"
                 ],
-                "summary": "Delete a question by ID",
+                "summary": "Stream Quiz Generation from Source Material",
                 "parameters": [
+                    {
+                        "type": "file",
+                        "description": "PDF, Markdown or plain text file",
+                        "name": "file",
+                        "in": "formData"
+                    },
                     {
                         "type": "string",
-                        "description": "Question ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Source text, used when no file is uploaded",
+                        "name": "text",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category ID",
+                        "name": "category_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Topic",
+                        "name": "topic",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Number of questions (min: 2, max: 50)",
+                        "name": "question_count",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "easy",
+                            "medium",
+                            "hard",
+                            "mixed"
+                        ],
+                        "type": "string",
+                        "default": "mixed",
+                        "description": "Difficulty",
+                        "name": "difficulty",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "default": "pt-BR",
+                        "description": "Language",
+                        "name": "language",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 2,
+                        "description": "Minimum number of choices per question (min: 2, max: 6)",
+                        "name": "min_choices",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 6,
+                        "description": "Maximum number of choices per question (min: 2, max: 6)",
+                        "name": "max_choices",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.SuccessResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.QuizStreamQuestionEventDTO"
                         }
                     },
                     "400": {
@@ -993,12 +1410,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
                         }
                     },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.PaymentRequiredErrorResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
                         }
                     },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.TooManyRequestsErrorResponseStruct"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1006,31 +1441,29 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Update a question by its ID",
-                "produces": [
+            }
+        },
+        "/ai/generate-quiz/stream": {
+            "post": {
+                "description": "Generate a complete quiz like /ai/generate-quiz, streaming it as Server-Sent Events: \"title\" (QuizStreamTitleEventDTO), one \"question\" per question (QuizStreamQuestionEventDTO), then \"done\" (QuizStreamDoneEventDTO) or \"error\" (QuizStreamErrorEventDTO)",
+                "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "text/event-stream"
+                ],
                 "tags": [
-                    "questions"
+                    "ai"
                 ],
-                "summary": "Update a question by ID",
+                "summary": "Stream Full Quiz Generation",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Question ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update Question Request Body",
+                        "description": "Generate Quiz Request Body",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.UpdateQuestionRequestBody"
+                            "$ref": "#/definitions/intelliquiz_src_types.GenerateQuizRequestDTO"
                         }
                     }
                 ],
@@ -1038,7 +1471,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.SuccessResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.QuizStreamQuestionEventDTO"
                         }
                     },
                     "400": {
@@ -1047,12 +1480,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
                         }
                     },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.PaymentRequiredErrorResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
                         }
                     },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.TooManyRequestsErrorResponseStruct"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1062,21 +1513,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/questions/{questionId}/choices": {
-            "get": {
-                "description": "Retrieve a list of all choices for a specific question",
+        "/ai/review-quiz/{quizId}": {
+            "post": {
+                "description": "Review the questions and choices of an own quiz, pointing out ambiguous wording, factual doubts, questions with more than one plausible answer, obviously wrong distractors and spelling issues. Suggested rewrites can be applied with the question and choice update endpoints.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "choices"
+                    "ai"
                 ],
-                "summary": "Get all choices",
+                "summary": "Review a quiz with AI",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Question ID",
-                        "name": "questionID",
+                        "description": "Quiz ID",
+                        "name": "quizId",
                         "in": "path",
                         "required": true
                     }
@@ -1085,7 +1536,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.GetChoicesSuccessResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.ReviewQuizSuccessResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.PaymentRequiredErrorResponseStruct"
                         }
                     },
                     "403": {
@@ -1094,45 +1557,60 @@ const docTemplate = `{
                             "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.UnprocessableEntityErrorResponseStruct"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.TooManyRequestsErrorResponseStruct"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ServiceUnavailableErrorResponseStruct"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.GatewayTimeoutErrorResponseStruct"
+                        }
                     }
                 }
-            },
-            "post": {
-                "description": "Create a new choice",
+            }
+        },
+        "/categories": {
+            "get": {
+                "description": "Retrieve a list of all categories and subcategories, sorted by name, along with how many public quizzes each one has",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "choices"
-                ],
-                "summary": "Create a new choice",
-                "parameters": [
-                    {
-                        "description": "Create Choice Request Body",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.CreateChoiceRequestBody"
-                        }
-                    }
+                    "categories"
                 ],
+                "summary": "Get all categories",
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.CreateChoiceSuccessResponseStruct"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.GetCategoriesSuccessResponseStruct"
                         }
                     },
                     "403": {
@@ -1150,84 +1628,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/quizzes": {
+        "/categories/{categoryId}": {
             "get": {
-                "description": "Retrieve a list of all quizzes",
+                "description": "Retrieve a category by its ID or slug, along with its parent and subcategories",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "quizzes"
+                    "categories"
                 ],
-                "summary": "Get all quizzes",
+                "summary": "Get a category by ID or slug",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Limit of quizzes per page (min: 5, max: 50)",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Page number (0-indexed)",
-                        "name": "page",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Filter quizzes by name, category name, user name, or username",
-                        "name": "name",
-                        "in": "query"
+                        "description": "Category ID or slug",
+                        "name": "categoryId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.GetQuizzesSuccessResponseStruct"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "description": "Create a new quiz",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "quizzes"
-                ],
-                "summary": "Create a new quiz",
-                "parameters": [
-                    {
-                        "description": "Create Quiz Request Body",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.CreateQuizRequestBody"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.CreateQuizSuccessResponseStruct"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.GetCategorySuccessResponseStruct"
                         }
                     },
                     "403": {
@@ -1236,6 +1660,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1245,20 +1675,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/quizzes/{quizId}": {
+        "/choices/{choiceId}": {
             "get": {
-                "description": "Retrieve a quiz by its ID",
+                "description": "Retrieve a choice by its ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "quizzes"
+                    "choices"
                 ],
-                "summary": "Get a quiz by ID",
+                "summary": "Get a choice by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Quiz ID",
+                        "description": "Choice ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1268,7 +1698,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.GetQuizSuccessResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.GetChoiceSuccessResponseStruct"
                         }
                     },
                     "400": {
@@ -1277,6 +1707,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1292,18 +1728,18 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete a quiz by its ID",
+                "description": "Delete a choice by its ID. The last correct choice of a question cannot be deleted",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "quizzes"
+                    "choices"
                 ],
-                "summary": "Delete a quiz by ID",
+                "summary": "Delete a choice by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Quiz ID",
+                        "description": "Choice ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1343,7 +1779,7 @@ const docTemplate = `{
                 }
             },
             "patch": {
-                "description": "Update a quiz by its ID",
+                "description": "Update a choice by its ID. Choices of multiple_select questions can be marked correct or incorrect, while marking a choice of a single answer question correct unmarks the others. The last correct choice of a question cannot be unmarked. Content flagged by moderation keeps the quiz out of the public listings until reviewed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1351,24 +1787,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "quizzes"
+                    "choices"
                 ],
-                "summary": "Update a quiz by ID",
+                "summary": "Update a choice by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Quiz ID",
+                        "description": "Choice ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update Quiz Request Body",
+                        "description": "Update Choice Request Body",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.UpdateQuizRequestBody"
+                            "$ref": "#/definitions/intelliquiz_src_types.UpdateChoiceRequestBody"
                         }
                     }
                 ],
@@ -1406,21 +1842,69 @@ const docTemplate = `{
                 }
             }
         },
-        "/quizzes/{quizId}/dislike": {
-            "post": {
-                "description": "Dislike a quiz by its ID",
+        "/drafts": {
+            "get": {
+                "description": "Get the quiz drafts of the authenticated user, most recently updated first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "quizzes"
+                    "drafts"
                 ],
-                "summary": "Dislike a quiz by ID",
+                "summary": "Get own quiz drafts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Page number (starting from 0)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit of drafts per page (min: 5, max: 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.GetDraftsSuccessResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/drafts/{draftId}": {
+            "get": {
+                "description": "Get one of the quiz drafts of the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "drafts"
+                ],
+                "summary": "Get a quiz draft by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Quiz ID",
-                        "name": "id",
+                        "description": "Draft ID",
+                        "name": "draftId",
                         "in": "path",
                         "required": true
                     }
@@ -1429,7 +1913,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.SuccessResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.GetDraftSuccessResponseStruct"
                         }
                     },
                     "400": {
@@ -1457,32 +1941,42 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/quizzes/{quizId}/like": {
-            "post": {
-                "description": "Like a quiz by its ID",
+            },
+            "put": {
+                "description": "Replace the content of a quiz draft. Drafts can be left incomplete, they are only fully validated when published",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "quizzes"
+                    "drafts"
                 ],
-                "summary": "Like a quiz by ID",
+                "summary": "Update a quiz draft",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Quiz ID",
-                        "name": "id",
+                        "description": "Draft ID",
+                        "name": "draftId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Update Draft Request Body",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.UpdateDraftRequestBody"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.SuccessResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.GetDraftSuccessResponseStruct"
                         }
                     },
                     "400": {
@@ -1510,23 +2004,21 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/quizzes/{quizId}/play": {
-            "post": {
-                "description": "Start a new game session for a specific quiz",
+            },
+            "delete": {
+                "description": "Discard one of the quiz drafts of the authenticated user",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "games"
+                    "drafts"
                 ],
-                "summary": "Start a new game",
+                "summary": "Discard a quiz draft",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Quiz ID",
-                        "name": "id",
+                        "description": "Draft ID",
+                        "name": "draftId",
                         "in": "path",
                         "required": true
                     }
@@ -1535,7 +2027,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.StartGameResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.SuccessResponseStruct"
                         }
                     },
                     "400": {
@@ -1565,35 +2057,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/refresh": {
+        "/drafts/{draftId}/publish": {
             "post": {
-                "description": "Refresh the access and refresh tokens using a valid refresh token",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Create a quiz from a draft, with the same validation and moderation as quiz creation, and discard the draft",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "authentication"
+                    "drafts"
                 ],
-                "summary": "Refresh access and refresh tokens",
+                "summary": "Publish a quiz draft",
                 "parameters": [
                     {
-                        "description": "Refresh Token Data",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.RefreshRequestBody"
-                        }
+                        "type": "string",
+                        "description": "Draft ID",
+                        "name": "draftId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.RefreshResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.CreateQuizSuccessResponseStruct"
                         }
                     },
                     "400": {
@@ -1602,12 +2089,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1617,35 +2110,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/signup": {
+        "/games/{gameId}/answer": {
             "post": {
-                "description": "Create a new user account and return access and refresh tokens",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Submit an answer for the current question in a game session. The answer fields depend on the question type.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "authentication"
+                    "games"
                 ],
-                "summary": "Register a new user",
+                "summary": "Answer a question in a game",
                 "parameters": [
                     {
-                        "description": "User Data",
+                        "type": "string",
+                        "description": "Game ID",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Answer Question Request Body",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.SignUpRequestBody"
+                            "$ref": "#/definitions/intelliquiz_src_types.AnswerQuestionRequestBody"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.SignUpResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.AnswerQuestionResponseStruct"
                         }
                     },
                     "400": {
@@ -1654,30 +2151,76 @@ const docTemplate = `{
                             "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
                         }
-                    }
-                }
-            }
-        },
-        "/users": {
-            "get": {
-                "description": "Retrieve a list of all users",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "users"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ConflictErrorResponseStruct"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.DeletedQuestionResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{gameId}/answer/{choiceId}": {
+            "post": {
+                "description": "Submit the chosen choice for the current single choice or true/false question in a game session",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Answer a single choice question in a game",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Game ID",
+                        "name": "gameId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Choice ID",
+                        "name": "choiceId",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all users",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.GetUsersSuccessResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.AnswerQuestionResponseStruct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
                         }
                     },
                     "403": {
@@ -1686,6 +2229,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ConflictErrorResponseStruct"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.DeletedQuestionResponseStruct"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1695,21 +2256,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{userId}": {
+        "/games/{gameId}/result": {
             "get": {
-                "description": "Retrieve a user by their ID",
+                "description": "Retrieve the result of a finished game session",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "games"
                 ],
-                "summary": "Get a user by ID",
+                "summary": "Get game result",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
+                        "description": "Game ID",
+                        "name": "gameId",
                         "in": "path",
                         "required": true
                     }
@@ -1718,7 +2279,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.UserResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.GameResultDetailedResponseStruct"
                         }
                     },
                     "400": {
@@ -1746,9 +2307,43 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Update a user's information by their ID",
+            }
+        },
+        "/homepage": {
+            "get": {
+                "description": "Retrieve quizzes for home page sections, leaving out the ones quarantined by moderation. The sections are refreshed in the background, as of refreshedAt. Logged in users also get the forYou sections, which leave out the quizzes they created or already finished.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "homepage"
+                ],
+                "summary": "Get quizzes for home page",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.HomePageSuccessResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Authenticate a user and return access and refresh tokens",
                 "consumes": [
                     "application/json"
                 ],
@@ -1756,24 +2351,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "authentication"
                 ],
-                "summary": "Update a user by ID",
+                "summary": "Log in a user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update User Request Body",
+                        "description": "Login Data",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.UpdateUserRequestBody"
+                            "$ref": "#/definitions/intelliquiz_src_types.LoginRequestBody"
                         }
                     }
                 ],
@@ -1781,7 +2369,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/intelliquiz_src_types.SuccessResponseStruct"
+                            "$ref": "#/definitions/intelliquiz_src_types.LoginResponseStruct"
                         }
                     },
                     "400": {
@@ -1790,6 +2378,108 @@ const docTemplate = `{
                             "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/logout": {
+            "post": {
+                "description": "Revoke the session the access token was issued for, invalidating its refresh token",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authentication"
+                ],
+                "summary": "Log out the current session",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.SuccessResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/logout-all": {
+            "post": {
+                "description": "Revoke every session of the authenticated user on all devices",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authentication"
+                ],
+                "summary": "Log out every session",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.SuccessResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/me": {
+            "get": {
+                "description": "Retrieve the authenticated user's data",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get own user data",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.GetOwnUserSuccessResponseStruct"
+                        }
+                    },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
@@ -1810,78 +2500,3209 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "intelliquiz_src_types.AnswerQuestionDataStruct": {
-            "type": "object",
-            "properties": {
-                "is_correct": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "is_finished": {
-                    "type": "boolean",
-                    "example": false
-                },
-                "next_question": {
-                    "$ref": "#/definitions/intelliquiz_src_types.GameQuestionDTO"
+        },
+        "/me/ai-usage": {
+            "get": {
+                "description": "Retrieve the tokens the authenticated user spent on AI features today and this month, with their cost and what is left of the quotas",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ai"
+                ],
+                "summary": "Get own AI usage",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.GetOwnAIUsageSuccessResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/games": {
+            "get": {
+                "description": "Retrieve the finished game sessions of the authenticated user, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Get user's finished games",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit of games per page (min: 5, max: 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, taken from next_cursor or prev_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Page number (0-indexed), used without a cursor",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.GamesResultsResponseStruct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/notifications": {
+            "get": {
+                "description": "Get the notifications of the authenticated user, newest first, such as the outcome of their reports or moderation decisions on their quizzes",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Get own notifications",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Only return unread notifications",
+                        "name": "unread",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Page number (starting from 0)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit of notifications per page (min: 5, max: 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.GetNotificationsSuccessResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/notifications/read": {
+            "post": {
+                "description": "Mark every unread notification of the authenticated user as read",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Mark all notifications as read",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.SuccessResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/notifications/{notificationId}/read": {
+            "post": {
+                "description": "Mark one of the notifications of the authenticated user as read",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Mark a notification as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification ID",
+                        "name": "notificationId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.SuccessResponseStruct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/quizzes": {
+            "get": {
+                "description": "Retrieve a list of quizzes created by the authenticated user, including the ones quarantined by moderation",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quizzes"
+                ],
+                "summary": "Get own quizzes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit of quizzes per page (min: 5, max: 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, taken from next_cursor or prev_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Page number (0-indexed), used without a cursor",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter quizzes by name, category name, user name, or username",
+                        "name": "name",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.GetOwnQuizzesSuccessResponseStruct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/recommendations": {
+            "get": {
+                "description": "Recommend quizzes to play next, similar to the ones the authenticated user liked or finished, as played and liked by the same users, and from the categories the user plays the most. Users who did not play or like any quiz yet get the most liked quizzes. Quizzes the user created, finished or liked are left out.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quizzes"
+                ],
+                "summary": "Get recommended quizzes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of quizzes (min: 5, max: 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.RecommendedQuizzesSuccessResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/questions": {
+            "get": {
+                "description": "Retrieve a list of all questions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Get all questions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.GetQuestionsSuccessResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new question. Content flagged by moderation keeps the quiz out of the public listings until reviewed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Create a new question",
+                "parameters": [
+                    {
+                        "description": "Create Question Request Body",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.CreateQuestionRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.CreateQuestionSuccessResponseStruct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/questions/{questionId}": {
+            "get": {
+                "description": "Retrieve a question by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Get a question by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Question ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.GetQuestionSuccessResponseStruct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a question by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Delete a question by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Question ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.SuccessResponseStruct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update a question by its ID. Content flagged by moderation keeps the quiz out of the public listings until reviewed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "questions"
+                ],
+                "summary": "Update a question by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Question ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Question Request Body",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.UpdateQuestionRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.SuccessResponseStruct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/questions/{questionId}/choices": {
+            "get": {
+                "description": "Retrieve a list of all choices for a specific question",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "choices"
+                ],
+                "summary": "Get all choices",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Question ID",
+                        "name": "questionID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.GetChoicesSuccessResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new choice. Questions have up to 6 choices, or up to 10 accepted answers for free_text questions. Content flagged by moderation keeps the quiz out of the public listings until reviewed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "choices"
+                ],
+                "summary": "Create a new choice",
+                "parameters": [
+                    {
+                        "description": "Create Choice Request Body",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.CreateChoiceRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.CreateChoiceSuccessResponseStruct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/questions/{questionId}/report": {
+            "post": {
+                "description": "Report a question with a wrong answer key, or offensive, spam or infringing content, for the admins to review. The reporter is notified of the outcome.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Report a question",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Question ID",
+                        "name": "questionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Report Request Body",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ReportRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.CreateReportSuccessResponseStruct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/quizzes": {
+            "get": {
+                "description": "Retrieve a list of all quizzes, leaving out the ones quarantined by moderation. The search matches the quiz name, author, category and questions in Portuguese and English, ignoring case and accents, and sorts the quizzes by relevance unless another sort is given. The filters can be combined. The facets count the matching quizzes by category and curator pick, leaving out the filter on the facet itself, and are only returned on the first page.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quizzes"
+                ],
+                "summary": "Get all quizzes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit of quizzes per page (min: 5, max: 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, taken from next_cursor or prev_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Page number (0-indexed), used without a cursor",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search terms. Quotes match phrases, OR matches either term and -term leaves a term out",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated: same as q",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "relevance",
+                            "newest",
+                            "most_liked",
+                            "most_played",
+                            "trending",
+                            "alphabetical"
+                        ],
+                        "type": "string",
+                        "description": "Sort of the quizzes, relevance by default when searching and newest otherwise. Trending quizzes are the most played in the last 7 days",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Category IDs, including their subcategories",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Keep only the curator picks, or leave them out",
+                        "name": "curator_pick",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the quiz author",
+                        "name": "created_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum number of questions",
+                        "name": "min_questions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keep the quizzes created from this date on (2025-10-25 or RFC 3339)",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keep the quizzes created before this date (2025-10-25 or RFC 3339)",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Keep only the quizzes with an image, or without one",
+                        "name": "has_image",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.GetQuizzesSuccessResponseStruct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new quiz. Quizzes whose content is flagged by moderation are created, but kept out of the public listings until reviewed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quizzes"
+                ],
+                "summary": "Create a new quiz",
+                "parameters": [
+                    {
+                        "description": "Create Quiz Request Body",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.CreateQuizRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.CreateQuizSuccessResponseStruct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/quizzes/import": {
+            "post": {
+                "description": "Create a quiz from a JSON, CSV, GIFT or Moodle XML file. The questions are validated with the same rules as quiz creation and every invalid row is reported. The format is guessed from the file extension when not given. Form fields take precedence over the quiz data of JSON files. Names longer than 60 characters are cut. Quizzes flagged by moderation are kept out of the public listings until reviewed.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quizzes"
+                ],
+                "summary": "Import a quiz",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Quiz file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "gift",
+                            "moodlexml"
+                        ],
+                        "type": "string",
+                        "description": "File format",
+                        "name": "format",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quiz name, required unless given by a JSON file",
+                        "name": "name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category ID, required unless given by a JSON file",
+                        "name": "category_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Image URL",
+                        "name": "image_url",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Seconds allowed per question, between 5 and 300",
+                        "name": "question_time_limit",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.CreateQuizSuccessResponseStruct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ImportQuizErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/quizzes/{quizId}": {
+            "get": {
+                "description": "Retrieve a quiz by its ID. Logged-in users also get its questions, with the answers only for the author and the admins.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quizzes"
+                ],
+                "summary": "Get a quiz by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Quiz ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.GetQuizSuccessResponseStruct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a quiz by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quizzes"
+                ],
+                "summary": "Delete a quiz by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Quiz ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.SuccessResponseStruct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update a quiz by its ID. Sending question_time_limit as 0 removes the time limit. A name flagged by moderation keeps the quiz out of the public listings until reviewed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quizzes"
+                ],
+                "summary": "Update a quiz by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Quiz ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Quiz Request Body",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.UpdateQuizRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.SuccessResponseStruct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/quizzes/{quizId}/dislike": {
+            "post": {
+                "description": "Dislike a quiz by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quizzes"
+                ],
+                "summary": "Dislike a quiz by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Quiz ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.SuccessResponseStruct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/quizzes/{quizId}/export": {
+            "get": {
+                "description": "Download one of the user's quizzes with its questions in JSON, CSV, GIFT or Moodle XML format. Ordering questions are left out of GIFT exports.",
+                "produces": [
+                    "application/json",
+                    "text/plain",
+                    "text/xml"
+                ],
+                "tags": [
+                    "quizzes"
+                ],
+                "summary": "Export a quiz",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Quiz ID",
+                        "name": "quizId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "gift",
+                            "moodlexml"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Export format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/quizzes/{quizId}/like": {
+            "post": {
+                "description": "Like a quiz by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quizzes"
+                ],
+                "summary": "Like a quiz by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Quiz ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.SuccessResponseStruct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/quizzes/{quizId}/play": {
+            "post": {
+                "description": "Start a new game session for a specific quiz",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Start a new game",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Quiz ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.StartGameResponseStruct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/quizzes/{quizId}/report": {
+            "post": {
+                "description": "Report a quiz with offensive, spam or infringing content, or a wrong answer key, for the admins to review. The reporter is notified of the outcome.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Report a quiz",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Quiz ID",
+                        "name": "quizId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Report Request Body",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ReportRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.CreateReportSuccessResponseStruct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/quizzes/{quizId}/similar": {
+            "get": {
+                "description": "Retrieve the quizzes most similar to a quiz, as played and liked by the same users, favoring the ones of its category. Quizzes of its category fill in when few users played it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quizzes"
+                ],
+                "summary": "Get quizzes similar to a quiz",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Quiz ID",
+                        "name": "quizId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of quizzes (min: 5, max: 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.RecommendedQuizzesSuccessResponseStruct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/refresh": {
+            "post": {
+                "description": "Refresh the access and refresh tokens using a valid refresh token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authentication"
+                ],
+                "summary": "Refresh access and refresh tokens",
+                "parameters": [
+                    {
+                        "description": "Refresh Token Data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.RefreshRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.RefreshResponseStruct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms": {
+            "post": {
+                "description": "Open a live room for a quiz. Players join the room through its WebSocket using the returned code, and the host starts it by sending {\"type\": \"start\"}. If the host leaves the lobby, the player who joined next becomes the host. Without question_seconds, questions last as long as the time limit of the quiz, or 20 seconds when it has none.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rooms"
+                ],
+                "summary": "Create a multiplayer room",
+                "parameters": [
+                    {
+                        "description": "Create Room Request Body",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.CreateRoomRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.CreateRoomResponseStruct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{code}/ws": {
+            "get": {
+                "description": "Upgrade to a WebSocket connected to the room. Browsers can't set headers on WebSocket requests, so the access token may be sent in the token query parameter. Server messages are intelliquiz_src_types.RoomMessage envelopes (lobby, question, answer_result, leaderboard, error); clients send intelliquiz_src_types.RoomIncomingMessage (start, answer).",
+                "tags": [
+                    "rooms"
+                ],
+                "summary": "Join a multiplayer room",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Room code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Access token, when the Authorization header can't be set",
+                        "name": "token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/signup": {
+            "post": {
+                "description": "Create a new user account and return access and refresh tokens",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authentication"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "User Data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.SignUpRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.SignUpResponseStruct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "Retrieve a page of users, sorted by username",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get all users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit of users per page (min: 5, max: 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, taken from next_cursor or prev_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Page number (0-indexed), used without a cursor",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.GetUsersSuccessResponseStruct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{userId}": {
+            "get": {
+                "description": "Retrieve a user by their ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get a user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.UserResponseStruct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update a user's information by their ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update a user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update User Request Body",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.UpdateUserRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.SuccessResponseStruct"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.BadRequestErrorResponseStruct"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.ForbiddenErrorResponseStruct"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.NotFoundErrorResponseStruct"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/intelliquiz_src_types.InternalServerErrorResponseStruct"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "intelliquiz_src_types.AIUsagePeriodDTO": {
+            "type": "object",
+            "properties": {
+                "completion_tokens": {
+                    "type": "integer",
+                    "example": 5600
+                },
+                "cost": {
+                    "type": "number",
+                    "example": 0.0052
+                },
+                "prompt_tokens": {
+                    "type": "integer",
+                    "example": 12400
+                },
+                "quota": {
+                    "type": "integer",
+                    "example": 50000
+                },
+                "remaining": {
+                    "type": "integer",
+                    "example": 32000
+                },
+                "resets_at": {
+                    "type": "string",
+                    "example": "2025-10-23T00:00:00-03:00"
+                },
+                "total_tokens": {
+                    "type": "integer",
+                    "example": 18000
+                }
+            }
+        },
+        "intelliquiz_src_types.AdminReportResponseDTO": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "hide",
+                        "delete"
+                    ],
+                    "example": "none"
+                },
+                "comment": {
+                    "type": "string",
+                    "example": "The correct answer is Canberra, not Sydney."
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-10-22T19:01:58.778079424Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "7c0e5b8e-0f43-4f0b-8d38-2a7f3b1f4e21"
+                },
+                "question": {
+                    "$ref": "#/definitions/intelliquiz_src_types.ReportQuestionDTO"
+                },
+                "question_id": {
+                    "type": "string",
+                    "example": "b3d1c6a2-5f1e-4c8e-9a7b-1e2d3c4b5a69"
+                },
+                "quiz": {
+                    "$ref": "#/definitions/intelliquiz_src_types.ReportQuizDTO"
+                },
+                "quiz_id": {
+                    "type": "string",
+                    "example": "4fdb53f5-74d2-4d0e-8267-43f893a51aca"
+                },
+                "reason": {
+                    "type": "string",
+                    "enum": [
+                        "wrong_answer",
+                        "offensive",
+                        "spam",
+                        "copyright",
+                        "other"
+                    ],
+                    "example": "wrong_answer"
+                },
+                "reporter": {
+                    "$ref": "#/definitions/intelliquiz_src_types.ReportReporterDTO"
+                },
+                "reporter_id": {
+                    "type": "string",
+                    "example": "0fde5216-1bab-41f6-bd90-4c3f088ee91f"
+                },
+                "resolution_note": {
+                    "type": "string",
+                    "example": "The answer key was fixed by the author."
+                },
+                "resolved_at": {
+                    "type": "string",
+                    "example": "2025-10-23T08:12:40.112233445Z"
+                },
+                "resolved_by": {
+                    "type": "string",
+                    "example": "5e6f7a8b-9c0d-4e1f-a2b3-c4d5e6f7a8b9"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "open",
+                        "resolved",
+                        "dismissed"
+                    ],
+                    "example": "resolved"
+                }
+            }
+        },
+        "intelliquiz_src_types.AnswerQuestionDataStruct": {
+            "type": "object",
+            "properties": {
+                "correct_choices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.GameResultChoiceDTO"
+                    }
+                },
+                "correct_number": {
+                    "type": "number",
+                    "example": 3.14
+                },
+                "explanation": {
+                    "type": "string",
+                    "example": "Paris has been the capital of France since 987."
+                },
+                "is_correct": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "is_finished": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "next_question": {
+                    "$ref": "#/definitions/intelliquiz_src_types.GameQuestionDTO"
+                },
+                "points": {
+                    "type": "integer",
+                    "example": 165
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 330
+                },
+                "timed_out": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "intelliquiz_src_types.AnswerQuestionRequestBody": {
+            "type": "object",
+            "properties": {
+                "choice_id": {
+                    "type": "string",
+                    "example": "05a93ef2-23a6-4793-a6dc-0167bae5150f"
+                },
+                "choice_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "05a93ef2-23a6-4793-a6dc-0167bae5150f"
+                    ]
+                },
+                "number": {
+                    "type": "number",
+                    "example": 3.14
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Paris"
+                }
+            }
+        },
+        "intelliquiz_src_types.AnswerQuestionResponseStruct": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/intelliquiz_src_types.AnswerQuestionDataStruct"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "intelliquiz_src_types.AutocompleteChoiceRequestDTO": {
+            "type": "object",
+            "required": [
+                "category_id",
+                "is_correct",
+                "question_content",
+                "quiz_title"
+            ],
+            "properties": {
+                "category_id": {
+                    "type": "string",
+                    "example": "d27b21ab-6177-4159-9e13-15dc50ffed29"
+                },
+                "content": {
+                    "type": "string",
+                    "example": "Par"
+                },
+                "is_correct": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "question_content": {
+                    "type": "string",
+                    "example": "What is the capital of France?"
+                },
+                "quiz_title": {
+                    "type": "string",
+                    "example": "Geography Quiz"
+                }
+            }
+        },
+        "intelliquiz_src_types.AutocompleteChoiceSuccessResponseDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "string",
+                    "example": "Paris"
+                },
+                "statusCode": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "intelliquiz_src_types.AutocompleteQuestionRequestDTO": {
+            "type": "object",
+            "required": [
+                "category_id",
+                "quiz_title"
+            ],
+            "properties": {
+                "category_id": {
+                    "type": "string",
+                    "example": "d27b21ab-6177-4159-9e13-15dc50ffed29"
+                },
+                "content": {
+                    "type": "string",
+                    "example": "What is the capital of"
+                },
+                "quiz_title": {
+                    "type": "string",
+                    "example": "Geography Quiz"
+                }
+            }
+        },
+        "intelliquiz_src_types.AutocompleteQuestionSuccessResponseDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "string",
+                    "example": "What is the capital of France?"
+                },
+                "statusCode": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "intelliquiz_src_types.AutocompleteQuizRequestDTO": {
+            "type": "object",
+            "required": [
+                "category_id"
+            ],
+            "properties": {
+                "category_id": {
+                    "type": "string",
+                    "example": "d27b21ab-6177-4159-9e13-15dc50ffed29"
+                },
+                "content": {
+                    "type": "string",
+                    "example": "What is the capital of"
+                }
+            }
+        },
+        "intelliquiz_src_types.AutocompleteQuizSuccessResponseDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "string",
+                    "example": "What is the capital of France?"
+                },
+                "statusCode": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "intelliquiz_src_types.BadRequestErrorResponseStruct": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Bad Request"
+                },
+                "statusCode": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "intelliquiz_src_types.CategoryFacetDTO": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Science"
+                }
+            }
+        },
+        "intelliquiz_src_types.CategoryQuizResponseDTOStruct": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "d27b21ab-6177-4159-9e13-15dc50ffed29"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "General Knowledge"
+                }
+            }
+        },
+        "intelliquiz_src_types.CategoryRequestBody": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Quizzes about the natural world."
+                },
+                "icon_url": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://example.com/icons/science.svg"
+                },
+                "image_url": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://example.com/images/science.jpg"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 40,
+                    "example": "Science"
+                },
+                "parent_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "slug": {
+                    "type": "string",
+                    "maxLength": 60,
+                    "example": "science"
+                }
+            }
+        },
+        "intelliquiz_src_types.CategoryResponseStruct": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Quizzes about the natural world."
+                },
+                "icon_url": {
+                    "type": "string",
+                    "example": "https://example.com/icons/science.svg"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://example.com/images/science.jpg"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Science"
+                },
+                "parent_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "quizzes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.GetCategoryQuizDTO"
+                    }
+                },
+                "quizzes_count": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "science"
+                }
+            }
+        },
+        "intelliquiz_src_types.CategorySuccessResponseStruct": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/intelliquiz_src_types.CategoryResponseStruct"
+                },
+                "statusCode": {
+                    "type": "integer",
+                    "example": 201
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "intelliquiz_src_types.CategorySummaryDTO": {
+            "type": "object",
+            "properties": {
+                "icon_url": {
+                    "type": "string",
+                    "example": "https://example.com/icons/physics.svg"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Physics"
+                },
+                "parent_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "physics"
+                }
+            }
+        },
+        "intelliquiz_src_types.CategoryWithChildrenResponseStruct": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.CategorySummaryDTO"
+                    }
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Quizzes about the natural world."
+                },
+                "icon_url": {
+                    "type": "string",
+                    "example": "https://example.com/icons/science.svg"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://example.com/images/science.jpg"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Science"
+                },
+                "parent": {
+                    "$ref": "#/definitions/intelliquiz_src_types.CategorySummaryDTO"
+                },
+                "parent_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "quizzes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.GetCategoryQuizDTO"
+                    }
+                },
+                "quizzes_count": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "science"
+                }
+            }
+        },
+        "intelliquiz_src_types.ChoiceDTO": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "example": "Paris"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "4fdb53f5-74d2-4d0e-8267-43f893a51aca"
+                },
+                "is_correct": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "question_id": {
+                    "type": "string",
+                    "example": "d27b21ab-6177-4159-9e13-15dc50ffed29"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                }
+            }
+        },
+        "intelliquiz_src_types.ChoicesCreateQuestionDTO": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "example": "Paris"
+                },
+                "is_correct": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "intelliquiz_src_types.ConflictErrorResponseStruct": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Conflict"
+                },
+                "statusCode": {
+                    "type": "integer",
+                    "example": 409
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "intelliquiz_src_types.CreateChoiceRequestBody": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "example": "Paris"
+                }
+            }
+        },
+        "intelliquiz_src_types.CreateChoiceSuccessResponseStruct": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/intelliquiz_src_types.ChoiceDTO"
+                },
+                "statusCode": {
+                    "type": "integer",
+                    "example": 201
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "intelliquiz_src_types.CreateQuestionRequestBody": {
+            "type": "object",
+            "required": [
+                "content",
+                "quiz_id"
+            ],
+            "properties": {
+                "accepted_answers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Paris"
+                    ]
+                },
+                "choices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.ChoicesCreateQuestionDTO"
+                    }
+                },
+                "content": {
+                    "type": "string",
+                    "example": "What is the capital of France?"
+                },
+                "explanation": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Paris has been the capital of France since 987."
+                },
+                "numeric_answer": {
+                    "type": "number",
+                    "example": 3.14
+                },
+                "numeric_tolerance": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 0.01
+                },
+                "quiz_id": {
+                    "type": "string",
+                    "example": "4fdb53f5-74d2-4d0e-8267-43f893a51aca"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "single_choice",
+                        "multiple_select",
+                        "true_false",
+                        "ordering",
+                        "free_text",
+                        "numeric"
+                    ],
+                    "example": "single_choice"
+                }
+            }
+        },
+        "intelliquiz_src_types.CreateQuestionSuccessResponseStruct": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/intelliquiz_src_types.QuestionResponseDTO"
+                },
+                "statusCode": {
+                    "type": "integer",
+                    "default": 201
+                },
+                "success": {
+                    "type": "boolean",
+                    "default": true
+                }
+            }
+        },
+        "intelliquiz_src_types.CreateQuizQuestionChoiceStruct": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "example": "Paris"
+                },
+                "is_correct": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "intelliquiz_src_types.CreateQuizQuestionsStruct": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "accepted_answers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Paris"
+                    ]
+                },
+                "choices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.CreateQuizQuestionChoiceStruct"
+                    }
+                },
+                "content": {
+                    "type": "string",
+                    "example": "What is the capital of France?"
+                },
+                "explanation": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Paris has been the capital of France since 987."
+                },
+                "numeric_answer": {
+                    "type": "number",
+                    "example": 3.14
+                },
+                "numeric_tolerance": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 0.01
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "single_choice",
+                        "multiple_select",
+                        "true_false",
+                        "ordering",
+                        "free_text",
+                        "numeric"
+                    ],
+                    "example": "single_choice"
+                }
+            }
+        },
+        "intelliquiz_src_types.CreateQuizRequestBody": {
+            "type": "object",
+            "required": [
+                "category_id",
+                "name",
+                "questions"
+            ],
+            "properties": {
+                "category_id": {
+                    "type": "string",
+                    "example": "d27b21ab-6177-4159-9e13-15dc50ffed29"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://example.com/image.jpg"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Sample Quiz"
+                },
+                "question_time_limit": {
+                    "type": "integer",
+                    "maximum": 300,
+                    "minimum": 5,
+                    "example": 20
+                },
+                "questions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.CreateQuizQuestionsStruct"
+                    }
+                }
+            }
+        },
+        "intelliquiz_src_types.CreateQuizSuccessResponseStruct": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/intelliquiz_src_types.QuizResponseDTO"
+                },
+                "statusCode": {
+                    "type": "integer",
+                    "example": 201
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "intelliquiz_src_types.CreateReportSuccessResponseStruct": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/intelliquiz_src_types.ReportResponseDTO"
+                },
+                "statusCode": {
+                    "type": "integer",
+                    "example": 201
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "intelliquiz_src_types.CreateRoomDataStruct": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "K7QX2M"
+                },
+                "host_id": {
+                    "type": "string",
+                    "example": "0fde5216-1bab-41f6-bd90-4c3f088ee91f"
+                },
+                "question_seconds": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "quiz_id": {
+                    "type": "string",
+                    "example": "4fdb53f5-74d2-4d0e-8267-43f893a51aca"
+                },
+                "total_questions": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "intelliquiz_src_types.CreateRoomRequestBody": {
+            "type": "object",
+            "required": [
+                "quiz_id"
+            ],
+            "properties": {
+                "question_seconds": {
+                    "type": "integer",
+                    "maximum": 120,
+                    "minimum": 5,
+                    "example": 20
+                },
+                "quiz_id": {
+                    "type": "string",
+                    "example": "4fdb53f5-74d2-4d0e-8267-43f893a51aca"
+                }
+            }
+        },
+        "intelliquiz_src_types.CreateRoomResponseStruct": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/intelliquiz_src_types.CreateRoomDataStruct"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 201
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "intelliquiz_src_types.CuratorPickFacetDTO": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "value": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "intelliquiz_src_types.DeletedQuestionDataStruct": {
+            "type": "object",
+            "properties": {
+                "is_finished": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "next_question": {
+                    "$ref": "#/definitions/intelliquiz_src_types.GameQuestionDTO"
+                }
+            }
+        },
+        "intelliquiz_src_types.DeletedQuestionResponseStruct": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/intelliquiz_src_types.DeletedQuestionDataStruct"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "The current question has been deleted."
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 410
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "intelliquiz_src_types.FlaggedQuizResponseDTO": {
+            "type": "object",
+            "properties": {
+                "created_by": {
+                    "type": "string",
+                    "example": "0fde5216-1bab-41f6-bd90-4c3f088ee91f"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "4fdb53f5-74d2-4d0e-8267-43f893a51aca"
+                },
+                "moderation_reason": {
+                    "type": "string",
+                    "example": "hate"
+                },
+                "moderation_status": {
+                    "type": "string",
+                    "enum": [
+                        "flagged"
+                    ],
+                    "example": "flagged"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Sample Quiz"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-10-22T19:01:58.778079424Z"
+                },
+                "user": {
+                    "$ref": "#/definitions/intelliquiz_src_types.UserQuizResponseDTOStruct"
+                }
+            }
+        },
+        "intelliquiz_src_types.ForbiddenErrorResponseStruct": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Forbidden"
+                },
+                "statusCode": {
+                    "type": "integer",
+                    "example": 403
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "intelliquiz_src_types.GameQuestionChoiceDTO": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "example": "Choice content"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "question_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                }
+            }
+        },
+        "intelliquiz_src_types.GameQuestionDTO": {
+            "type": "object",
+            "properties": {
+                "choices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.GameQuestionChoiceDTO"
+                    }
+                },
+                "content": {
+                    "type": "string",
+                    "example": "Question content"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440002"
+                },
+                "quiz_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440002"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "single_choice"
+                }
+            }
+        },
+        "intelliquiz_src_types.GameQuestionResultDTO": {
+            "type": "object",
+            "properties": {
+                "answer": {
+                    "type": "string",
+                    "example": "Paris"
+                },
+                "answered_at": {
+                    "type": "string",
+                    "example": "2025-10-25T18:45:27.849543Z"
+                },
+                "choice": {
+                    "$ref": "#/definitions/intelliquiz_src_types.GameResultChoiceDTO"
+                },
+                "choice_id": {
+                    "type": "string",
+                    "example": "04da923c-314a-41c6-98fc-8a39a992d5c0"
+                },
+                "correct_choices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.GameResultChoiceDTO"
+                    }
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-10-25T18:45:10.258139Z"
+                },
+                "game_id": {
+                    "type": "string",
+                    "example": "38822b7e-1a36-492e-bfc3-8c26131a278f"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "047bdcd1-fd82-43f2-895a-8ad7fc7206e3"
+                },
+                "is_correct": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "points": {
+                    "type": "integer",
+                    "example": 165
+                },
+                "position": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "question": {
+                    "$ref": "#/definitions/intelliquiz_src_types.GameResultQuestionDTO"
+                },
+                "question_id": {
+                    "type": "string",
+                    "example": "c9118e52-e912-4396-9f66-f8976f84e935"
+                },
+                "seconds_taken": {
+                    "type": "integer",
+                    "example": 17
+                },
+                "timed_out": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-10-25T18:45:27.84965Z"
+                }
+            }
+        },
+        "intelliquiz_src_types.GameResultChoiceDTO": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "example": "Paris"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "04da923c-314a-41c6-98fc-8a39a992d5c0"
+                }
+            }
+        },
+        "intelliquiz_src_types.GameResultDetailedDataStruct": {
+            "type": "object",
+            "properties": {
+                "game": {
+                    "$ref": "#/definitions/intelliquiz_src_types.GameResultGameDTO"
+                }
+            }
+        },
+        "intelliquiz_src_types.GameResultDetailedResponseStruct": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/intelliquiz_src_types.GameResultDetailedDataStruct"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "intelliquiz_src_types.GameResultGameDTO": {
+            "type": "object",
+            "properties": {
+                "correct_answers": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-10-25T18:45:10.256695Z"
+                },
+                "finished_at": {
+                    "type": "string",
+                    "example": "2025-10-25T18:45:27.849543Z"
+                },
+                "game_questions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.GameQuestionResultDTO"
+                    }
+                },
+                "id": {
+                    "type": "string",
+                    "example": "38822b7e-1a36-492e-bfc3-8c26131a278f"
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 165
+                },
+                "total_questions": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "total_seconds_taken": {
+                    "type": "integer",
+                    "example": 24
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-10-25T18:45:45.67655Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "4b97df8d-7616-47da-858f-acddb95d675a"
+                }
+            }
+        },
+        "intelliquiz_src_types.GameResultQuestionDTO": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "example": "Qual a capital da França?"
+                },
+                "explanation": {
+                    "type": "string",
+                    "example": "Paris é a capital da França desde 987."
+                },
+                "id": {
+                    "type": "string",
+                    "example": "c9118e52-e912-4396-9f66-f8976f84e935"
+                },
+                "numeric_answer": {
+                    "type": "number",
+                    "example": 3.14
+                },
+                "type": {
+                    "type": "string",
+                    "example": "single_choice"
+                }
+            }
+        },
+        "intelliquiz_src_types.GamesResultsDataStruct": {
+            "type": "object",
+            "properties": {
+                "correct_answers": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-10-25T18:45:10.256695Z"
+                },
+                "finished_at": {
+                    "type": "string",
+                    "example": "2025-10-25T18:45:27.849543Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "38822b7e-1a36-492e-bfc3-8c26131a278f"
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 165
+                },
+                "total_questions": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "total_seconds_taken": {
+                    "type": "integer",
+                    "example": 24
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-10-25T18:45:45.67655Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "4b97df8d-7616-47da-858f-acddb95d675a"
+                }
+            }
+        },
+        "intelliquiz_src_types.GamesResultsPageStruct": {
+            "type": "object",
+            "properties": {
+                "games": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.GamesResultsDataStruct"
+                    }
+                },
+                "maxPage": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJzIjoibmV3ZXN0IiwidiI6W119"
+                },
+                "prev_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "intelliquiz_src_types.GamesResultsResponseStruct": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/intelliquiz_src_types.GamesResultsPageStruct"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "intelliquiz_src_types.AnswerQuestionResponseStruct": {
+        "intelliquiz_src_types.GatewayTimeoutErrorResponseStruct": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/intelliquiz_src_types.AnswerQuestionDataStruct"
+                "message": {
+                    "type": "string",
+                    "default": "Gateway timeout"
                 },
-                "status_code": {
+                "statusCode": {
                     "type": "integer",
-                    "example": 200
+                    "default": 504
                 },
                 "success": {
                     "type": "boolean",
-                    "example": true
+                    "default": false
                 }
             }
         },
-        "intelliquiz_src_types.AutocompleteChoiceRequestDTO": {
+        "intelliquiz_src_types.GenerateExplanationRequestDTO": {
             "type": "object",
             "required": [
-                "category_id",
-                "is_correct",
-                "question_content",
-                "quiz_title"
+                "question_id"
             ],
             "properties": {
-                "category_id": {
-                    "type": "string",
-                    "example": "d27b21ab-6177-4159-9e13-15dc50ffed29"
-                },
-                "content": {
+                "question_id": {
                     "type": "string",
-                    "example": "Par"
+                    "example": "5d9c1a3e-2b4f-4e6a-8c7d-9f0e1a2b3c4d"
                 },
-                "is_correct": {
+                "save": {
                     "type": "boolean",
-                    "example": true
-                },
-                "question_content": {
-                    "type": "string",
-                    "example": "What is the capital of France?"
-                },
-                "quiz_title": {
-                    "type": "string",
-                    "example": "Geography Quiz"
+                    "example": false
                 }
             }
         },
-        "intelliquiz_src_types.AutocompleteChoiceSuccessResponseDTO": {
+        "intelliquiz_src_types.GenerateExplanationSuccessResponseDTO": {
             "type": "object",
             "properties": {
                 "data": {
-                    "type": "string",
-                    "example": "Paris"
+                    "$ref": "#/definitions/intelliquiz_src_types.GeneratedExplanationDataDTO"
                 },
                 "statusCode": {
                     "type": "integer",
@@ -1893,7 +5714,7 @@ const docTemplate = `{
                 }
             }
         },
-        "intelliquiz_src_types.AutocompleteQuestionRequestDTO": {
+        "intelliquiz_src_types.GenerateQuestionRequestDTO": {
             "type": "object",
             "required": [
                 "category_id",
@@ -1904,9 +5725,9 @@ const docTemplate = `{
                     "type": "string",
                     "example": "d27b21ab-6177-4159-9e13-15dc50ffed29"
                 },
-                "content": {
+                "draft_id": {
                     "type": "string",
-                    "example": "What is the capital of"
+                    "example": "a1e4a6b0-8c4b-4f52-9d0f-2b7c9a8e6f10"
                 },
                 "quiz_title": {
                     "type": "string",
@@ -1914,12 +5735,11 @@ const docTemplate = `{
                 }
             }
         },
-        "intelliquiz_src_types.AutocompleteQuestionSuccessResponseDTO": {
+        "intelliquiz_src_types.GenerateQuestionSuccessResponseDTO": {
             "type": "object",
             "properties": {
                 "data": {
-                    "type": "string",
-                    "example": "What is the capital of France?"
+                    "$ref": "#/definitions/intelliquiz_src_types.GeneratedQuestionDataDTO"
                 },
                 "statusCode": {
                     "type": "integer",
@@ -1931,7 +5751,23 @@ const docTemplate = `{
                 }
             }
         },
-        "intelliquiz_src_types.AutocompleteQuizRequestDTO": {
+        "intelliquiz_src_types.GenerateQuizFromTextSuccessResponseDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/intelliquiz_src_types.GeneratedSourceQuizDataDTO"
+                },
+                "statusCode": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "intelliquiz_src_types.GenerateQuizRequestDTO": {
             "type": "object",
             "required": [
                 "category_id"
@@ -1941,18 +5777,50 @@ const docTemplate = `{
                     "type": "string",
                     "example": "d27b21ab-6177-4159-9e13-15dc50ffed29"
                 },
-                "content": {
+                "difficulty": {
                     "type": "string",
-                    "example": "What is the capital of"
+                    "enum": [
+                        "easy",
+                        "medium",
+                        "hard",
+                        "mixed"
+                    ],
+                    "example": "medium"
+                },
+                "language": {
+                    "type": "string",
+                    "example": "pt-BR"
+                },
+                "max_choices": {
+                    "type": "integer",
+                    "maximum": 6,
+                    "minimum": 2,
+                    "example": 4
+                },
+                "min_choices": {
+                    "type": "integer",
+                    "maximum": 6,
+                    "minimum": 2,
+                    "example": 3
+                },
+                "question_count": {
+                    "type": "integer",
+                    "maximum": 50,
+                    "minimum": 2,
+                    "example": 10
+                },
+                "topic": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Capitals of Europe"
                 }
             }
         },
-        "intelliquiz_src_types.AutocompleteQuizSuccessResponseDTO": {
+        "intelliquiz_src_types.GenerateQuizSuccessResponseDTO": {
             "type": "object",
             "properties": {
                 "data": {
-                    "type": "string",
-                    "example": "What is the capital of France?"
+                    "$ref": "#/definitions/intelliquiz_src_types.GeneratedQuizDataDTO"
                 },
                 "statusCode": {
                     "type": "integer",
@@ -1964,122 +5832,227 @@ const docTemplate = `{
                 }
             }
         },
-        "intelliquiz_src_types.BadRequestErrorResponseStruct": {
+        "intelliquiz_src_types.GeneratedChoiceDTO": {
             "type": "object",
             "properties": {
-                "message": {
+                "content": {
                     "type": "string",
-                    "example": "Bad Request"
+                    "example": "Paris"
                 },
-                "statusCode": {
-                    "type": "integer",
-                    "example": 400
+                "is_correct": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "intelliquiz_src_types.GeneratedExplanationDataDTO": {
+            "type": "object",
+            "properties": {
+                "explanation": {
+                    "type": "string",
+                    "example": "Paris has been the capital of France since 987, when Hugh Capet made it the seat of his kingdom."
                 },
-                "success": {
+                "question_id": {
+                    "type": "string",
+                    "example": "5d9c1a3e-2b4f-4e6a-8c7d-9f0e1a2b3c4d"
+                },
+                "saved": {
                     "type": "boolean",
                     "example": false
                 }
             }
         },
-        "intelliquiz_src_types.CategoryQuizResponseDTOStruct": {
+        "intelliquiz_src_types.GeneratedQuestionDataDTO": {
             "type": "object",
             "properties": {
-                "id": {
+                "choices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.GeneratedChoiceDTO"
+                    }
+                },
+                "draft_id": {
                     "type": "string",
-                    "example": "d27b21ab-6177-4159-9e13-15dc50ffed29"
+                    "example": "a1e4a6b0-8c4b-4f52-9d0f-2b7c9a8e6f10"
                 },
-                "name": {
+                "question_content": {
                     "type": "string",
-                    "example": "General Knowledge"
+                    "example": "What is the capital of France?"
                 }
             }
         },
-        "intelliquiz_src_types.CategoryResponseStruct": {
+        "intelliquiz_src_types.GeneratedQuizDataDTO": {
             "type": "object",
             "properties": {
-                "id": {
+                "draft_id": {
                     "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                    "example": "a1e4a6b0-8c4b-4f52-9d0f-2b7c9a8e6f10"
                 },
-                "name": {
-                    "type": "string",
-                    "example": "Science"
+                "questions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.GeneratedQuizQuestionDTO"
+                    }
                 },
-                "quizzes": {
+                "quiz_title": {
+                    "type": "string",
+                    "example": "European Capitals Quiz"
+                }
+            }
+        },
+        "intelliquiz_src_types.GeneratedQuizQuestionDTO": {
+            "type": "object",
+            "properties": {
+                "choices": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/intelliquiz_src_types.GetCategoryQuizDTO"
+                        "$ref": "#/definitions/intelliquiz_src_types.GeneratedChoiceDTO"
                     }
+                },
+                "question_content": {
+                    "type": "string",
+                    "example": "What is the capital of France?"
                 }
             }
         },
-        "intelliquiz_src_types.ChoiceDTO": {
+        "intelliquiz_src_types.GeneratedSourceQuestionDTO": {
             "type": "object",
             "properties": {
-                "content": {
+                "choices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.GeneratedChoiceDTO"
+                    }
+                },
+                "question_content": {
                     "type": "string",
-                    "example": "Paris"
+                    "example": "Into what do plants convert light during photosynthesis?"
                 },
-                "created_at": {
+                "source_excerpt": {
                     "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                    "example": "A fotossíntese é o processo pelo qual as plantas convertem luz em energia química."
+                }
+            }
+        },
+        "intelliquiz_src_types.GeneratedSourceQuizDataDTO": {
+            "type": "object",
+            "properties": {
+                "draft_id": {
+                    "type": "string",
+                    "example": "a1e4a6b0-8c4b-4f52-9d0f-2b7c9a8e6f10"
                 },
-                "id": {
+                "questions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.GeneratedSourceQuestionDTO"
+                    }
+                },
+                "quiz_title": {
                     "type": "string",
-                    "example": "4fdb53f5-74d2-4d0e-8267-43f893a51aca"
+                    "example": "Photosynthesis Basics"
+                }
+            }
+        },
+        "intelliquiz_src_types.GetCategoriesSuccessResponseStruct": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.CategoryResponseStruct"
+                    }
                 },
-                "is_correct": {
+                "statusCode": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "success": {
                     "type": "boolean",
                     "example": true
+                }
+            }
+        },
+        "intelliquiz_src_types.GetCategoryQuizDTO": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
                 },
-                "question_id": {
+                "created_by": {
                     "type": "string",
-                    "example": "d27b21ab-6177-4159-9e13-15dc50ffed29"
+                    "example": "user123"
                 },
-                "updated_at": {
+                "id": {
                     "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "General Knowledge Quiz"
                 }
             }
         },
-        "intelliquiz_src_types.ChoicesCreateQuestionDTO": {
+        "intelliquiz_src_types.GetCategorySuccessResponseStruct": {
             "type": "object",
-            "required": [
-                "content",
-                "is_correct"
-            ],
             "properties": {
-                "content": {
-                    "type": "string",
-                    "example": "Paris"
+                "data": {
+                    "$ref": "#/definitions/intelliquiz_src_types.CategoryWithChildrenResponseStruct"
                 },
-                "is_correct": {
+                "statusCode": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "success": {
                     "type": "boolean",
                     "example": true
                 }
             }
         },
-        "intelliquiz_src_types.CreateChoiceRequestBody": {
+        "intelliquiz_src_types.GetChoiceSuccessResponseStruct": {
             "type": "object",
-            "required": [
-                "content"
-            ],
             "properties": {
-                "content": {
-                    "type": "string",
-                    "example": "Paris"
+                "data": {
+                    "$ref": "#/definitions/intelliquiz_src_types.ChoiceDTO"
+                },
+                "statusCode": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "intelliquiz_src_types.GetChoicesSuccessResponseStruct": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.ChoiceDTO"
+                    }
+                },
+                "statusCode": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "intelliquiz_src_types.CreateChoiceSuccessResponseStruct": {
+        "intelliquiz_src_types.GetDraftSuccessResponseStruct": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/intelliquiz_src_types.ChoiceDTO"
+                    "$ref": "#/definitions/intelliquiz_src_types.QuizDraftResponseDTO"
                 },
                 "statusCode": {
                     "type": "integer",
-                    "example": 201
+                    "example": 200
                 },
                 "success": {
                     "type": "boolean",
@@ -2087,116 +6060,96 @@ const docTemplate = `{
                 }
             }
         },
-        "intelliquiz_src_types.CreateQuestionRequestBody": {
+        "intelliquiz_src_types.GetDraftsDataField": {
             "type": "object",
-            "required": [
-                "choices",
-                "content",
-                "quiz_id"
-            ],
             "properties": {
-                "choices": {
+                "drafts": {
                     "type": "array",
-                    "minItems": 2,
                     "items": {
-                        "$ref": "#/definitions/intelliquiz_src_types.ChoicesCreateQuestionDTO"
+                        "$ref": "#/definitions/intelliquiz_src_types.QuizDraftResponseDTO"
                     }
                 },
-                "content": {
-                    "type": "string",
-                    "example": "What is the capital of France?"
-                },
-                "quiz_id": {
-                    "type": "string",
-                    "example": "4fdb53f5-74d2-4d0e-8267-43f893a51aca"
+                "maxPage": {
+                    "type": "integer",
+                    "example": 10
                 }
             }
         },
-        "intelliquiz_src_types.CreateQuestionSuccessResponseStruct": {
+        "intelliquiz_src_types.GetDraftsSuccessResponseStruct": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/intelliquiz_src_types.QuestionResponseDTO"
+                    "$ref": "#/definitions/intelliquiz_src_types.GetDraftsDataField"
                 },
                 "statusCode": {
                     "type": "integer",
-                    "default": 201
+                    "example": 200
                 },
                 "success": {
                     "type": "boolean",
-                    "default": true
+                    "example": true
                 }
             }
         },
-        "intelliquiz_src_types.CreateQuizQuestionChoiceStruct": {
+        "intelliquiz_src_types.GetFlaggedQuizzesDataField": {
             "type": "object",
             "properties": {
-                "content": {
-                    "type": "string",
-                    "example": "Paris"
+                "maxPage": {
+                    "type": "integer",
+                    "example": 10
                 },
-                "is_correct": {
-                    "type": "boolean",
-                    "example": true
+                "quizzes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.FlaggedQuizResponseDTO"
+                    }
                 }
             }
         },
-        "intelliquiz_src_types.CreateQuizQuestionsStruct": {
+        "intelliquiz_src_types.GetFlaggedQuizzesSuccessResponseStruct": {
             "type": "object",
-            "required": [
-                "choices",
-                "content"
-            ],
             "properties": {
-                "choices": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/intelliquiz_src_types.CreateQuizQuestionChoiceStruct"
-                    }
+                "data": {
+                    "$ref": "#/definitions/intelliquiz_src_types.GetFlaggedQuizzesDataField"
                 },
-                "content": {
-                    "type": "string",
-                    "example": "What is the capital of France?"
+                "statusCode": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "intelliquiz_src_types.CreateQuizRequestBody": {
+        "intelliquiz_src_types.GetNotificationsDataField": {
             "type": "object",
-            "required": [
-                "category_id",
-                "name",
-                "questions"
-            ],
             "properties": {
-                "category_id": {
-                    "type": "string",
-                    "example": "d27b21ab-6177-4159-9e13-15dc50ffed29"
-                },
-                "image_url": {
-                    "type": "string",
-                    "example": "https://example.com/image.jpg"
-                },
-                "name": {
-                    "type": "string",
-                    "example": "Sample Quiz"
+                "maxPage": {
+                    "type": "integer",
+                    "example": 10
                 },
-                "questions": {
+                "notifications": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/intelliquiz_src_types.CreateQuizQuestionsStruct"
+                        "$ref": "#/definitions/intelliquiz_src_types.NotificationResponseDTO"
                     }
+                },
+                "unreadCount": {
+                    "type": "integer",
+                    "example": 2
                 }
             }
         },
-        "intelliquiz_src_types.CreateQuizSuccessResponseStruct": {
+        "intelliquiz_src_types.GetNotificationsSuccessResponseStruct": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/intelliquiz_src_types.QuizResponseDTO"
+                    "$ref": "#/definitions/intelliquiz_src_types.GetNotificationsDataField"
                 },
                 "statusCode": {
                     "type": "integer",
-                    "example": 201
+                    "example": 200
                 },
                 "success": {
                     "type": "boolean",
@@ -2204,254 +6157,170 @@ const docTemplate = `{
                 }
             }
         },
-        "intelliquiz_src_types.ForbiddenErrorResponseStruct": {
+        "intelliquiz_src_types.GetOwnAIUsageDataField": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "Forbidden"
-                },
-                "statusCode": {
-                    "type": "integer",
-                    "example": 403
+                "day": {
+                    "$ref": "#/definitions/intelliquiz_src_types.AIUsagePeriodDTO"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": false
+                "month": {
+                    "$ref": "#/definitions/intelliquiz_src_types.AIUsagePeriodDTO"
                 }
             }
         },
-        "intelliquiz_src_types.GameQuestionChoiceDTO": {
+        "intelliquiz_src_types.GetOwnAIUsageSuccessResponseStruct": {
             "type": "object",
             "properties": {
-                "content": {
-                    "type": "string",
-                    "example": "Choice content"
+                "data": {
+                    "$ref": "#/definitions/intelliquiz_src_types.GetOwnAIUsageDataField"
                 },
-                "id": {
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                "statusCode": {
+                    "type": "integer",
+                    "example": 200
                 },
-                "question_id": {
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                "success": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "intelliquiz_src_types.GameQuestionDTO": {
+        "intelliquiz_src_types.GetOwnQuizzesDataField": {
             "type": "object",
             "properties": {
-                "choices": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/intelliquiz_src_types.GameQuestionChoiceDTO"
-                    }
+                "maxPage": {
+                    "type": "integer",
+                    "example": 10
                 },
-                "content": {
+                "next_cursor": {
                     "type": "string",
-                    "example": "Question content"
+                    "example": "eyJzIjoibmV3ZXN0IiwidiI6W119"
                 },
-                "id": {
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440002"
+                "prev_cursor": {
+                    "type": "string"
                 },
-                "quiz_id": {
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440002"
+                "quizzes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.QuizResponseDTO"
+                    }
                 }
             }
         },
-        "intelliquiz_src_types.GameQuestionResultDTO": {
+        "intelliquiz_src_types.GetOwnQuizzesSuccessResponseStruct": {
             "type": "object",
             "properties": {
-                "answered_at": {
-                    "type": "string",
-                    "example": "2025-10-25T18:45:27.849543Z"
-                },
-                "choice": {
-                    "$ref": "#/definitions/intelliquiz_src_types.GameResultChoiceDTO"
-                },
-                "choice_id": {
-                    "type": "string",
-                    "example": "04da923c-314a-41c6-98fc-8a39a992d5c0"
-                },
-                "created_at": {
-                    "type": "string",
-                    "example": "2025-10-25T18:45:10.258139Z"
-                },
-                "game_id": {
-                    "type": "string",
-                    "example": "38822b7e-1a36-492e-bfc3-8c26131a278f"
+                "data": {
+                    "$ref": "#/definitions/intelliquiz_src_types.GetOwnQuizzesDataField"
                 },
-                "id": {
-                    "type": "string",
-                    "example": "047bdcd1-fd82-43f2-895a-8ad7fc7206e3"
+                "statusCode": {
+                    "type": "integer",
+                    "example": 200
                 },
-                "is_correct": {
+                "success": {
                     "type": "boolean",
                     "example": true
-                },
-                "position": {
-                    "type": "integer",
-                    "example": 0
-                },
-                "question": {
-                    "$ref": "#/definitions/intelliquiz_src_types.GameResultQuestionDTO"
-                },
-                "question_id": {
-                    "type": "string",
-                    "example": "c9118e52-e912-4396-9f66-f8976f84e935"
-                },
-                "seconds_taken": {
-                    "type": "integer",
-                    "example": 17
-                },
-                "updated_at": {
-                    "type": "string",
-                    "example": "2025-10-25T18:45:27.84965Z"
                 }
             }
         },
-        "intelliquiz_src_types.GameResultChoiceDTO": {
+        "intelliquiz_src_types.GetOwnUserSuccessResponseStruct": {
             "type": "object",
             "properties": {
-                "content": {
-                    "type": "string",
-                    "example": "Paris"
+                "data": {
+                    "$ref": "#/definitions/intelliquiz_src_types.UserWithEmailResponseStruct"
                 },
-                "id": {
-                    "type": "string",
-                    "example": "04da923c-314a-41c6-98fc-8a39a992d5c0"
-                }
-            }
-        },
-        "intelliquiz_src_types.GameResultDetailedDataStruct": {
-            "type": "object",
-            "properties": {
-                "game": {
-                    "$ref": "#/definitions/intelliquiz_src_types.GameResultGameDTO"
+                "statusCode": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "intelliquiz_src_types.GameResultDetailedResponseStruct": {
+        "intelliquiz_src_types.GetQuestionSuccessResponseStruct": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/intelliquiz_src_types.GameResultDetailedDataStruct"
+                    "$ref": "#/definitions/intelliquiz_src_types.QuestionResponseDTO"
                 },
-                "status_code": {
+                "statusCode": {
                     "type": "integer",
-                    "example": 200
+                    "default": 200
                 },
                 "success": {
                     "type": "boolean",
-                    "example": true
+                    "default": true
                 }
             }
         },
-        "intelliquiz_src_types.GameResultGameDTO": {
+        "intelliquiz_src_types.GetQuestionsSuccessResponseStruct": {
             "type": "object",
             "properties": {
-                "correct_answers": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "created_at": {
-                    "type": "string",
-                    "example": "2025-10-25T18:45:10.256695Z"
-                },
-                "finished_at": {
-                    "type": "string",
-                    "example": "2025-10-25T18:45:27.849543Z"
-                },
-                "game_questions": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/intelliquiz_src_types.GameQuestionResultDTO"
-                    }
-                },
-                "id": {
-                    "type": "string",
-                    "example": "38822b7e-1a36-492e-bfc3-8c26131a278f"
-                },
-                "total_questions": {
-                    "type": "integer",
-                    "example": 2
+                        "$ref": "#/definitions/intelliquiz_src_types.QuestionResponseDTO"
+                    }
                 },
-                "total_seconds_taken": {
+                "statusCode": {
                     "type": "integer",
-                    "example": 24
-                },
-                "updated_at": {
-                    "type": "string",
-                    "example": "2025-10-25T18:45:45.67655Z"
+                    "default": 200
                 },
-                "user_id": {
-                    "type": "string",
-                    "example": "4b97df8d-7616-47da-858f-acddb95d675a"
+                "success": {
+                    "type": "boolean",
+                    "default": true
                 }
             }
         },
-        "intelliquiz_src_types.GameResultQuestionDTO": {
+        "intelliquiz_src_types.GetQuizSuccessResponseStruct": {
             "type": "object",
             "properties": {
-                "content": {
-                    "type": "string",
-                    "example": "Qual a capital da França?"
+                "data": {
+                    "$ref": "#/definitions/intelliquiz_src_types.QuizDetailedResponseDTO"
                 },
-                "id": {
-                    "type": "string",
-                    "example": "c9118e52-e912-4396-9f66-f8976f84e935"
+                "statusCode": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "intelliquiz_src_types.GamesResultsDataStruct": {
+        "intelliquiz_src_types.GetQuizzesDataField": {
             "type": "object",
             "properties": {
-                "correct_answers": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "created_at": {
-                    "type": "string",
-                    "example": "2025-10-25T18:45:10.256695Z"
-                },
-                "finished_at": {
-                    "type": "string",
-                    "example": "2025-10-25T18:45:27.849543Z"
-                },
-                "id": {
-                    "type": "string",
-                    "example": "38822b7e-1a36-492e-bfc3-8c26131a278f"
-                },
-                "total_questions": {
-                    "type": "integer",
-                    "example": 2
+                "facets": {
+                    "$ref": "#/definitions/intelliquiz_src_types.QuizFacetsDTO"
                 },
-                "total_seconds_taken": {
+                "maxPage": {
                     "type": "integer",
-                    "example": 24
+                    "example": 10
                 },
-                "updated_at": {
+                "next_cursor": {
                     "type": "string",
-                    "example": "2025-10-25T18:45:45.67655Z"
+                    "example": "eyJzIjoibmV3ZXN0IiwidiI6W119"
                 },
-                "user_id": {
-                    "type": "string",
-                    "example": "4b97df8d-7616-47da-858f-acddb95d675a"
+                "prev_cursor": {
+                    "type": "string"
+                },
+                "quizzes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.QuizResponseDTO"
+                    }
                 }
             }
         },
-        "intelliquiz_src_types.GamesResultsResponseStruct": {
+        "intelliquiz_src_types.GetQuizzesSuccessResponseStruct": {
             "type": "object",
             "properties": {
                 "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/intelliquiz_src_types.GamesResultsDataStruct"
-                    }
+                    "$ref": "#/definitions/intelliquiz_src_types.GetQuizzesDataField"
                 },
-                "status_code": {
+                "statusCode": {
                     "type": "integer",
                     "example": 200
                 },
@@ -2461,28 +6330,26 @@ const docTemplate = `{
                 }
             }
         },
-        "intelliquiz_src_types.GenerateQuestionRequestDTO": {
+        "intelliquiz_src_types.GetReportsDataField": {
             "type": "object",
-            "required": [
-                "category_id",
-                "quiz_title"
-            ],
             "properties": {
-                "category_id": {
-                    "type": "string",
-                    "example": "d27b21ab-6177-4159-9e13-15dc50ffed29"
+                "maxPage": {
+                    "type": "integer",
+                    "example": 10
                 },
-                "quiz_title": {
-                    "type": "string",
-                    "example": "Geography Quiz"
+                "reports": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.AdminReportResponseDTO"
+                    }
                 }
             }
         },
-        "intelliquiz_src_types.GenerateQuestionSuccessResponseDTO": {
+        "intelliquiz_src_types.GetReportsSuccessResponseStruct": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/intelliquiz_src_types.GeneratedQuestionDataDTO"
+                    "$ref": "#/definitions/intelliquiz_src_types.GetReportsDataField"
                 },
                 "statusCode": {
                     "type": "integer",
@@ -2494,23 +6361,33 @@ const docTemplate = `{
                 }
             }
         },
-        "intelliquiz_src_types.GenerateQuizRequestDTO": {
+        "intelliquiz_src_types.GetUsersPageStruct": {
             "type": "object",
-            "required": [
-                "category_id"
-            ],
             "properties": {
-                "category_id": {
+                "maxPage": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "next_cursor": {
                     "type": "string",
-                    "example": "d27b21ab-6177-4159-9e13-15dc50ffed29"
+                    "example": "eyJzIjoidXNlcm5hbWUiLCJ2IjpbXX0"
+                },
+                "prev_cursor": {
+                    "type": "string"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.UserResponseStruct"
+                    }
                 }
             }
         },
-        "intelliquiz_src_types.GenerateQuizSuccessResponseDTO": {
+        "intelliquiz_src_types.GetUsersSuccessResponseStruct": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/intelliquiz_src_types.GeneratedQuizDataDTO"
+                    "$ref": "#/definitions/intelliquiz_src_types.GetUsersPageStruct"
                 },
                 "statusCode": {
                     "type": "integer",
@@ -2522,109 +6399,197 @@ const docTemplate = `{
                 }
             }
         },
-        "intelliquiz_src_types.GeneratedChoiceDTO": {
+        "intelliquiz_src_types.HomePageBecauseYouLikedDTO": {
             "type": "object",
             "properties": {
-                "content": {
-                    "type": "string",
-                    "example": "Paris"
+                "quiz": {
+                    "$ref": "#/definitions/intelliquiz_src_types.HomePageQuizDTO"
                 },
-                "is_correct": {
-                    "type": "boolean",
-                    "example": true
+                "quizzes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.HomePageQuizDTO"
+                    }
                 }
             }
         },
-        "intelliquiz_src_types.GeneratedQuestionDataDTO": {
+        "intelliquiz_src_types.HomePageCategoryDTO": {
             "type": "object",
             "properties": {
-                "choices": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/intelliquiz_src_types.GeneratedChoiceDTO"
-                    }
+                "icon_url": {
+                    "type": "string",
+                    "example": "https://example.com/icons/geografia.png"
                 },
-                "question_content": {
+                "id": {
                     "type": "string",
-                    "example": "What is the capital of France?"
+                    "example": "69f93509-275f-4c04-b5da-f105f4764830"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Geografia"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "geografia"
                 }
             }
         },
-        "intelliquiz_src_types.GeneratedQuizDataDTO": {
+        "intelliquiz_src_types.HomePageCategoryQuizzesDTO": {
             "type": "object",
             "properties": {
-                "questions": {
+                "category": {
+                    "$ref": "#/definitions/intelliquiz_src_types.HomePageCategoryDTO"
+                },
+                "quizzes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/intelliquiz_src_types.GeneratedQuizQuestionDTO"
+                        "$ref": "#/definitions/intelliquiz_src_types.HomePageQuizDTO"
                     }
+                }
+            }
+        },
+        "intelliquiz_src_types.HomePageContinuePlayingDTO": {
+            "type": "object",
+            "properties": {
+                "game_id": {
+                    "type": "string",
+                    "example": "622ad40f-44d0-416d-beb0-d62c7c7abb2e"
                 },
-                "quiz_title": {
+                "quiz": {
+                    "$ref": "#/definitions/intelliquiz_src_types.HomePageQuizDTO"
+                },
+                "started_at": {
                     "type": "string",
-                    "example": "European Capitals Quiz"
+                    "example": "2025-11-04T21:35:49.803868Z"
                 }
             }
         },
-        "intelliquiz_src_types.GeneratedQuizQuestionDTO": {
+        "intelliquiz_src_types.HomePageDataField": {
             "type": "object",
             "properties": {
-                "choices": {
+                "bestQuizzesOfMonth": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/intelliquiz_src_types.GeneratedChoiceDTO"
+                        "$ref": "#/definitions/intelliquiz_src_types.HomePageQuizDTO"
                     }
                 },
-                "question_content": {
+                "curatedQuizzes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.HomePageQuizDTO"
+                    }
+                },
+                "forYou": {
+                    "$ref": "#/definitions/intelliquiz_src_types.HomePageForYouDTO"
+                },
+                "mostLikedQuizzes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.HomePageQuizDTO"
+                    }
+                },
+                "mostPlayedQuizzes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.HomePageQuizDTO"
+                    }
+                },
+                "newlyAddedQuizzes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.HomePageQuizDTO"
+                    }
+                },
+                "refreshedAt": {
                     "type": "string",
-                    "example": "What is the capital of France?"
+                    "example": "2025-11-04T21:40:00.000000Z"
                 }
             }
         },
-        "intelliquiz_src_types.GetCategoriesSuccessResponseStruct": {
+        "intelliquiz_src_types.HomePageForYouDTO": {
             "type": "object",
             "properties": {
-                "data": {
+                "becauseYouLiked": {
+                    "$ref": "#/definitions/intelliquiz_src_types.HomePageBecauseYouLikedDTO"
+                },
+                "continuePlaying": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/intelliquiz_src_types.CategoryResponseStruct"
+                        "$ref": "#/definitions/intelliquiz_src_types.HomePageContinuePlayingDTO"
                     }
                 },
-                "statusCode": {
-                    "type": "integer",
-                    "example": 200
-                },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "topCategories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.HomePageCategoryQuizzesDTO"
+                    }
                 }
             }
         },
-        "intelliquiz_src_types.GetCategoryQuizDTO": {
+        "intelliquiz_src_types.HomePageQuizDTO": {
             "type": "object",
             "properties": {
+                "category": {
+                    "$ref": "#/definitions/intelliquiz_src_types.HomePageCategoryDTO"
+                },
                 "category_id": {
                     "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                    "example": "69f93509-275f-4c04-b5da-f105f4764830"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-11-04T21:35:49.803868Z"
                 },
                 "created_by": {
                     "type": "string",
-                    "example": "user123"
+                    "example": "ece40bba-484f-4de3-9ca6-9aa5cf56220b"
+                },
+                "curator_pick": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "games_played": {
+                    "type": "integer",
+                    "example": 1
                 },
                 "id": {
                     "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                    "example": "95e85c0b-ea32-437f-91a3-8daaeb492951"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://example.com/images/franca.png"
+                },
+                "likes": {
+                    "type": "integer",
+                    "example": 1
                 },
                 "name": {
                     "type": "string",
-                    "example": "General Knowledge Quiz"
+                    "example": "Geografia da França"
+                },
+                "score": {
+                    "type": "number",
+                    "example": 1
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-11-04T21:40:51.906957Z"
+                },
+                "user": {
+                    "$ref": "#/definitions/intelliquiz_src_types.HomePageUserDTO"
                 }
             }
         },
-        "intelliquiz_src_types.GetCategorySuccessResponseStruct": {
+        "intelliquiz_src_types.HomePageSuccessResponseStruct": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/intelliquiz_src_types.CategoryResponseStruct"
+                    "$ref": "#/definitions/intelliquiz_src_types.HomePageDataField"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Quizzes retrieved successfully"
                 },
                 "statusCode": {
                     "type": "integer",
@@ -2636,77 +6601,107 @@ const docTemplate = `{
                 }
             }
         },
-        "intelliquiz_src_types.GetChoiceSuccessResponseStruct": {
+        "intelliquiz_src_types.HomePageUserDTO": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/intelliquiz_src_types.ChoiceDTO"
-                },
-                "statusCode": {
-                    "type": "integer",
-                    "example": 200
+                "id": {
+                    "type": "string",
+                    "example": "ece40bba-484f-4de3-9ca6-9aa5cf56220b"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "username": {
+                    "type": "string",
+                    "example": "newyann"
                 }
             }
         },
-        "intelliquiz_src_types.GetChoicesSuccessResponseStruct": {
+        "intelliquiz_src_types.ImportQuizErrorResponseStruct": {
             "type": "object",
             "properties": {
-                "data": {
+                "errors": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/intelliquiz_src_types.ChoiceDTO"
+                        "$ref": "#/definitions/intelliquiz_src_types.ImportQuizRowErrorDTO"
                     }
                 },
+                "message": {
+                    "type": "string",
+                    "example": "The quiz file has invalid rows."
+                },
                 "statusCode": {
                     "type": "integer",
-                    "example": 200
+                    "example": 422
                 },
                 "success": {
                     "type": "boolean",
-                    "example": true
+                    "example": false
                 }
             }
         },
-        "intelliquiz_src_types.GetOwnQuizzesDataField": {
+        "intelliquiz_src_types.ImportQuizRowErrorDTO": {
             "type": "object",
             "properties": {
-                "maxPage": {
-                    "type": "integer",
-                    "example": 10
+                "message": {
+                    "type": "string",
+                    "example": "Only one correct choice can be specified for the question: What is the capital of France?"
                 },
-                "quizzes": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/intelliquiz_src_types.QuizResponseDTO"
-                    }
+                "row": {
+                    "type": "integer",
+                    "example": 3
                 }
             }
         },
-        "intelliquiz_src_types.GetOwnQuizzesSuccessResponseStruct": {
+        "intelliquiz_src_types.InternalServerErrorResponseStruct": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/intelliquiz_src_types.GetOwnQuizzesDataField"
+                "message": {
+                    "type": "string",
+                    "default": "Internal Server Error"
                 },
                 "statusCode": {
                     "type": "integer",
-                    "example": 200
+                    "default": 500
                 },
                 "success": {
                     "type": "boolean",
-                    "example": true
+                    "default": false
                 }
             }
         },
-        "intelliquiz_src_types.GetOwnUserSuccessResponseStruct": {
+        "intelliquiz_src_types.LoginRequestBody": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "password123"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "johndoe"
+                }
+            }
+        },
+        "intelliquiz_src_types.LoginResponseData": {
+            "type": "object",
+            "properties": {
+                "refreshToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJSUzI1NiIsImtpZCI6IjY0Y2I3Y2E3Y2I3Y2E3Y2I3Y2E3Y2E3Y2E3Y2E3Y2EifQ.eyJpc3MiOiJodHRwczovL2FjY291bnRzLmdvb2dsZS5jb20iLCJhdWQiOiJpbnRlbGxpcXVpei1jMjczNCIsImF1dGhfdGltZSI6MTcwMTIzNzM5NSwidXNlcl9pZCI6Ijg5ZjI4ZjA4MjM0NmRiMGI4ODAzZDIyMiIsInN1YiI6Ijg5ZjI4ZjA4MjM0NmRiMGI4ODAzZDIyMiIsImlhdCI6MTcwMTIzNzM5NSwiZXhwIjoxNzAxMjQxOTk1LCJlbWFpbCI6InlhbkBnbWFpbC5jb20iLCJlbWFpbF92ZXJpZmllZCI6dHJ1ZX0.XYZ"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJSUzI1NiIsImtpZCI6IjY0Y2I3Y2E3Y2I3Y2E3Y2I3Y2E3Y2E3Y2E3Y2E3Y2EifQ.eyJpc3MiOiJodHRwczovL2FjY291bnRzLmdvb2dsZS5jb20iLCJhdWQiOiJpbnRlbGxpcXVpei1jMjczNCIsImF1dGhfdGltZSI6MTcwMTIzNzM5NSwidXNlcl9pZCI6Ijg5ZjI4ZjA4MjM0NmRiMGI4ODAzZDIyMiIsInN1YiI6Ijg5ZjI4ZjA4MjM0NmRiMGI4ODAzZDIyMiIsImlhdCI6MTcwMTIzNzM5NSwiZXhwIjoxNzAxMjQxOTk1LCJlbWFpbCI6InlhbkBnbWFpbC5jb20iLCJlbWFpbF92ZXJpZmllZCI6dHJ1ZX0.XYZ"
+                }
+            }
+        },
+        "intelliquiz_src_types.LoginResponseStruct": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/intelliquiz_src_types.UserWithEmailResponseStruct"
+                    "$ref": "#/definitions/intelliquiz_src_types.LoginResponseData"
                 },
                 "statusCode": {
                     "type": "integer",
@@ -2718,305 +6713,535 @@ const docTemplate = `{
                 }
             }
         },
-        "intelliquiz_src_types.GetQuestionSuccessResponseStruct": {
+        "intelliquiz_src_types.NotFoundErrorResponseStruct": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/intelliquiz_src_types.QuestionResponseDTO"
+                "message": {
+                    "type": "string",
+                    "example": "Not Found"
                 },
                 "statusCode": {
                     "type": "integer",
-                    "default": 200
+                    "example": 404
                 },
                 "success": {
                     "type": "boolean",
-                    "default": true
+                    "example": false
                 }
             }
         },
-        "intelliquiz_src_types.GetQuestionsSuccessResponseStruct": {
+        "intelliquiz_src_types.NotificationResponseDTO": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/intelliquiz_src_types.QuestionResponseDTO"
-                    }
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-10-23T08:12:40.112233445Z"
                 },
-                "statusCode": {
-                    "type": "integer",
-                    "default": 200
+                "id": {
+                    "type": "string",
+                    "example": "9a8b7c6d-5e4f-4a3b-9c2d-1e0f9a8b7c6d"
                 },
-                "success": {
-                    "type": "boolean",
-                    "default": true
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "report_resolved",
+                        "quiz_moderated"
+                    ],
+                    "example": "report_resolved"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Your report on \"Oceania Capitals\" was reviewed and the reported content was removed."
+                },
+                "quiz_id": {
+                    "type": "string",
+                    "example": "4fdb53f5-74d2-4d0e-8267-43f893a51aca"
+                },
+                "read_at": {
+                    "type": "string",
+                    "example": "2025-10-23T09:00:00.000000000Z"
+                },
+                "report_id": {
+                    "type": "string",
+                    "example": "7c0e5b8e-0f43-4f0b-8d38-2a7f3b1f4e21"
                 }
             }
         },
-        "intelliquiz_src_types.GetQuizSuccessResponseStruct": {
+        "intelliquiz_src_types.PaymentRequiredErrorResponseStruct": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/intelliquiz_src_types.QuizDetailedResponseDTO"
+                "message": {
+                    "type": "string",
+                    "default": "Payment required"
                 },
                 "statusCode": {
                     "type": "integer",
-                    "example": 200
+                    "default": 402
                 },
                 "success": {
                     "type": "boolean",
-                    "example": true
+                    "default": false
                 }
             }
         },
-        "intelliquiz_src_types.GetQuizzesDataField": {
+        "intelliquiz_src_types.QuestionResponseDTO": {
             "type": "object",
             "properties": {
-                "maxPage": {
-                    "type": "integer",
-                    "example": 10
+                "content": {
+                    "type": "string",
+                    "default": "What is the capital of France?"
                 },
-                "quizzes": {
+                "id": {
+                    "type": "string",
+                    "default": "4fdb53f5-74d2-4d0e-8267-43f893a51aca"
+                },
+                "quiz_id": {
+                    "type": "string",
+                    "default": "d27b21ab-6177-4159-9e13-15dc50ffed29"
+                },
+                "type": {
+                    "type": "string",
+                    "default": "single_choice"
+                }
+            }
+        },
+        "intelliquiz_src_types.QuestionReviewDTO": {
+            "type": "object",
+            "properties": {
+                "issues": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/intelliquiz_src_types.QuizResponseDTO"
+                        "$ref": "#/definitions/intelliquiz_src_types.ReviewIssueDTO"
                     }
+                },
+                "question_content": {
+                    "type": "string",
+                    "example": "What is the capital?"
+                },
+                "question_id": {
+                    "type": "string",
+                    "example": "5d9c1a3e-2b4f-4e6a-8c7d-9f0e1a2b3c4d"
                 }
             }
         },
-        "intelliquiz_src_types.GetQuizzesSuccessResponseStruct": {
+        "intelliquiz_src_types.QuizChoiceResponseDTO": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/intelliquiz_src_types.GetQuizzesDataField"
+                "content": {
+                    "type": "string",
+                    "example": "Paris"
                 },
-                "statusCode": {
-                    "type": "integer",
-                    "example": 200
+                "id": {
+                    "type": "string",
+                    "example": "05a93ef2-23a6-4793-a6dc-0167bae5150f"
                 },
-                "success": {
+                "is_correct": {
                     "type": "boolean",
                     "example": true
+                },
+                "position": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "question_id": {
+                    "type": "string",
+                    "example": "78712bb2-7005-4510-bff6-133359af04f9"
                 }
             }
         },
-        "intelliquiz_src_types.GetUsersSuccessResponseStruct": {
+        "intelliquiz_src_types.QuizDetailedResponseDTO": {
             "type": "object",
             "properties": {
-                "data": {
+                "category": {
+                    "$ref": "#/definitions/intelliquiz_src_types.CategoryQuizResponseDTOStruct"
+                },
+                "category_id": {
+                    "type": "string",
+                    "example": "d27b21ab-6177-4159-9e13-15dc50ffed29"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-10-22T19:01:58.778079424Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "0fde5216-1bab-41f6-bd90-4c3f088ee91f"
+                },
+                "curator_pick": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "games_played": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "id": {
+                    "type": "string",
+                    "example": "4fdb53f5-74d2-4d0e-8267-43f893a51aca"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://example.com/image.jpg"
+                },
+                "likes": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Sample Quiz"
+                },
+                "question_time_limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "questions": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/intelliquiz_src_types.UserResponseStruct"
+                        "$ref": "#/definitions/intelliquiz_src_types.QuizQuestionResponseDTO"
                     }
                 },
-                "statusCode": {
-                    "type": "integer",
-                    "example": 200
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-10-22T19:01:58.778079424Z"
+                },
+                "user": {
+                    "$ref": "#/definitions/intelliquiz_src_types.UserQuizResponseDTOStruct"
+                }
+            }
+        },
+        "intelliquiz_src_types.QuizDraftChoiceDTO": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "example": "Paris"
                 },
-                "success": {
+                "is_correct": {
                     "type": "boolean",
                     "example": true
                 }
             }
         },
-        "intelliquiz_src_types.HomePageCategoryDTO": {
+        "intelliquiz_src_types.QuizDraftQuestionDTO": {
             "type": "object",
             "properties": {
-                "id": {
+                "accepted_answers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Paris"
+                    ]
+                },
+                "choices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.QuizDraftChoiceDTO"
+                    }
+                },
+                "content": {
                     "type": "string",
-                    "example": "69f93509-275f-4c04-b5da-f105f4764830"
+                    "example": "What is the capital of France?"
                 },
-                "name": {
+                "explanation": {
                     "type": "string",
-                    "example": "Geografia"
+                    "maxLength": 1000,
+                    "example": "Paris has been the capital of France since 987."
+                },
+                "numeric_answer": {
+                    "type": "number",
+                    "example": 3.14
+                },
+                "numeric_tolerance": {
+                    "type": "number",
+                    "example": 0.01
+                },
+                "source_excerpt": {
+                    "type": "string",
+                    "example": "Paris is the capital and largest city of France."
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "single_choice",
+                        "multiple_select",
+                        "true_false",
+                        "ordering",
+                        "free_text",
+                        "numeric"
+                    ],
+                    "example": "single_choice"
                 }
             }
         },
-        "intelliquiz_src_types.HomePageDataField": {
+        "intelliquiz_src_types.QuizDraftResponseDTO": {
             "type": "object",
             "properties": {
-                "bestQuizzesOfMonth": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/intelliquiz_src_types.HomePageQuizDTO"
-                    }
+                "category_id": {
+                    "type": "string",
+                    "example": "d27b21ab-6177-4159-9e13-15dc50ffed29"
                 },
-                "curatedQuizzes": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/intelliquiz_src_types.HomePageQuizDTO"
-                    }
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-10-22T19:01:58.778079424Z"
                 },
-                "mostLikedQuizzes": {
+                "created_by": {
+                    "type": "string",
+                    "example": "0fde5216-1bab-41f6-bd90-4c3f088ee91f"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "a1e4a6b0-8c4b-4f52-9d0f-2b7c9a8e6f10"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://example.com/image.jpg"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "European Capitals Quiz"
+                },
+                "question_time_limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "questions": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/intelliquiz_src_types.HomePageQuizDTO"
+                        "$ref": "#/definitions/intelliquiz_src_types.QuizDraftQuestionDTO"
                     }
                 },
-                "mostPlayedQuizzes": {
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "generate-quiz",
+                        "generate-question",
+                        "generate-quiz-from-text"
+                    ],
+                    "example": "generate-quiz"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-10-22T19:01:58.778079424Z"
+                }
+            }
+        },
+        "intelliquiz_src_types.QuizFacetsDTO": {
+            "type": "object",
+            "properties": {
+                "categories": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/intelliquiz_src_types.HomePageQuizDTO"
+                        "$ref": "#/definitions/intelliquiz_src_types.CategoryFacetDTO"
                     }
                 },
-                "newlyAddedQuizzes": {
+                "curatorPick": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/intelliquiz_src_types.HomePageQuizDTO"
+                        "$ref": "#/definitions/intelliquiz_src_types.CuratorPickFacetDTO"
                     }
                 }
             }
         },
-        "intelliquiz_src_types.HomePageGameDTO": {
+        "intelliquiz_src_types.QuizQuestionResponseDTO": {
             "type": "object",
             "properties": {
-                "correct_answers": {
-                    "type": "integer",
-                    "example": 7
+                "choices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.QuizChoiceResponseDTO"
+                    }
+                },
+                "content": {
+                    "type": "string",
+                    "example": "Qual a capital da França?"
+                },
+                "explanation": {
+                    "type": "string",
+                    "example": "Paris has been the capital of France since 987."
                 },
                 "id": {
                     "type": "string",
-                    "example": "622ad40f-44d0-416d-beb0-d62c7c7abb2e"
+                    "example": "78712bb2-7005-4510-bff6-133359af04f9"
+                },
+                "numeric_answer": {
+                    "type": "number",
+                    "example": 3.14
+                },
+                "numeric_tolerance": {
+                    "type": "number",
+                    "example": 0.01
                 },
                 "quiz_id": {
                     "type": "string",
-                    "example": "95e85c0b-ea32-437f-91a3-8daaeb492951"
-                },
-                "total_questions": {
-                    "type": "integer",
-                    "example": 10
+                    "example": "304827d4-f291-4253-9a86-07d2305afd95"
                 },
-                "total_seconds_taken": {
-                    "type": "integer",
-                    "example": 120
+                "type": {
+                    "type": "string",
+                    "example": "single_choice"
                 }
             }
         },
-        "intelliquiz_src_types.HomePageQuizDTO": {
+        "intelliquiz_src_types.QuizResponseDTO": {
             "type": "object",
             "properties": {
                 "category": {
-                    "$ref": "#/definitions/intelliquiz_src_types.HomePageCategoryDTO"
+                    "$ref": "#/definitions/intelliquiz_src_types.CategoryQuizResponseDTOStruct"
                 },
                 "category_id": {
                     "type": "string",
-                    "example": "69f93509-275f-4c04-b5da-f105f4764830"
+                    "example": "d27b21ab-6177-4159-9e13-15dc50ffed29"
                 },
                 "created_at": {
                     "type": "string",
-                    "example": "2025-11-04T21:35:49.803868Z"
+                    "example": "2025-10-22T19:01:58.778079424Z"
                 },
                 "created_by": {
                     "type": "string",
-                    "example": "ece40bba-484f-4de3-9ca6-9aa5cf56220b"
+                    "example": "0fde5216-1bab-41f6-bd90-4c3f088ee91f"
                 },
                 "curator_pick": {
                     "type": "boolean",
                     "example": false
                 },
-                "games": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/intelliquiz_src_types.HomePageGameDTO"
-                    }
-                },
                 "games_played": {
                     "type": "integer",
-                    "example": 1
+                    "example": 0
                 },
                 "id": {
                     "type": "string",
-                    "example": "95e85c0b-ea32-437f-91a3-8daaeb492951"
+                    "example": "4fdb53f5-74d2-4d0e-8267-43f893a51aca"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://example.com/image.jpg"
                 },
                 "likes": {
                     "type": "integer",
-                    "example": 1
+                    "example": 0
+                },
+                "moderation_reason": {
+                    "type": "string",
+                    "example": "hate"
+                },
+                "moderation_status": {
+                    "type": "string",
+                    "enum": [
+                        "approved",
+                        "flagged",
+                        "hidden"
+                    ],
+                    "example": "approved"
                 },
                 "name": {
                     "type": "string",
-                    "example": "Geografia da França"
+                    "example": "Sample Quiz"
+                },
+                "question_time_limit": {
+                    "type": "integer",
+                    "example": 20
                 },
                 "score": {
                     "type": "number",
-                    "example": 1
+                    "example": 0.42
                 },
                 "updated_at": {
                     "type": "string",
-                    "example": "2025-11-04T21:40:51.906957Z"
+                    "example": "2025-10-22T19:01:58.778079424Z"
                 },
                 "user": {
-                    "$ref": "#/definitions/intelliquiz_src_types.HomePageUserDTO"
+                    "$ref": "#/definitions/intelliquiz_src_types.UserQuizResponseDTOStruct"
                 }
             }
         },
-        "intelliquiz_src_types.HomePageSuccessResponseStruct": {
+        "intelliquiz_src_types.QuizReviewDataDTO": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/intelliquiz_src_types.HomePageDataField"
+                "issue_count": {
+                    "type": "integer",
+                    "example": 2
                 },
-                "message": {
-                    "type": "string",
-                    "example": "Quizzes retrieved successfully"
+                "questions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.QuestionReviewDTO"
+                    }
                 },
-                "statusCode": {
-                    "type": "integer",
-                    "example": 200
+                "quiz_id": {
+                    "type": "string",
+                    "example": "3f1c2b4a-5d6e-4f70-8a9b-0c1d2e3f4a5b"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "summary": {
+                    "type": "string",
+                    "example": "The quiz is well written, but two questions are ambiguous."
                 }
             }
         },
-        "intelliquiz_src_types.HomePageUserDTO": {
+        "intelliquiz_src_types.QuizStreamQuestionEventDTO": {
             "type": "object",
             "properties": {
-                "id": {
+                "choices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.GeneratedChoiceDTO"
+                    }
+                },
+                "index": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "question_content": {
                     "type": "string",
-                    "example": "ece40bba-484f-4de3-9ca6-9aa5cf56220b"
+                    "example": "What is the capital of France?"
                 },
-                "username": {
+                "source_excerpt": {
                     "type": "string",
-                    "example": "newyann"
+                    "example": "Paris is the capital and largest city of France."
                 }
             }
         },
-        "intelliquiz_src_types.InternalServerErrorResponseStruct": {
+        "intelliquiz_src_types.RecommendedQuizzesDataField": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string",
-                    "default": "Internal Server Error"
+                "quizzes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.QuizResponseDTO"
+                    }
+                }
+            }
+        },
+        "intelliquiz_src_types.RecommendedQuizzesSuccessResponseStruct": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/intelliquiz_src_types.RecommendedQuizzesDataField"
                 },
                 "statusCode": {
                     "type": "integer",
-                    "default": 500
+                    "example": 200
                 },
                 "success": {
                     "type": "boolean",
-                    "default": false
+                    "example": true
                 }
             }
         },
-        "intelliquiz_src_types.LoginRequestBody": {
+        "intelliquiz_src_types.RefreshRequestBody": {
             "type": "object",
             "required": [
-                "password",
-                "username"
+                "refreshToken"
             ],
             "properties": {
-                "password": {
-                    "type": "string",
-                    "example": "password123"
-                },
-                "username": {
+                "refreshToken": {
                     "type": "string",
-                    "example": "johndoe"
+                    "example": "eyJhbGciOiJSUzI1NiIsImtpZCI6IjY0Y2I3Y2E3Y2I3Y2E3Y2I3Y2E3Y2E3Y2E3Y2E3Y2EifQ.eyJpc3MiOiJodHRwczovL2FjY291bnRzLmdvb2dsZS5jb20iLCJhdWQiOiJpbnRlbGxpcXVpei1jMjczNCIsImF1dGhfdGltZSI6MTcwMTIzNzM5NSwidXNlcl9pZCI6Ijg5ZjI4ZjA4MjM0NmRiMGI4ODAzZDIyMiIsInN1YiI6Ijg5ZjI4ZjA4MjM0NmRiMGI4ODAzZDIyMiIsImlhdCI6MTcwMTIzNzM5NSwiZXhwIjoxNzAxMjQxOTk1LCJlbWFpbCI6InlhbkBnbWFpbC5jb20iLCJlbWFpbF92ZXJpZmllZCI6dHJ1ZX0.XYZ"
                 }
             }
         },
-        "intelliquiz_src_types.LoginResponseData": {
+        "intelliquiz_src_types.RefreshResponseData": {
             "type": "object",
             "properties": {
                 "refreshToken": {
@@ -3029,15 +7254,15 @@ const docTemplate = `{
                 }
             }
         },
-        "intelliquiz_src_types.LoginResponseStruct": {
+        "intelliquiz_src_types.RefreshResponseStruct": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/intelliquiz_src_types.LoginResponseData"
+                    "$ref": "#/definitions/intelliquiz_src_types.RefreshResponseData"
                 },
                 "statusCode": {
                     "type": "integer",
-                    "example": 200
+                    "example": 201
                 },
                 "success": {
                     "type": "boolean",
@@ -3045,228 +7270,275 @@ const docTemplate = `{
                 }
             }
         },
-        "intelliquiz_src_types.NotFoundErrorResponseStruct": {
+        "intelliquiz_src_types.ReportQuestionDTO": {
             "type": "object",
             "properties": {
-                "message": {
+                "content": {
                     "type": "string",
-                    "example": "Not Found"
-                },
-                "statusCode": {
-                    "type": "integer",
-                    "example": 404
+                    "example": "What is the capital of Australia?"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": false
+                "id": {
+                    "type": "string",
+                    "example": "b3d1c6a2-5f1e-4c8e-9a7b-1e2d3c4b5a69"
                 }
             }
         },
-        "intelliquiz_src_types.QuestionResponseDTO": {
+        "intelliquiz_src_types.ReportQuizDTO": {
             "type": "object",
             "properties": {
-                "content": {
+                "created_by": {
                     "type": "string",
-                    "default": "What is the capital of France?"
+                    "example": "2a9c4e1d-6b7f-4a3e-8c5d-9f0e1b2c3d4e"
                 },
                 "id": {
                     "type": "string",
-                    "default": "4fdb53f5-74d2-4d0e-8267-43f893a51aca"
+                    "example": "4fdb53f5-74d2-4d0e-8267-43f893a51aca"
                 },
-                "quiz_id": {
+                "moderation_status": {
+                    "type": "string",
+                    "enum": [
+                        "approved",
+                        "flagged",
+                        "hidden"
+                    ],
+                    "example": "approved"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Oceania Capitals"
+                }
+            }
+        },
+        "intelliquiz_src_types.ReportReporterDTO": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "0fde5216-1bab-41f6-bd90-4c3f088ee91f"
+                },
+                "username": {
                     "type": "string",
-                    "default": "d27b21ab-6177-4159-9e13-15dc50ffed29"
+                    "example": "john_doe"
                 }
             }
         },
-        "intelliquiz_src_types.QuizChoiceResponseDTO": {
+        "intelliquiz_src_types.ReportRequestBody": {
             "type": "object",
+            "required": [
+                "reason"
+            ],
             "properties": {
-                "content": {
-                    "type": "string",
-                    "example": "Paris"
-                },
-                "id": {
+                "comment": {
                     "type": "string",
-                    "example": "05a93ef2-23a6-4793-a6dc-0167bae5150f"
-                },
-                "is_correct": {
-                    "type": "boolean",
-                    "example": true
+                    "maxLength": 500,
+                    "example": "The correct answer is Canberra, not Sydney."
                 },
-                "question_id": {
+                "reason": {
                     "type": "string",
-                    "example": "78712bb2-7005-4510-bff6-133359af04f9"
+                    "enum": [
+                        "wrong_answer",
+                        "offensive",
+                        "spam",
+                        "copyright",
+                        "other"
+                    ],
+                    "example": "wrong_answer"
                 }
             }
         },
-        "intelliquiz_src_types.QuizDetailedResponseDTO": {
+        "intelliquiz_src_types.ReportResponseDTO": {
             "type": "object",
             "properties": {
-                "category": {
-                    "$ref": "#/definitions/intelliquiz_src_types.CategoryQuizResponseDTOStruct"
-                },
-                "category_id": {
+                "comment": {
                     "type": "string",
-                    "example": "d27b21ab-6177-4159-9e13-15dc50ffed29"
+                    "example": "The correct answer is Canberra, not Sydney."
                 },
                 "created_at": {
                     "type": "string",
                     "example": "2025-10-22T19:01:58.778079424Z"
                 },
-                "created_by": {
+                "id": {
                     "type": "string",
-                    "example": "0fde5216-1bab-41f6-bd90-4c3f088ee91f"
-                },
-                "curator_pick": {
-                    "type": "boolean",
-                    "example": false
+                    "example": "7c0e5b8e-0f43-4f0b-8d38-2a7f3b1f4e21"
                 },
-                "games_played": {
-                    "type": "integer",
-                    "example": 0
+                "question_id": {
+                    "type": "string",
+                    "example": "b3d1c6a2-5f1e-4c8e-9a7b-1e2d3c4b5a69"
                 },
-                "id": {
+                "quiz_id": {
                     "type": "string",
                     "example": "4fdb53f5-74d2-4d0e-8267-43f893a51aca"
                 },
-                "image_url": {
+                "reason": {
                     "type": "string",
-                    "example": "https://example.com/image.jpg"
-                },
-                "likes": {
-                    "type": "integer",
-                    "example": 0
+                    "enum": [
+                        "wrong_answer",
+                        "offensive",
+                        "spam",
+                        "copyright",
+                        "other"
+                    ],
+                    "example": "wrong_answer"
                 },
-                "name": {
+                "reporter_id": {
                     "type": "string",
-                    "example": "Sample Quiz"
-                },
-                "questions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/intelliquiz_src_types.QuizQuestionResponseDTO"
-                    }
+                    "example": "0fde5216-1bab-41f6-bd90-4c3f088ee91f"
                 },
-                "updated_at": {
+                "status": {
                     "type": "string",
-                    "example": "2025-10-22T19:01:58.778079424Z"
-                },
-                "user": {
-                    "$ref": "#/definitions/intelliquiz_src_types.UserQuizResponseDTOStruct"
+                    "enum": [
+                        "open",
+                        "resolved",
+                        "dismissed"
+                    ],
+                    "example": "open"
                 }
             }
         },
-        "intelliquiz_src_types.QuizQuestionResponseDTO": {
+        "intelliquiz_src_types.ResolveReportDataField": {
             "type": "object",
             "properties": {
-                "choices": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/intelliquiz_src_types.QuizChoiceResponseDTO"
-                    }
-                },
-                "content": {
-                    "type": "string",
-                    "example": "Qual a capital da França?"
-                },
-                "id": {
-                    "type": "string",
-                    "example": "78712bb2-7005-4510-bff6-133359af04f9"
-                },
-                "quiz_id": {
-                    "type": "string",
-                    "example": "304827d4-f291-4253-9a86-07d2305afd95"
+                "closed_reports": {
+                    "type": "integer",
+                    "example": 3
                 }
             }
         },
-        "intelliquiz_src_types.QuizResponseDTO": {
+        "intelliquiz_src_types.ResolveReportRequestBody": {
             "type": "object",
+            "required": [
+                "status"
+            ],
             "properties": {
-                "category": {
-                    "$ref": "#/definitions/intelliquiz_src_types.CategoryQuizResponseDTOStruct"
-                },
-                "category_id": {
+                "action": {
                     "type": "string",
-                    "example": "d27b21ab-6177-4159-9e13-15dc50ffed29"
+                    "enum": [
+                        "none",
+                        "hide",
+                        "delete"
+                    ],
+                    "example": "hide"
                 },
-                "created_at": {
+                "note": {
                     "type": "string",
-                    "example": "2025-10-22T19:01:58.778079424Z"
+                    "maxLength": 500,
+                    "example": "The quiz contains offensive content."
                 },
-                "created_by": {
+                "status": {
                     "type": "string",
-                    "example": "0fde5216-1bab-41f6-bd90-4c3f088ee91f"
-                },
-                "curator_pick": {
-                    "type": "boolean",
-                    "example": false
+                    "enum": [
+                        "resolved",
+                        "dismissed"
+                    ],
+                    "example": "resolved"
+                }
+            }
+        },
+        "intelliquiz_src_types.ResolveReportSuccessResponseStruct": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/intelliquiz_src_types.ResolveReportDataField"
                 },
-                "games_played": {
+                "statusCode": {
                     "type": "integer",
-                    "example": 0
+                    "example": 200
                 },
-                "id": {
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "intelliquiz_src_types.ReviewIssueDTO": {
+            "type": "object",
+            "properties": {
+                "choice_id": {
                     "type": "string",
-                    "example": "4fdb53f5-74d2-4d0e-8267-43f893a51aca"
+                    "example": "6a0e1f5c-7d8e-4c2b-9a3f-1b2c3d4e5f60"
                 },
-                "image_url": {
+                "description": {
                     "type": "string",
-                    "example": "https://example.com/image.jpg"
-                },
-                "likes": {
-                    "type": "integer",
-                    "example": 0
+                    "example": "The question does not say which Paris it refers to."
                 },
-                "name": {
+                "kind": {
                     "type": "string",
-                    "example": "Sample Quiz"
+                    "enum": [
+                        "ambiguous_wording",
+                        "factual_doubt",
+                        "multiple_plausible_answers",
+                        "obvious_distractor",
+                        "spelling"
+                    ],
+                    "example": "ambiguous_wording"
                 },
-                "updated_at": {
+                "suggested_content": {
                     "type": "string",
-                    "example": "2025-10-22T19:01:58.778079424Z"
-                },
-                "user": {
-                    "$ref": "#/definitions/intelliquiz_src_types.UserQuizResponseDTOStruct"
+                    "example": "What is the capital of France?"
                 }
             }
         },
-        "intelliquiz_src_types.RefreshRequestBody": {
+        "intelliquiz_src_types.ReviewQuizModerationRequestBody": {
             "type": "object",
             "required": [
-                "refreshToken"
+                "status"
             ],
             "properties": {
-                "refreshToken": {
+                "note": {
                     "type": "string",
-                    "example": "eyJhbGciOiJSUzI1NiIsImtpZCI6IjY0Y2I3Y2E3Y2I3Y2E3Y2I3Y2E3Y2E3Y2E3Y2E3Y2EifQ.eyJpc3MiOiJodHRwczovL2FjY291bnRzLmdvb2dsZS5jb20iLCJhdWQiOiJpbnRlbGxpcXVpei1jMjczNCIsImF1dGhfdGltZSI6MTcwMTIzNzM5NSwidXNlcl9pZCI6Ijg5ZjI4ZjA4MjM0NmRiMGI4ODAzZDIyMiIsInN1YiI6Ijg5ZjI4ZjA4MjM0NmRiMGI4ODAzZDIyMiIsImlhdCI6MTcwMTIzNzM5NSwiZXhwIjoxNzAxMjQxOTk1LCJlbWFpbCI6InlhbkBnbWFpbC5jb20iLCJlbWFpbF92ZXJpZmllZCI6dHJ1ZX0.XYZ"
+                    "maxLength": 500,
+                    "example": "The flagged word is used in a historical context."
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "approved",
+                        "hidden"
+                    ],
+                    "example": "approved"
                 }
             }
         },
-        "intelliquiz_src_types.RefreshResponseData": {
+        "intelliquiz_src_types.ReviewQuizSuccessResponseDTO": {
             "type": "object",
             "properties": {
-                "refreshToken": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJSUzI1NiIsImtpZCI6IjY0Y2I3Y2E3Y2I3Y2E3Y2I3Y2E3Y2E3Y2E3Y2E3Y2EifQ.eyJpc3MiOiJodHRwczovL2FjY291bnRzLmdvb2dsZS5jb20iLCJhdWQiOiJpbnRlbGxpcXVpei1jMjczNCIsImF1dGhfdGltZSI6MTcwMTIzNzM5NSwidXNlcl9pZCI6Ijg5ZjI4ZjA4MjM0NmRiMGI4ODAzZDIyMiIsInN1YiI6Ijg5ZjI4ZjA4MjM0NmRiMGI4ODAzZDIyMiIsImlhdCI6MTcwMTIzNzM5NSwiZXhwIjoxNzAxMjQxOTk1LCJlbWFpbCI6InlhbkBnbWFpbC5jb20iLCJlbWFpbF92ZXJpZmllZCI6dHJ1ZX0.XYZ"
+                "data": {
+                    "$ref": "#/definitions/intelliquiz_src_types.QuizReviewDataDTO"
                 },
-                "token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJSUzI1NiIsImtpZCI6IjY0Y2I3Y2E3Y2I3Y2E3Y2I3Y2E3Y2E3Y2E3Y2E3Y2EifQ.eyJpc3MiOiJodHRwczovL2FjY291bnRzLmdvb2dsZS5jb20iLCJhdWQiOiJpbnRlbGxpcXVpei1jMjczNCIsImF1dGhfdGltZSI6MTcwMTIzNzM5NSwidXNlcl9pZCI6Ijg5ZjI4ZjA4MjM0NmRiMGI4ODAzZDIyMiIsInN1YiI6Ijg5ZjI4ZjA4MjM0NmRiMGI4ODAzZDIyMiIsImlhdCI6MTcwMTIzNzM5NSwiZXhwIjoxNzAxMjQxOTk1LCJlbWFpbCI6InlhbkBnbWFpbC5jb20iLCJlbWFpbF92ZXJpZmllZCI6dHJ1ZX0.XYZ"
+                "statusCode": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "intelliquiz_src_types.RefreshResponseStruct": {
+        "intelliquiz_src_types.ServiceUnavailableErrorResponseStruct": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/intelliquiz_src_types.RefreshResponseData"
+                "message": {
+                    "type": "string",
+                    "default": "Service unavailable"
                 },
                 "statusCode": {
                     "type": "integer",
-                    "example": 201
+                    "default": 503
                 },
                 "success": {
+                    "type": "boolean",
+                    "default": false
+                }
+            }
+        },
+        "intelliquiz_src_types.SetCuratorPickRequestBody": {
+            "type": "object",
+            "required": [
+                "curator_pick"
+            ],
+            "properties": {
+                "curator_pick": {
                     "type": "boolean",
                     "example": true
                 }
@@ -3339,6 +7611,10 @@ const docTemplate = `{
                 "question": {
                     "$ref": "#/definitions/intelliquiz_src_types.GameQuestionDTO"
                 },
+                "question_time_limit": {
+                    "type": "integer",
+                    "example": 20
+                },
                 "total_questions": {
                     "type": "integer",
                     "example": 10
@@ -3378,6 +7654,84 @@ const docTemplate = `{
                 }
             }
         },
+        "intelliquiz_src_types.SuspendUserRequestBody": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Repeatedly publishing offensive quizzes."
+                }
+            }
+        },
+        "intelliquiz_src_types.TooManyRequestsErrorResponseStruct": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "default": "Too many requests"
+                },
+                "statusCode": {
+                    "type": "integer",
+                    "default": 429
+                },
+                "success": {
+                    "type": "boolean",
+                    "default": false
+                }
+            }
+        },
+        "intelliquiz_src_types.UnprocessableEntityErrorResponseStruct": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "default": "Unprocessable request body"
+                },
+                "statusCode": {
+                    "type": "integer",
+                    "default": 422
+                },
+                "success": {
+                    "type": "boolean",
+                    "default": false
+                }
+            }
+        },
+        "intelliquiz_src_types.UpdateCategoryRequestBody": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Quizzes about the natural world."
+                },
+                "icon_url": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://example.com/icons/science.svg"
+                },
+                "image_url": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://example.com/images/science.jpg"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 40,
+                    "example": "Science"
+                },
+                "parent_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "slug": {
+                    "type": "string",
+                    "maxLength": 60,
+                    "example": "science"
+                }
+            }
+        },
         "intelliquiz_src_types.UpdateChoiceRequestBody": {
             "type": "object",
             "properties": {
@@ -3391,12 +7745,48 @@ const docTemplate = `{
                 }
             }
         },
+        "intelliquiz_src_types.UpdateDraftRequestBody": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "string",
+                    "example": "d27b21ab-6177-4159-9e13-15dc50ffed29"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://example.com/image.jpg"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 60,
+                    "example": "European Capitals Quiz"
+                },
+                "question_time_limit": {
+                    "type": "integer",
+                    "maximum": 300,
+                    "minimum": 5,
+                    "example": 20
+                },
+                "questions": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "$ref": "#/definitions/intelliquiz_src_types.QuizDraftQuestionDTO"
+                    }
+                }
+            }
+        },
         "intelliquiz_src_types.UpdateQuestionRequestBody": {
             "type": "object",
             "properties": {
                 "content": {
                     "type": "string",
                     "example": "What is the capital of France?"
+                },
+                "explanation": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Paris has been the capital of France since 987."
                 }
             }
         },
@@ -3414,6 +7804,11 @@ const docTemplate = `{
                 "name": {
                     "type": "string",
                     "example": "Sample Quiz"
+                },
+                "question_time_limit": {
+                    "type": "integer",
+                    "maximum": 300,
+                    "example": 20
                 }
             }
         },
@@ -3431,6 +7826,23 @@ const docTemplate = `{
                 }
             }
         },
+        "intelliquiz_src_types.UpdateUserRoleRequestBody": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "user",
+                        "curator",
+                        "admin"
+                    ],
+                    "example": "curator"
+                }
+            }
+        },
         "intelliquiz_src_types.UserQuizResponseDTOStruct": {
             "type": "object",
             "properties": {
@@ -3480,6 +7892,15 @@ const docTemplate = `{
                     "type": "string",
                     "example": "John Doe"
                 },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "user",
+                        "curator",
+                        "admin"
+                    ],
+                    "example": "user"
+                },
                 "username": {
                     "type": "string",
                     "example": "johndoe"
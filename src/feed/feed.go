@@ -0,0 +1,137 @@
+package feed
+
+import (
+	"context"
+	"intelliquiz/src/database/schemas"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const sectionSize = 20
+
+// Sections are the home page sections shown to everyone. They are the same
+// for every user, so they are computed in the background and served from
+// memory.
+type Sections struct {
+	CuratedQuizzes     []schemas.Quiz `json:"curatedQuizzes"`
+	MostLikedQuizzes   []schemas.Quiz `json:"mostLikedQuizzes"`
+	MostPlayedQuizzes  []schemas.Quiz `json:"mostPlayedQuizzes"`
+	NewlyAddedQuizzes  []schemas.Quiz `json:"newlyAddedQuizzes"`
+	BestQuizzesOfMonth []schemas.Quiz `json:"bestQuizzesOfMonth"`
+	RefreshedAt        time.Time      `json:"refreshedAt"`
+}
+
+// Feed caches the home page sections. Refresh is meant to run on a schedule;
+// until it first succeeds, the sections are computed on demand.
+type Feed struct {
+	db *gorm.DB
+
+	mu       sync.RWMutex
+	sections *Sections
+}
+
+func NewFeed(db *gorm.DB) *Feed {
+	return &Feed{db: db}
+}
+
+// Sections returns the cached sections, computing them if they were never
+// refreshed.
+func (f *Feed) Sections(ctx context.Context) (Sections, error) {
+	f.mu.RLock()
+	sections := f.sections
+	f.mu.RUnlock()
+
+	if sections != nil {
+		return *sections, nil
+	}
+
+	if err := f.Refresh(ctx); err != nil {
+		return Sections{}, err
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return *f.sections, nil
+}
+
+// Refresh computes the sections and replaces the cached ones. On failure the
+// previous sections are kept.
+func (f *Feed) Refresh(ctx context.Context) error {
+	db := f.db.WithContext(ctx)
+	sections := Sections{RefreshedAt: time.Now()}
+
+	err := listedQuizzes(db).
+		Order("quizzes.games_played DESC").
+		Limit(sectionSize).
+		Find(&sections.MostPlayedQuizzes).
+		Error
+	if err != nil {
+		return err
+	}
+
+	err = listedQuizzes(db).
+		Order("quizzes.created_at DESC").
+		Limit(sectionSize).
+		Find(&sections.NewlyAddedQuizzes).
+		Error
+	if err != nil {
+		return err
+	}
+
+	err = listedQuizzes(db).
+		Where("quizzes.curator_pick = ?", true).
+		Order("quizzes.likes DESC").
+		Limit(sectionSize).
+		Find(&sections.CuratedQuizzes).
+		Error
+	if err != nil {
+		return err
+	}
+
+	err = listedQuizzes(db).
+		Order("quizzes.likes DESC").
+		Limit(sectionSize).
+		Find(&sections.MostLikedQuizzes).
+		Error
+	if err != nil {
+		return err
+	}
+
+	monthAgo := time.Now().AddDate(0, -1, 0)
+	err = listedQuizzes(db).
+		Select(listedQuizColumns+", (quizzes.games_played * 0.05) + "+
+			"((SELECT COUNT(*) FROM games WHERE games.quiz_id = quizzes.id AND games.created_at >= ?) * 0.3) + "+
+			"(quizzes.likes * 0.15) + "+
+			"((SELECT COUNT(*) FROM quiz_user_likes WHERE quiz_user_likes.quiz_id = quizzes.id AND quiz_user_likes.created_at >= ?) * 0.5) AS score", monthAgo, monthAgo).
+		Order("score DESC").
+		Limit(sectionSize + 1).
+		Find(&sections.BestQuizzesOfMonth).
+		Error
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	f.sections = &sections
+	f.mu.Unlock()
+
+	return nil
+}
+
+const listedQuizColumns = "quizzes.id, quizzes.name, quizzes.category_id, quizzes.created_by, quizzes.curator_pick, quizzes.image_url, quizzes.likes, quizzes.games_played, quizzes.created_at, quizzes.updated_at"
+
+// listedQuizzes is the query of the quizzes shown in the sections, leaving out
+// the ones quarantined by moderation.
+func listedQuizzes(db *gorm.DB) *gorm.DB {
+	return db.Model(&schemas.Quiz{}).
+		Select(listedQuizColumns).
+		Preload("Category", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, name")
+		}).
+		Preload("User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, username")
+		}).
+		Where("quizzes.moderation_status = ?", schemas.ModerationApproved)
+}
@@ -0,0 +1,155 @@
+package feed
+
+import (
+	"context"
+	"intelliquiz/src/database/schemas"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	personalizedSectionSize = 10
+	topCategoriesCount      = 3
+)
+
+// Personalized are the home page sections of a logged in user. Quizzes the
+// user created or already finished are left out of them.
+type Personalized struct {
+	ContinuePlaying []ContinuePlaying `json:"continuePlaying"`
+	BecauseYouLiked *BecauseYouLiked  `json:"becauseYouLiked"`
+	TopCategories   []CategoryQuizzes `json:"topCategories"`
+}
+
+// ContinuePlaying is an unfinished game of the user, the latest one of its
+// quiz.
+type ContinuePlaying struct {
+	GameID    string        `json:"game_id"`
+	StartedAt *time.Time    `json:"started_at"`
+	Quiz      *schemas.Quiz `json:"quiz"`
+}
+
+// BecauseYouLiked are quizzes of the category of the quiz the user liked last.
+type BecauseYouLiked struct {
+	Quiz    schemas.Quiz   `json:"quiz"`
+	Quizzes []schemas.Quiz `json:"quizzes"`
+}
+
+// CategoryQuizzes are quizzes of one of the categories the user plays the
+// most.
+type CategoryQuizzes struct {
+	Category schemas.Category `json:"category"`
+	Quizzes  []schemas.Quiz   `json:"quizzes"`
+}
+
+// notPlayedBy leaves out the quizzes the user created or finished.
+func notPlayedBy(db *gorm.DB, userID string) *gorm.DB {
+	return db.Where("quizzes.created_by <> ?", userID).
+		Where("NOT EXISTS (SELECT 1 FROM games WHERE games.quiz_id = quizzes.id AND games.user_id = ? AND games.finished_at IS NOT NULL AND games.deleted_at IS NULL)", userID)
+}
+
+// Personalized computes the sections of the user. They are not cached, as
+// each one reads only the user's own games and likes.
+func (f *Feed) Personalized(ctx context.Context, userID string) (Personalized, error) {
+	db := f.db.WithContext(ctx)
+	personalized := Personalized{
+		ContinuePlaying: []ContinuePlaying{},
+		TopCategories:   []CategoryQuizzes{},
+	}
+
+	var games []schemas.Game
+	err := notPlayedBy(db.Model(&schemas.Game{}), userID).
+		Select("games.id, games.quiz_id, games.created_at").
+		Joins("JOIN quizzes ON quizzes.id = games.quiz_id AND quizzes.deleted_at IS NULL").
+		Where("games.id IN (?)", db.Model(&schemas.Game{}).
+			Select("DISTINCT ON (games.quiz_id) games.id").
+			Where("games.user_id = ? AND games.finished_at IS NULL", userID).
+			Order("games.quiz_id, games.created_at DESC")).
+		Where("quizzes.moderation_status = ?", schemas.ModerationApproved).
+		Preload("Quiz", func(db *gorm.DB) *gorm.DB {
+			return db.Select(listedQuizColumns)
+		}).
+		Preload("Quiz.Category", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, name")
+		}).
+		Preload("Quiz.User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, username")
+		}).
+		Order("games.created_at DESC").
+		Limit(personalizedSectionSize).
+		Find(&games).
+		Error
+	if err != nil {
+		return personalized, err
+	}
+
+	for _, game := range games {
+		personalized.ContinuePlaying = append(personalized.ContinuePlaying, ContinuePlaying{
+			GameID:    game.ID,
+			StartedAt: game.CreatedAt,
+			Quiz:      game.Quiz,
+		})
+	}
+
+	var liked schemas.Quiz
+	err = listedQuizzes(db).
+		Joins("JOIN quiz_user_likes ON quiz_user_likes.quiz_id = quizzes.id AND quiz_user_likes.user_id = ?", userID).
+		Order("quiz_user_likes.created_at DESC").
+		Limit(1).
+		Find(&liked).
+		Error
+	if err != nil {
+		return personalized, err
+	}
+
+	if liked.ID != "" {
+		var quizzes []schemas.Quiz
+		err = notPlayedBy(listedQuizzes(db), userID).
+			Where("quizzes.category_id = ? AND quizzes.id <> ?", liked.CategoryID, liked.ID).
+			Where("NOT EXISTS (SELECT 1 FROM quiz_user_likes WHERE quiz_user_likes.quiz_id = quizzes.id AND quiz_user_likes.user_id = ?)", userID).
+			Order("quizzes.likes DESC, quizzes.id").
+			Limit(personalizedSectionSize).
+			Find(&quizzes).
+			Error
+		if err != nil {
+			return personalized, err
+		}
+
+		if len(quizzes) > 0 {
+			personalized.BecauseYouLiked = &BecauseYouLiked{Quiz: liked, Quizzes: quizzes}
+		}
+	}
+
+	var categories []schemas.Category
+	err = db.Model(&schemas.Category{}).
+		Select("categories.id, categories.name, categories.slug, categories.icon_url").
+		Joins("JOIN quizzes ON quizzes.category_id = categories.id AND quizzes.deleted_at IS NULL").
+		Joins("JOIN games ON games.quiz_id = quizzes.id AND games.user_id = ? AND games.deleted_at IS NULL", userID).
+		Group("categories.id").
+		Order("COUNT(games.id) DESC, categories.name").
+		Limit(topCategoriesCount).
+		Find(&categories).
+		Error
+	if err != nil {
+		return personalized, err
+	}
+
+	for _, category := range categories {
+		var quizzes []schemas.Quiz
+		err = notPlayedBy(listedQuizzes(db), userID).
+			Where("quizzes.category_id = ?", category.ID).
+			Order("quizzes.games_played DESC, quizzes.id").
+			Limit(personalizedSectionSize).
+			Find(&quizzes).
+			Error
+		if err != nil {
+			return personalized, err
+		}
+
+		if len(quizzes) > 0 {
+			personalized.TopCategories = append(personalized.TopCategories, CategoryQuizzes{Category: category, Quizzes: quizzes})
+		}
+	}
+
+	return personalized, nil
+}
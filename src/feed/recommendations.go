@@ -0,0 +1,217 @@
+package feed
+
+import (
+	"context"
+	"intelliquiz/src/database/schemas"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// maxSimilarQuizzes is how many similar quizzes are kept for each quiz
+	maxSimilarQuizzes = 50
+	// categoryAffinityWeight is how much the share of a user's plays and likes
+	// in a category weighs against the similarity to the quizzes they played
+	categoryAffinityWeight = 0.5
+	// sameCategoryWeight is how much being in the same category weighs against
+	// the similarity of two quizzes
+	sameCategoryWeight = 0.2
+)
+
+// interactionsQuery is how much each user interacted with each quiz: a like
+// and a finished game count one each, however many times the quiz was played.
+const interactionsQuery = `SELECT user_id, quiz_id, SUM(weight) AS weight FROM (
+	SELECT user_id, quiz_id, 1::float8 AS weight FROM quiz_user_likes
+	UNION ALL
+	SELECT DISTINCT user_id, quiz_id, 1::float8 AS weight FROM games WHERE finished_at IS NOT NULL AND deleted_at IS NULL
+) AS events`
+
+// similaritiesInsert computes the cosine similarity of the quizzes from the
+// interactions and norms CTEs, comparing the users who interacted with each of
+// them. similaritiesGroup follows it.
+const similaritiesInsert = `INSERT INTO quiz_similarities (quiz_id, similar_quiz_id, score, users, updated_at)
+SELECT a.quiz_id, b.quiz_id, SUM(a.weight * b.weight) / (na.norm * nb.norm), COUNT(*), now()
+FROM interactions a
+JOIN interactions b ON b.user_id = a.user_id AND b.quiz_id <> a.quiz_id
+JOIN norms na ON na.quiz_id = a.quiz_id
+JOIN norms nb ON nb.quiz_id = b.quiz_id`
+
+const similaritiesGroup = ` GROUP BY a.quiz_id, b.quiz_id, na.norm, nb.norm`
+
+// allSimilaritiesQuery computes the similarity of every pair of quizzes.
+const allSimilaritiesQuery = `WITH interactions AS (` + interactionsQuery + ` GROUP BY user_id, quiz_id),
+norms AS (SELECT quiz_id, sqrt(SUM(weight * weight)) AS norm FROM interactions GROUP BY quiz_id)
+` + similaritiesInsert + similaritiesGroup
+
+// touchedSimilaritiesQuery computes the similarity of the pairs with one of
+// the given quizzes. Only the users who interacted with them can relate them
+// to other quizzes, so only their interactions are read, along with all the
+// interactions of the quizzes they reach, which their norms need. The quiz IDs
+// are given four times.
+const touchedSimilaritiesQuery = `WITH touched_users AS (
+	SELECT user_id FROM quiz_user_likes WHERE quiz_id IN ?
+	UNION
+	SELECT user_id FROM games WHERE quiz_id IN ? AND finished_at IS NOT NULL AND deleted_at IS NULL
+),
+interactions AS (` + interactionsQuery + ` WHERE user_id IN (SELECT user_id FROM touched_users) GROUP BY user_id, quiz_id),
+norms AS (
+	SELECT quiz_id, sqrt(SUM(weight * weight)) AS norm FROM (` + interactionsQuery + ` WHERE quiz_id IN (SELECT quiz_id FROM interactions) GROUP BY user_id, quiz_id) AS reached
+	GROUP BY quiz_id
+)
+` + similaritiesInsert + `
+WHERE a.quiz_id IN ? OR b.quiz_id IN ?` + similaritiesGroup
+
+// pruneSimilaritiesQuery keeps the most similar quizzes of each quiz.
+const pruneSimilaritiesQuery = `DELETE FROM quiz_similarities WHERE (quiz_id, similar_quiz_id) IN (
+	SELECT quiz_id, similar_quiz_id FROM (
+		SELECT quiz_id, similar_quiz_id, ROW_NUMBER() OVER (PARTITION BY quiz_id ORDER BY score DESC, users DESC) AS rank
+		FROM quiz_similarities
+	) AS ranked
+	WHERE rank > ?
+)`
+
+// Recommender recommends quizzes from the likes and finished games of the
+// users. Refresh and RefreshAll are meant to run on their own schedules:
+// Refresh recomputes the similarity of the quizzes liked or finished since the
+// last refresh, and RefreshAll the similarity of every quiz, to account for
+// removed likes and games, which leave no trace to find them by.
+type Recommender struct {
+	db *gorm.DB
+
+	mu          sync.Mutex
+	refreshedAt time.Time
+}
+
+func NewRecommender(db *gorm.DB) *Recommender {
+	return &Recommender{db: db}
+}
+
+// Refresh recomputes the quiz similarities that may have changed since the
+// last refresh. Every quiz is recomputed when there was no refresh yet.
+func (r *Recommender) Refresh(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.refreshedAt.IsZero() {
+		return r.refreshAllLocked(ctx)
+	}
+
+	startedAt := time.Now()
+	db := r.db.WithContext(ctx)
+
+	var touched []string
+	err := db.Raw(
+		"SELECT quiz_id FROM quiz_user_likes WHERE created_at >= ? UNION SELECT quiz_id FROM games WHERE finished_at >= ? AND deleted_at IS NULL",
+		r.refreshedAt, r.refreshedAt,
+	).Scan(&touched).Error
+	if err != nil {
+		return err
+	}
+
+	if len(touched) == 0 {
+		r.refreshedAt = startedAt
+		return nil
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec("DELETE FROM quiz_similarities WHERE quiz_id IN ? OR similar_quiz_id IN ?", touched, touched).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Exec(touchedSimilaritiesQuery, touched, touched, touched, touched).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec(pruneSimilaritiesQuery, maxSimilarQuizzes).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	r.refreshedAt = startedAt
+
+	return nil
+}
+
+// RefreshAll recomputes the similarity of every quiz.
+func (r *Recommender) RefreshAll(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.refreshAllLocked(ctx)
+}
+
+func (r *Recommender) refreshAllLocked(ctx context.Context) error {
+	startedAt := time.Now()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM quiz_similarities").Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(allSimilaritiesQuery).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec(pruneSimilaritiesQuery, maxSimilarQuizzes).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	r.refreshedAt = startedAt
+
+	return nil
+}
+
+// ForUser recommends quizzes to the user, by their similarity to the quizzes
+// the user liked or finished and by the user's affinity to their categories.
+// Users with no likes or games get the most liked quizzes. Quizzes the user
+// created, finished or liked are left out.
+func (r *Recommender) ForUser(ctx context.Context, userID string, limit int) ([]schemas.Quiz, error) {
+	db := r.db.WithContext(ctx)
+
+	interactions := db.Raw(interactionsQuery+" WHERE user_id = ? GROUP BY user_id, quiz_id", userID)
+
+	similar := db.Table("(?) AS interactions", interactions).
+		Select("quiz_similarities.similar_quiz_id AS quiz_id, SUM(interactions.weight * quiz_similarities.score) AS score").
+		Joins("JOIN quiz_similarities ON quiz_similarities.quiz_id = interactions.quiz_id").
+		Group("quiz_similarities.similar_quiz_id")
+
+	affinity := db.Table("(?) AS interactions", interactions).
+		Select("quizzes.category_id, SUM(interactions.weight) / SUM(SUM(interactions.weight)) OVER () AS affinity").
+		Joins("JOIN quizzes ON quizzes.id = interactions.quiz_id").
+		Group("quizzes.category_id")
+
+	var quizzes []schemas.Quiz
+	err := notPlayedBy(listedQuizzes(db), userID).
+		Select(listedQuizColumns+", COALESCE(similar.score, 0) + ?::float8 * COALESCE(affinity.affinity, 0) AS score", categoryAffinityWeight).
+		Joins("LEFT JOIN (?) AS similar ON similar.quiz_id = quizzes.id", similar).
+		Joins("LEFT JOIN (?) AS affinity ON affinity.category_id = quizzes.category_id", affinity).
+		Where("NOT EXISTS (SELECT 1 FROM quiz_user_likes WHERE quiz_user_likes.quiz_id = quizzes.id AND quiz_user_likes.user_id = ?)", userID).
+		Order("score DESC, quizzes.likes DESC, quizzes.id").
+		Limit(limit).
+		Find(&quizzes).
+		Error
+
+	return quizzes, err
+}
+
+// Similar gives the quizzes most similar to the quiz, played or liked by the
+// same users, favoring the ones of its category. Quizzes no one played along
+// with it are filled in from its category.
+func (r *Recommender) Similar(ctx context.Context, quiz schemas.Quiz, limit int) ([]schemas.Quiz, error) {
+	var quizzes []schemas.Quiz
+	err := listedQuizzes(r.db.WithContext(ctx)).
+		Select(listedQuizColumns+", COALESCE(quiz_similarities.score, 0) + CASE WHEN quizzes.category_id = ? THEN ?::float8 ELSE 0 END AS score", quiz.CategoryID, sameCategoryWeight).
+		Joins("LEFT JOIN quiz_similarities ON quiz_similarities.similar_quiz_id = quizzes.id AND quiz_similarities.quiz_id = ?", quiz.ID).
+		Where("quizzes.id <> ?", quiz.ID).
+		Where("quiz_similarities.quiz_id IS NOT NULL OR quizzes.category_id = ?", quiz.CategoryID).
+		Order("score DESC, quizzes.likes DESC, quizzes.id").
+		Limit(limit).
+		Find(&quizzes).
+		Error
+
+	return quizzes, err
+}
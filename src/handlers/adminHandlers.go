@@ -0,0 +1,589 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"intelliquiz/src/database/schemas"
+	"intelliquiz/src/types"
+	"intelliquiz/src/utils"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var errReportClosed = errors.New("report already closed")
+
+// minQuizQuestions is the number of questions a quiz needs to be played.
+const minQuizQuestions = 2
+
+// GetReports godoc
+// @Summary Get reports
+// @Schemes
+// @Description Get the reports with the given status, oldest first, along with the reported content and its reporter. Admin only.
+// @Tags admin
+// @Produce json
+// @Param status query string false "Report status" Enums(open, resolved, dismissed) default(open)
+// @Param page query int false "Page number (starting from 0)" default(0)
+// @Param limit query int false "Limit of reports per page (min: 5, max: 50)" default(10)
+// @Success 200 {object} types.GetReportsSuccessResponseStruct
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /admin/reports [get]
+func GetReports(c *gin.Context, db *gorm.DB) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "0"))
+	status := c.DefaultQuery("status", schemas.ReportStatusOpen)
+
+	limit = max(5, min(50, limit))
+	page = max(0, page)
+
+	if status != schemas.ReportStatusOpen && status != schemas.ReportStatusResolved && status != schemas.ReportStatusDismissed {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Invalid report status. Status must be open, resolved or dismissed.",
+		})
+		return
+	}
+
+	reportsCount, err := gorm.G[schemas.Report](db).
+		Where("status = ?", status).
+		Count(c.Request.Context(), "id")
+	if err != nil {
+		log.Printf("Error counting reports: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while fetching reports count.",
+		})
+		return
+	}
+
+	reports, err := gorm.G[schemas.Report](db).
+		Where("status = ?", status).
+		Preload("Reporter", func(db gorm.PreloadBuilder) error {
+			db.Select("id, username")
+			return nil
+		}).
+		Preload("Quiz", func(db gorm.PreloadBuilder) error {
+			db.Select("id, name, created_by, moderation_status")
+			return nil
+		}).
+		Preload("Question", func(db gorm.PreloadBuilder) error {
+			db.Select("id, content")
+			return nil
+		}).
+		Order("created_at ASC").
+		Limit(limit).
+		Offset(page * limit).
+		Find(c.Request.Context())
+	if err != nil {
+		log.Printf("Error fetching reports: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while fetching reports.",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"statusCode": http.StatusOK,
+		"success":    true,
+		"data": gin.H{
+			"reports": reports,
+			"maxPage": math.Ceil(float64(reportsCount)/float64(limit)) - 1,
+		},
+	})
+}
+
+// ResolveReport godoc
+// @Summary Resolve a report
+// @Schemes
+// @Description Close a report, optionally hiding the reported quiz or deleting the reported quiz or question. Every open report on the same content is closed with it, and their reporters are notified of the outcome. The quiz author is notified when their content is hidden or deleted. Admin only.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param reportId path string true "Report ID"
+// @Param data body types.ResolveReportRequestBody true "Resolve Report Request Body"
+// @Success 200 {object} types.ResolveReportSuccessResponseStruct
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 404 {object} types.NotFoundErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /admin/reports/{reportId}/resolve [post]
+func ResolveReport(c *gin.Context, db *gorm.DB) {
+	reportUuid, err := uuid.Parse(c.Param("reportId"))
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Invalid report ID format.",
+		})
+		return
+	}
+
+	adminUuid, err := uuid.Parse(c.MustGet("userID").(string))
+	if err != nil {
+		log.Printf("Error parsing User UUID from context: %v", err)
+
+		c.JSON(http.StatusForbidden, types.ForbiddenErrorResponseStruct{
+			StatusCode: http.StatusForbidden,
+			Success:    false,
+			Message:    "Invalid user ID format on claims.",
+		})
+		return
+	}
+
+	var reqBody types.ResolveReportRequestBody
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		log.Printf("Error parsing request body: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "An error occurred while parsing the request body.",
+		})
+		return
+	}
+
+	action := reqBody.Action
+	if action == "" {
+		action = schemas.ReportActionNone
+	}
+	if reqBody.Status == schemas.ReportStatusDismissed && action != schemas.ReportActionNone {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Dismissed reports cannot hide or delete content.",
+		})
+		return
+	}
+
+	report, err := gorm.G[schemas.Report](db).Where("id = ?", reportUuid).
+		Preload("Quiz", func(db gorm.PreloadBuilder) error {
+			db.Select("id, name, created_by")
+			return nil
+		}).
+		Preload("Question", func(db gorm.PreloadBuilder) error {
+			db.Select("id, quiz_id")
+			return nil
+		}).
+		First(c.Request.Context())
+	if err != nil {
+		log.Printf("Error fetching report by ID: %v", err)
+
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, types.NotFoundErrorResponseStruct{
+				StatusCode: http.StatusNotFound,
+				Success:    false,
+				Message:    "Report not found.",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while fetching the report.",
+		})
+		return
+	}
+
+	if report.Status != schemas.ReportStatusOpen {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "This report has already been closed.",
+		})
+		return
+	}
+
+	contentDeleted := report.Quiz == nil || (report.QuestionID != nil && report.Question == nil)
+	if action != schemas.ReportActionNone && contentDeleted {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "The reported content no longer exists.",
+		})
+		return
+	}
+
+	note := strings.TrimSpace(reqBody.Note)
+	var closedReports []schemas.Report
+	err = db.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		quizHidden, err := applyReportAction(tx, report, action)
+		if err != nil {
+			return err
+		}
+
+		sameContent := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("quiz_id = ? AND status = ?", report.QuizID, schemas.ReportStatusOpen)
+		if report.QuestionID != nil {
+			sameContent = sameContent.Where("question_id = ?", *report.QuestionID)
+		} else {
+			sameContent = sameContent.Where("question_id IS NULL")
+		}
+		if err := sameContent.Find(&closedReports).Error; err != nil {
+			return err
+		}
+		if len(closedReports) == 0 {
+			// Another admin closed the reports in the meantime
+			return errReportClosed
+		}
+
+		reportIDs := make([]string, len(closedReports))
+		notifications := make([]schemas.Notification, 0, len(closedReports)+1)
+		for i, closedReport := range closedReports {
+			reportIDs[i] = closedReport.ID
+			notifications = append(notifications, schemas.Notification{
+				UserID:   closedReport.ReporterID,
+				Kind:     schemas.NotificationReportResolved,
+				Message:  reportOutcomeMessage(report, reqBody.Status, action, note),
+				ReportID: &closedReport.ID,
+				QuizID:   &closedReport.QuizID,
+			})
+		}
+		if action != schemas.ReportActionNone {
+			notifications = append(notifications, schemas.Notification{
+				UserID:  report.Quiz.CreatedBy,
+				Kind:    schemas.NotificationQuizModerated,
+				Message: reportedContentMessage(report, action, note, quizHidden),
+				QuizID:  &report.QuizID,
+			})
+		}
+
+		err = tx.Model(&schemas.Report{}).
+			Where("id IN ?", reportIDs).
+			Updates(map[string]any{
+				"status":          reqBody.Status,
+				"action":          action,
+				"resolution_note": note,
+				"resolved_by":     adminUuid.String(),
+				"resolved_at":     time.Now(),
+			}).
+			Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(&notifications).Error
+	})
+	if errors.Is(err, errReportClosed) {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "This report has already been closed.",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("Error resolving report: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while resolving the report.",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"statusCode": http.StatusOK,
+		"success":    true,
+		"data": gin.H{
+			"closed_reports": len(closedReports),
+		},
+	})
+}
+
+// applyReportAction hides the reported quiz, or deletes the reported quiz or
+// question. A quiz left with too few questions to be played is hidden along
+// with the deleted question, which it reports.
+func applyReportAction(tx *gorm.DB, report schemas.Report, action string) (bool, error) {
+	hide := func(reason string) error {
+		return tx.Model(&schemas.Quiz{}).
+			Where("id = ?", report.QuizID).
+			Updates(map[string]any{
+				"moderation_status": schemas.ModerationHidden,
+				"moderation_reason": reason,
+			}).
+			Error
+	}
+
+	switch action {
+	case schemas.ReportActionHide:
+		return false, hide("reported: " + report.Reason)
+	case schemas.ReportActionDelete:
+		if report.QuestionID == nil {
+			return false, tx.Where("id = ?", report.QuizID).Delete(&schemas.Quiz{ID: report.QuizID}).Error
+		}
+
+		// Locking the quiz makes concurrent deletions of its questions count
+		// the questions one after the other
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			Where("id = ?", report.QuizID).
+			First(&schemas.Quiz{}).
+			Error
+		if err != nil {
+			return false, err
+		}
+
+		result := tx.Where("id = ?", *report.QuestionID).Delete(&schemas.Question{ID: *report.QuestionID})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return false, result.Error
+		}
+		if err := utils.IncrementQuizStat(tx, report.QuizID, utils.QuizStatQuestions, -1); err != nil {
+			return false, err
+		}
+
+		var remaining int64
+		if err := tx.Model(&schemas.Question{}).Where("quiz_id = ?", report.QuizID).Count(&remaining).Error; err != nil {
+			return false, err
+		}
+		if remaining >= minQuizQuestions {
+			return false, nil
+		}
+		return true, hide("reported: " + report.Reason + " (too few questions left)")
+	}
+
+	return false, nil
+}
+
+// reportOutcomeMessage tells a reporter what was done about their report.
+func reportOutcomeMessage(report schemas.Report, status string, action string, note string) string {
+	subject := "Your report"
+	if report.Quiz != nil && report.QuestionID != nil {
+		subject = fmt.Sprintf("Your report on a question of %q", report.Quiz.Name)
+	} else if report.Quiz != nil {
+		subject = fmt.Sprintf("Your report on %q", report.Quiz.Name)
+	}
+
+	outcome := "was reviewed and resolved."
+	switch {
+	case status == schemas.ReportStatusDismissed:
+		outcome = "was reviewed, but no action was taken."
+	case action == schemas.ReportActionHide:
+		outcome = "was reviewed and the quiz has been hidden."
+	case action == schemas.ReportActionDelete && report.QuestionID != nil:
+		outcome = "was reviewed and the question has been removed."
+	case action == schemas.ReportActionDelete:
+		outcome = "was reviewed and the quiz has been removed."
+	}
+
+	return withModeratorNote(subject+" "+outcome, note)
+}
+
+// reportedContentMessage tells the author of reported content that it was
+// hidden or deleted, and whether the quiz was hidden for having too few
+// questions left.
+func reportedContentMessage(report schemas.Report, action string, note string, quizHidden bool) string {
+	message := fmt.Sprintf("Your quiz %q has been hidden after a report.", report.Quiz.Name)
+	if action == schemas.ReportActionDelete && report.QuestionID != nil && quizHidden {
+		message = fmt.Sprintf("A question of your quiz %q has been removed after a report, and the quiz has been hidden as it has fewer than %d questions left.", report.Quiz.Name, minQuizQuestions)
+	} else if action == schemas.ReportActionDelete && report.QuestionID != nil {
+		message = fmt.Sprintf("A question of your quiz %q has been removed after a report.", report.Quiz.Name)
+	} else if action == schemas.ReportActionDelete {
+		message = fmt.Sprintf("Your quiz %q has been removed after a report.", report.Quiz.Name)
+	}
+
+	return withModeratorNote(message, note)
+}
+
+func withModeratorNote(message string, note string) string {
+	if note == "" {
+		return message
+	}
+
+	return message + " Note from the moderators: " + note
+}
+
+// GetFlaggedQuizzes godoc
+// @Summary Get flagged quizzes
+// @Schemes
+// @Description Get the quizzes quarantined by automatic moderation and waiting for review, oldest first. Admin only.
+// @Tags admin
+// @Produce json
+// @Param page query int false "Page number (starting from 0)" default(0)
+// @Param limit query int false "Limit of quizzes per page (min: 5, max: 50)" default(10)
+// @Success 200 {object} types.GetFlaggedQuizzesSuccessResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /admin/quizzes/flagged [get]
+func GetFlaggedQuizzes(c *gin.Context, db *gorm.DB) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "0"))
+
+	limit = max(5, min(50, limit))
+	page = max(0, page)
+
+	quizzesCount, err := gorm.G[schemas.Quiz](db).
+		Where("moderation_status = ?", schemas.ModerationFlagged).
+		Count(c.Request.Context(), "id")
+	if err != nil {
+		log.Printf("Error counting flagged quizzes: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while fetching flagged quizzes count.",
+		})
+		return
+	}
+
+	quizzes, err := gorm.G[schemas.Quiz](db).
+		Where("moderation_status = ?", schemas.ModerationFlagged).
+		Select("id, name, created_by, moderation_status, moderation_reason, updated_at").
+		Preload("User", func(db gorm.PreloadBuilder) error {
+			db.Select("id, username, name")
+			return nil
+		}).
+		Order("updated_at ASC").
+		Limit(limit).
+		Offset(page * limit).
+		Find(c.Request.Context())
+	if err != nil {
+		log.Printf("Error fetching flagged quizzes: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while fetching flagged quizzes.",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"statusCode": http.StatusOK,
+		"success":    true,
+		"data": gin.H{
+			"quizzes": quizzes,
+			"maxPage": math.Ceil(float64(quizzesCount)/float64(limit)) - 1,
+		},
+	})
+}
+
+// ReviewQuizModeration godoc
+// @Summary Review the moderation of a quiz
+// @Schemes
+// @Description Approve a quiz, making it public again, or hide it from the public listings. The quiz author is notified of the decision. Admin only.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param quizId path string true "Quiz ID"
+// @Param data body types.ReviewQuizModerationRequestBody true "Review Quiz Moderation Request Body"
+// @Success 200 {object} types.SuccessResponseStruct
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 404 {object} types.NotFoundErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /admin/quizzes/{quizId}/moderation [patch]
+func ReviewQuizModeration(c *gin.Context, db *gorm.DB) {
+	quizUuid, err := uuid.Parse(c.Param("quizId"))
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Invalid quiz ID format.",
+		})
+		return
+	}
+
+	var reqBody types.ReviewQuizModerationRequestBody
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		log.Printf("Error parsing request body: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "An error occurred while parsing the request body.",
+		})
+		return
+	}
+
+	quiz, err := gorm.G[schemas.Quiz](db).Where("id = ?", quizUuid).
+		Select("id, name, created_by, moderation_status, moderation_reason").
+		First(c.Request.Context())
+	if err != nil {
+		log.Printf("Error fetching quiz by ID: %v", err)
+
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, types.NotFoundErrorResponseStruct{
+				StatusCode: http.StatusNotFound,
+				Success:    false,
+				Message:    "Quiz not found.",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while fetching the quiz.",
+		})
+		return
+	}
+
+	note := strings.TrimSpace(reqBody.Note)
+	reason := ""
+	message := fmt.Sprintf("Your quiz %q was reviewed and is public again.", quiz.Name)
+	if reqBody.Status == schemas.ModerationHidden {
+		reason = note
+		if reason == "" {
+			reason = quiz.ModerationReason
+		}
+		message = fmt.Sprintf("Your quiz %q was reviewed and has been hidden.", quiz.Name)
+	}
+
+	err = db.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&schemas.Quiz{}).
+			Where("id = ?", quiz.ID).
+			Updates(map[string]any{
+				"moderation_status": reqBody.Status,
+				"moderation_reason": reason,
+			}).
+			Error
+		if err != nil || quiz.ModerationStatus == reqBody.Status {
+			return err
+		}
+
+		return tx.Create(&schemas.Notification{
+			UserID:  quiz.CreatedBy,
+			Kind:    schemas.NotificationQuizModerated,
+			Message: withModeratorNote(message, note),
+			QuizID:  &quiz.ID,
+		}).Error
+	})
+	if err != nil {
+		log.Printf("Error updating quiz moderation: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while updating the quiz moderation.",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"statusCode": http.StatusOK,
+		"success":    true,
+		"message":    "Quiz moderation updated successfully.",
+	})
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"errors"
+	"intelliquiz/src/ai"
+	"intelliquiz/src/types"
+	"intelliquiz/src/utils"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	uuidG "github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GetOwnAIUsage godoc
+// @Summary Get own AI usage
+// @Schemes
+// @Description Retrieve the tokens the authenticated user spent on AI features today and this month, with their cost and what is left of the quotas
+// @Tags ai
+// @Produce json
+// @Success 200 {object} types.GetOwnAIUsageSuccessResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /me/ai-usage [get]
+func GetOwnAIUsage(c *gin.Context, db *gorm.DB, budget ai.Budget) {
+	userUuid, err := uuidG.Parse(c.MustGet("userID").(string))
+	if err != nil {
+		log.Printf("Error parsing UUID from token: %v", err)
+
+		c.JSON(http.StatusForbidden, types.ForbiddenErrorResponseStruct{
+			StatusCode: http.StatusForbidden,
+			Success:    false,
+			Message:    "Invalid user ID format on claims.",
+		})
+		return
+	}
+
+	now := time.Now()
+	day, dayErr := aiUsagePeriod(c, db, userUuid.String(), utils.StartOfDay(now), utils.StartOfDay(now).AddDate(0, 0, 1), budget.DailyTokens)
+	month, monthErr := aiUsagePeriod(c, db, userUuid.String(), utils.StartOfMonth(now), utils.StartOfMonth(now).AddDate(0, 1, 0), budget.MonthlyTokens)
+	if err := errors.Join(dayErr, monthErr); err != nil {
+		log.Printf("Error fetching AI usage: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while fetching the AI usage.",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"statusCode": http.StatusOK,
+		"success":    true,
+		"data": types.GetOwnAIUsageDataField{
+			Day:   day,
+			Month: month,
+		},
+	})
+}
+
+func aiUsagePeriod(c *gin.Context, db *gorm.DB, userID string, since, resetsAt time.Time, quota int64) (types.AIUsagePeriodDTO, error) {
+	totals, err := utils.SumAIUsage(c.Request.Context(), db, userID, since)
+	if err != nil {
+		return types.AIUsagePeriodDTO{}, err
+	}
+
+	period := types.AIUsagePeriodDTO{
+		PromptTokens:     totals.PromptTokens,
+		CompletionTokens: totals.CompletionTokens,
+		TotalTokens:      totals.TotalTokens,
+		Cost:             totals.Cost,
+		ResetsAt:         resetsAt.Format(time.RFC3339),
+	}
+	if quota > 0 {
+		remaining := max(0, quota-totals.TotalTokens)
+		period.Quota = &quota
+		period.Remaining = &remaining
+	}
+
+	return period, nil
+}
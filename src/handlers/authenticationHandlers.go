@@ -1,11 +1,13 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"intelliquiz/src/auth"
 	"intelliquiz/src/database/schemas"
 	"intelliquiz/src/types"
 	"intelliquiz/src/utils"
+	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -70,7 +72,7 @@ func SignUp(c *gin.Context, db *gorm.DB) {
 		return
 	}
 
-	tokens, err := auth.IssueTokens(newUser.ID)
+	tokens, err := auth.StartSession(c.Request.Context(), db, newUser.ID, newUser.Role)
 	if err != nil {
 		_ = fmt.Errorf("error issuing tokens: %v", err)
 		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
@@ -101,6 +103,7 @@ func SignUp(c *gin.Context, db *gorm.DB) {
 // @Success      200  {object}  types.LoginResponseStruct
 // @Failure      400  {object}  types.BadRequestErrorResponseStruct
 // @Failure      401  {object}  types.ForbiddenErrorResponseStruct
+// @Failure      403  {object}  types.ForbiddenErrorResponseStruct
 // @Failure      500  {object}  types.InternalServerErrorResponseStruct
 // @Router       /login [post]
 func Login(c *gin.Context, db *gorm.DB) {
@@ -122,7 +125,16 @@ func Login(c *gin.Context, db *gorm.DB) {
 		return
 	}
 
-	tokens, err := auth.IssueTokens(user.ID)
+	if user.SuspendedAt != nil {
+		c.JSON(http.StatusForbidden, types.ForbiddenErrorResponseStruct{
+			StatusCode: http.StatusForbidden,
+			Success:    false,
+			Message:    "This account has been suspended.",
+		})
+		return
+	}
+
+	tokens, err := auth.StartSession(c.Request.Context(), db, user.ID, user.Role)
 	if err != nil {
 		_ = fmt.Errorf("error issuing tokens: %v", err)
 		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
@@ -153,6 +165,7 @@ func Login(c *gin.Context, db *gorm.DB) {
 // @Success      201  {object}  types.RefreshResponseStruct
 // @Failure      400  {object}  types.BadRequestErrorResponseStruct
 // @Failure      401  {object}  types.ForbiddenErrorResponseStruct
+// @Failure      403  {object}  types.ForbiddenErrorResponseStruct
 // @Failure      500  {object}  types.InternalServerErrorResponseStruct
 // @Router       /refresh [post]
 func Refresh(c *gin.Context, db *gorm.DB) {
@@ -173,9 +186,37 @@ func Refresh(c *gin.Context, db *gorm.DB) {
 		return
 	}
 
-	tokens, err := auth.IssueTokens(claims.Subject)
+	tokens, err := auth.RotateSession(c.Request.Context(), db, claims)
 	if err != nil {
-		_ = fmt.Errorf("error issuing tokens: %v", err)
+		log.Printf("Error rotating session: %v", err)
+
+		if errors.Is(err, auth.ErrRefreshTokenReused) {
+			c.JSON(http.StatusUnauthorized, types.ForbiddenErrorResponseStruct{
+				StatusCode: http.StatusUnauthorized,
+				Success:    false,
+				Message:    "Refresh token reuse detected. The session has been revoked.",
+			})
+			return
+		}
+
+		if errors.Is(err, auth.ErrUserSuspended) {
+			c.JSON(http.StatusForbidden, types.ForbiddenErrorResponseStruct{
+				StatusCode: http.StatusForbidden,
+				Success:    false,
+				Message:    "This account has been suspended.",
+			})
+			return
+		}
+
+		if errors.Is(err, auth.ErrSessionNotFound) || errors.Is(err, auth.ErrSessionRevoked) {
+			c.JSON(http.StatusUnauthorized, types.ForbiddenErrorResponseStruct{
+				StatusCode: http.StatusUnauthorized,
+				Success:    false,
+				Message:    "Invalid refresh token",
+			})
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
 			StatusCode: http.StatusInternalServerError,
 			Success:    false,
@@ -193,3 +234,59 @@ func Refresh(c *gin.Context, db *gorm.DB) {
 		},
 	})
 }
+
+// Logout godoc
+// @Summary      Log out the current session
+// @Description  Revoke the session the access token was issued for, invalidating its refresh token
+// @Tags         authentication
+// @Produce      json
+// @Success      200  {object}  types.SuccessResponseStruct
+// @Failure      403  {object}  types.ForbiddenErrorResponseStruct
+// @Failure      500  {object}  types.InternalServerErrorResponseStruct
+// @Router       /logout [post]
+func Logout(c *gin.Context, db *gorm.DB) {
+	if err := auth.RevokeFamily(c.Request.Context(), db, c.MustGet("sessionID").(string)); err != nil {
+		log.Printf("Error revoking session: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while logging out",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.SuccessResponseStruct{
+		StatusCode: http.StatusOK,
+		Success:    true,
+		Message:    "Logged out successfully",
+	})
+}
+
+// LogoutAll godoc
+// @Summary      Log out every session
+// @Description  Revoke every session of the authenticated user on all devices
+// @Tags         authentication
+// @Produce      json
+// @Success      200  {object}  types.SuccessResponseStruct
+// @Failure      403  {object}  types.ForbiddenErrorResponseStruct
+// @Failure      500  {object}  types.InternalServerErrorResponseStruct
+// @Router       /logout-all [post]
+func LogoutAll(c *gin.Context, db *gorm.DB) {
+	if err := auth.RevokeUserSessions(c.Request.Context(), db, c.MustGet("userID").(string)); err != nil {
+		log.Printf("Error revoking user sessions: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while logging out",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.SuccessResponseStruct{
+		StatusCode: http.StatusOK,
+		Success:    true,
+		Message:    "Logged out from all sessions successfully",
+	})
+}
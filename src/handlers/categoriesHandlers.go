@@ -3,18 +3,23 @@ package handlers
 import (
 	"intelliquiz/src/database/schemas"
 	"intelliquiz/src/types"
+	"intelliquiz/src/utils"
 	"log"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	uuidG "github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+const categoryColumns = "id, name, slug, description, icon_url, image_url, parent_id"
+
 // GetCategories godoc
 // @Summary Get all categories
 // @Schemes
-// @Description Retrieve a list of all categories
+// @Description Retrieve a list of all categories and subcategories, sorted by name, along with how many public quizzes each one has
 // @Tags categories
 // @Produce json
 // @Success 200 {object} types.GetCategoriesSuccessResponseStruct
@@ -23,12 +28,14 @@ import (
 // @Router /categories [get]
 func GetCategories(c *gin.Context, db *gorm.DB) {
 	categories, err := gorm.G[schemas.Category](db).
-		Select("id, name").
+		Select(categoryColumns+", (?) AS quizzes_count", categoryQuizzesCount(db)).
 		Preload("Quizzes", func(db gorm.PreloadBuilder) error {
 			db.Select("id", "name", "category_id", "created_by").
+				Where("moderation_status = ?", schemas.ModerationApproved).
 				LimitPerRecord(20)
 			return nil
 		}).
+		Order("name").
 		Find(c)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
@@ -47,14 +54,13 @@ func GetCategories(c *gin.Context, db *gorm.DB) {
 }
 
 // GetCategoryByID godoc
-// @Summary Get a category by ID
+// @Summary Get a category by ID or slug
 // @Schemes
-// @Description Retrieve a category by its ID
+// @Description Retrieve a category by its ID or slug, along with its parent and subcategories
 // @Tags categories
 // @Produce json
-// @Param id path string true "Category ID"
+// @Param categoryId path string true "Category ID or slug"
 // @Success 200 {object} types.GetCategorySuccessResponseStruct
-// @Failure 400 {object} types.BadRequestErrorResponseStruct
 // @Failure 403 {object} types.ForbiddenErrorResponseStruct
 // @Failure 404 {object} types.NotFoundErrorResponseStruct
 // @Failure 500 {object} types.InternalServerErrorResponseStruct
@@ -62,23 +68,25 @@ func GetCategories(c *gin.Context, db *gorm.DB) {
 func GetCategoryByID(c *gin.Context, db *gorm.DB) {
 	categoryId := c.Param("categoryId")
 
-	uuid, err := uuidG.Parse(categoryId)
-	if err != nil {
-		log.Printf("Error parsing UUID: %v", err)
-
-		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
-			StatusCode: http.StatusBadRequest,
-			Success:    false,
-			Message:    "Invalid category ID format.",
-		})
-		return
+	query := gorm.G[schemas.Category](db).Where("slug = ?", categoryId)
+	if uuid, err := uuidG.Parse(categoryId); err == nil {
+		query = gorm.G[schemas.Category](db).Where("id = ?", uuid)
 	}
 
-	category, err := gorm.G[schemas.Category](db).
-		Where("id = ?", uuid).
-		Select("id, name").
+	category, err := query.
+		Select(categoryColumns+", (?) AS quizzes_count", categoryQuizzesCount(db)).
+		Preload("Parent", func(db gorm.PreloadBuilder) error {
+			db.Select("id", "name", "slug", "icon_url")
+			return nil
+		}).
+		Preload("Children", func(db gorm.PreloadBuilder) error {
+			db.Select("id", "name", "slug", "icon_url", "parent_id").
+				Order("name")
+			return nil
+		}).
 		Preload("Quizzes", func(db gorm.PreloadBuilder) error {
 			db.Select("id", "name", "category_id", "created_by").
+				Where("moderation_status = ?", schemas.ModerationApproved).
 				LimitPerRecord(20)
 			return nil
 		}).
@@ -110,3 +118,522 @@ func GetCategoryByID(c *gin.Context, db *gorm.DB) {
 		"data":       category,
 	})
 }
+
+// categoryQuizzesCount is the subquery counting the public quizzes of each
+// category.
+func categoryQuizzesCount(db *gorm.DB) *gorm.DB {
+	return db.Model(&schemas.Quiz{}).
+		Select("COUNT(*)").
+		Where("quizzes.category_id = categories.id AND quizzes.moderation_status = ?", schemas.ModerationApproved)
+}
+
+// CreateCategory godoc
+// @Summary Create a category
+// @Schemes
+// @Description Create a new category, or a subcategory when given a parent. Subcategories cannot have subcategories of their own. The slug is made from the name when not given. Category names are unique among their siblings, ignoring case. Curators and admins only.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body types.CategoryRequestBody true "Category Request Body"
+// @Success 201 {object} types.CategorySuccessResponseStruct
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /admin/categories [post]
+func CreateCategory(c *gin.Context, db *gorm.DB) {
+	var reqBody types.CategoryRequestBody
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		log.Printf("Error parsing request body: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "An error occurred while parsing the request body.",
+		})
+		return
+	}
+
+	category := schemas.Category{
+		Name:        strings.TrimSpace(reqBody.Name),
+		Description: strings.TrimSpace(reqBody.Description),
+		IconUrl:     strings.TrimSpace(reqBody.IconUrl),
+		ImageUrl:    strings.TrimSpace(reqBody.ImageUrl),
+	}
+	if !checkCategoryUrls(c, category) {
+		return
+	}
+
+	if reqBody.ParentID != "" {
+		parentID, ok := checkCategoryParent(c, db, category, reqBody.ParentID)
+		if !ok {
+			return
+		}
+		category.ParentID = &parentID
+	}
+
+	if !checkCategoryName(c, db, category) {
+		return
+	}
+
+	if reqBody.Slug != "" {
+		if !checkCategorySlug(c, db, category, reqBody.Slug) {
+			return
+		}
+		category.Slug = reqBody.Slug
+	} else {
+		slug, err := utils.UniqueCategorySlug(c.Request.Context(), db, category.Name, "")
+		if err != nil {
+			log.Printf("Error making category slug: %v", err)
+
+			c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+				StatusCode: http.StatusInternalServerError,
+				Success:    false,
+				Message:    "An error occurred while creating the category.",
+			})
+			return
+		}
+		category.Slug = slug
+	}
+
+	if err := gorm.G[schemas.Category](db).Create(c.Request.Context(), &category); err != nil {
+		log.Printf("Error creating category: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while creating the category.",
+		})
+		return
+	}
+
+	category.CreatedAt = nil
+	category.UpdatedAt = nil
+
+	c.JSON(http.StatusCreated, gin.H{
+		"statusCode": http.StatusCreated,
+		"success":    true,
+		"data":       category,
+	})
+}
+
+// UpdateCategory godoc
+// @Summary Update a category
+// @Schemes
+// @Description Update the given fields of a category. Renaming a category keeps its slug. Categories with subcategories cannot become subcategories. Curators and admins only.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param categoryId path string true "Category ID"
+// @Param data body types.UpdateCategoryRequestBody true "Update Category Request Body"
+// @Success 200 {object} types.SuccessResponseStruct
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 404 {object} types.NotFoundErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /admin/categories/{categoryId} [patch]
+func UpdateCategory(c *gin.Context, db *gorm.DB) {
+	uuid, err := uuidG.Parse(c.Param("categoryId"))
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Invalid category ID format.",
+		})
+		return
+	}
+
+	var reqBody types.UpdateCategoryRequestBody
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		log.Printf("Error parsing request body: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "An error occurred while parsing the request body.",
+		})
+		return
+	}
+
+	category, err := gorm.G[schemas.Category](db).
+		Where("id = ?", uuid).
+		Select(categoryColumns).
+		First(c.Request.Context())
+	if err != nil {
+		log.Printf("Error fetching category by ID: %v", err)
+
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, types.NotFoundErrorResponseStruct{
+				StatusCode: http.StatusNotFound,
+				Success:    false,
+				Message:    "Category not found.",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while fetching the category.",
+		})
+		return
+	}
+
+	updates := map[string]any{}
+	if reqBody.Name != nil {
+		category.Name = strings.TrimSpace(*reqBody.Name)
+		if category.Name == "" {
+			c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+				StatusCode: http.StatusBadRequest,
+				Success:    false,
+				Message:    "Category name cannot be empty.",
+			})
+			return
+		}
+		updates["name"] = category.Name
+	}
+	if reqBody.Description != nil {
+		category.Description = strings.TrimSpace(*reqBody.Description)
+		updates["description"] = category.Description
+	}
+	if reqBody.IconUrl != nil {
+		category.IconUrl = strings.TrimSpace(*reqBody.IconUrl)
+		updates["icon_url"] = category.IconUrl
+	}
+	if reqBody.ImageUrl != nil {
+		category.ImageUrl = strings.TrimSpace(*reqBody.ImageUrl)
+		updates["image_url"] = category.ImageUrl
+	}
+	if !checkCategoryUrls(c, category) {
+		return
+	}
+
+	if reqBody.ParentID != nil {
+		category.ParentID = nil
+		if *reqBody.ParentID != "" {
+			parentID, ok := checkCategoryParent(c, db, category, *reqBody.ParentID)
+			if !ok {
+				return
+			}
+			category.ParentID = &parentID
+		}
+		updates["parent_id"] = category.ParentID
+	}
+
+	if reqBody.Name != nil || reqBody.ParentID != nil {
+		if !checkCategoryName(c, db, category) {
+			return
+		}
+	}
+
+	if reqBody.Slug != nil && *reqBody.Slug != category.Slug {
+		if !checkCategorySlug(c, db, category, *reqBody.Slug) {
+			return
+		}
+		updates["slug"] = *reqBody.Slug
+	}
+
+	if len(updates) > 0 {
+		err := db.WithContext(c.Request.Context()).Model(&schemas.Category{}).
+			Where("id = ?", category.ID).
+			Updates(updates).
+			Error
+		if err != nil {
+			log.Printf("Error updating category: %v", err)
+
+			c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+				StatusCode: http.StatusInternalServerError,
+				Success:    false,
+				Message:    "An error occurred while updating the category.",
+			})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, types.SuccessResponseStruct{
+		StatusCode: http.StatusOK,
+		Success:    true,
+		Message:    "Category updated successfully.",
+	})
+}
+
+// DeleteCategory godoc
+// @Summary Delete a category
+// @Schemes
+// @Description Delete a category. Categories that still have quizzes or subcategories cannot be deleted. Curators and admins only.
+// @Tags admin
+// @Produce json
+// @Param categoryId path string true "Category ID"
+// @Success 200 {object} types.SuccessResponseStruct
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 404 {object} types.NotFoundErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /admin/categories/{categoryId} [delete]
+func DeleteCategory(c *gin.Context, db *gorm.DB) {
+	uuid, err := uuidG.Parse(c.Param("categoryId"))
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Invalid category ID format.",
+		})
+		return
+	}
+
+	quizzesCount, err := gorm.G[schemas.Quiz](db).
+		Where("category_id = ?", uuid.String()).
+		Count(c.Request.Context(), "id")
+	if err != nil {
+		log.Printf("Error counting category quizzes: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while deleting the category.",
+		})
+		return
+	}
+
+	if quizzesCount > 0 {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Categories that still have quizzes cannot be deleted.",
+		})
+		return
+	}
+
+	childrenCount, err := gorm.G[schemas.Category](db).
+		Where("parent_id = ?", uuid.String()).
+		Count(c.Request.Context(), "id")
+	if err != nil {
+		log.Printf("Error counting subcategories: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while deleting the category.",
+		})
+		return
+	}
+
+	if childrenCount > 0 {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Categories that still have subcategories cannot be deleted.",
+		})
+		return
+	}
+
+	r, err := gorm.G[schemas.Category](db).
+		Where("id = ?", uuid.String()).
+		Delete(c.Request.Context())
+	if err != nil {
+		log.Printf("Error deleting category: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while deleting the category.",
+		})
+		return
+	}
+
+	if r <= 0 {
+		c.JSON(http.StatusNotFound, types.NotFoundErrorResponseStruct{
+			StatusCode: http.StatusNotFound,
+			Success:    false,
+			Message:    "Category not found.",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.SuccessResponseStruct{
+		StatusCode: http.StatusOK,
+		Success:    true,
+		Message:    "Category deleted successfully.",
+	})
+}
+
+// checkCategoryName writes the error response and returns false when a
+// sibling of the category already has the name, ignoring case.
+func checkCategoryName(c *gin.Context, db *gorm.DB, category schemas.Category) bool {
+	query := gorm.G[schemas.Category](db).
+		Where("LOWER(name) = LOWER(?) AND parent_id IS NOT DISTINCT FROM ?", category.Name, category.ParentID)
+	if category.ID != "" {
+		query = query.Where("id <> ?", category.ID)
+	}
+
+	count, err := query.Count(c.Request.Context(), "id")
+	if err != nil {
+		log.Printf("Error checking category name: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while checking the category name.",
+		})
+		return false
+	}
+
+	if count > 0 {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "A category with this name already exists.",
+		})
+		return false
+	}
+
+	return true
+}
+
+// checkCategorySlug writes the error response and returns false when the slug
+// is invalid or used by another category.
+func checkCategorySlug(c *gin.Context, db *gorm.DB, category schemas.Category, slug string) bool {
+	if !utils.IsSlug(slug) {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Slugs may only have lowercase letters and numbers separated by hyphens.",
+		})
+		return false
+	}
+
+	taken, err := utils.IsCategorySlugTaken(c.Request.Context(), db, slug, category.ID)
+	if err != nil {
+		log.Printf("Error checking category slug: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while checking the category slug.",
+		})
+		return false
+	}
+
+	if taken {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "A category with this slug already exists.",
+		})
+		return false
+	}
+
+	return true
+}
+
+// checkCategoryParent writes the error response and returns false when the
+// category cannot be moved under the parent. Only top-level categories can be
+// parents, and categories with subcategories cannot become subcategories, so
+// there are never more than two levels.
+func checkCategoryParent(c *gin.Context, db *gorm.DB, category schemas.Category, parentID string) (string, bool) {
+	parentUuid, err := uuidG.Parse(parentID)
+	if err != nil {
+		log.Printf("Error parsing parent UUID: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Invalid parent category ID format.",
+		})
+		return "", false
+	}
+
+	if parentUuid.String() == category.ID {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "A category cannot be its own parent.",
+		})
+		return "", false
+	}
+
+	parent, err := gorm.G[schemas.Category](db).
+		Where("id = ?", parentUuid).
+		Select("id, parent_id").
+		First(c.Request.Context())
+	if err != nil {
+		log.Printf("Error fetching parent category: %v", err)
+
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+				StatusCode: http.StatusBadRequest,
+				Success:    false,
+				Message:    "Parent category not found.",
+			})
+			return "", false
+		}
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while fetching the parent category.",
+		})
+		return "", false
+	}
+
+	if parent.ParentID != nil {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Subcategories cannot have subcategories.",
+		})
+		return "", false
+	}
+
+	if category.ID != "" {
+		childrenCount, err := gorm.G[schemas.Category](db).
+			Where("parent_id = ?", category.ID).
+			Count(c.Request.Context(), "id")
+		if err != nil {
+			log.Printf("Error counting subcategories: %v", err)
+
+			c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+				StatusCode: http.StatusInternalServerError,
+				Success:    false,
+				Message:    "An error occurred while checking the subcategories.",
+			})
+			return "", false
+		}
+
+		if childrenCount > 0 {
+			c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+				StatusCode: http.StatusBadRequest,
+				Success:    false,
+				Message:    "Categories with subcategories cannot become subcategories.",
+			})
+			return "", false
+		}
+	}
+
+	return parent.ID, true
+}
+
+// checkCategoryUrls writes the error response and returns false when the icon
+// or image URL of the category is not an http or https URL.
+func checkCategoryUrls(c *gin.Context, category schemas.Category) bool {
+	for _, rawUrl := range []string{category.IconUrl, category.ImageUrl} {
+		if rawUrl == "" {
+			continue
+		}
+
+		parsed, err := url.ParseRequestURI(rawUrl)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+				StatusCode: http.StatusBadRequest,
+				Success:    false,
+				Message:    "Icon and image URLs must be http or https URLs.",
+			})
+			return false
+		}
+	}
+
+	return true
+}
@@ -1,16 +1,24 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"intelliquiz/src/database/schemas"
+	"intelliquiz/src/moderation"
 	"intelliquiz/src/types"
+	"intelliquiz/src/utils"
 	"log"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+var errLastCorrectChoice = errors.New("last correct choice of the question")
+
 // GetChoices godoc
 // @Summary Get all choices
 // @Schemes
@@ -20,6 +28,7 @@ import (
 // @Produce json
 // @Success 200 {object} types.GetChoicesSuccessResponseStruct
 // @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 404 {object} types.NotFoundErrorResponseStruct
 // @Failure 500 {object} types.InternalServerErrorResponseStruct
 // @Router /questions/{questionId}/choices [get]
 func GetChoices(c *gin.Context, db *gorm.DB) {
@@ -46,11 +55,14 @@ func GetChoices(c *gin.Context, db *gorm.DB) {
 	}
 
 	question, err := gorm.G[schemas.Question](db).Where("id = ?", questionUuid).
-		Preload("Quiz", nil).
+		Preload("Quiz", func(db gorm.PreloadBuilder) error {
+			db.Where(utils.VisibleQuiz(userUuid.String(), c.GetString("role")))
+			return nil
+		}).
 		First(c)
-	if err != nil {
+	if err != nil || question.Quiz == nil {
 		log.Printf("Error fetching question by ID: %v", err)
-		if err == gorm.ErrRecordNotFound {
+		if err == gorm.ErrRecordNotFound || question.Quiz == nil {
 			c.JSON(http.StatusNotFound, types.NotFoundErrorResponseStruct{
 				StatusCode: http.StatusNotFound,
 				Success:    false,
@@ -99,7 +111,7 @@ func GetChoices(c *gin.Context, db *gorm.DB) {
 // CreateChoice godoc
 // @Summary Create a new choice
 // @Schemes
-// @Description Create a new choice
+// @Description Create a new choice. Questions have up to 6 choices, or up to 10 accepted answers for free_text questions. Content flagged by moderation keeps the quiz out of the public listings until reviewed.
 // @Tags choices
 // @Produce json
 // @Param data body types.CreateChoiceRequestBody true "Create Choice Request Body"
@@ -108,7 +120,7 @@ func GetChoices(c *gin.Context, db *gorm.DB) {
 // @Failure 403 {object} types.ForbiddenErrorResponseStruct
 // @Failure 500 {object} types.InternalServerErrorResponseStruct
 // @Router /questions/{questionId}/choices [post]
-func CreateChoice(c *gin.Context, db *gorm.DB) {
+func CreateChoice(c *gin.Context, db *gorm.DB, moderator moderation.Moderator) {
 	var reqBody types.CreateChoiceRequestBody
 	if err := c.ShouldBindJSON(&reqBody); err != nil {
 		log.Printf("Error parsing request body: %v", err)
@@ -179,13 +191,26 @@ func CreateChoice(c *gin.Context, db *gorm.DB) {
 		return
 	}
 
-	if len(question.Choices) >= 6 {
+	maxChoices := utils.MaxQuestionChoices
+	if question.Type == schemas.QuestionTypeFreeText {
+		maxChoices = utils.MaxAcceptedAnswers
+	}
+	if len(question.Choices) >= maxChoices {
 		log.Printf("Too many choices for question: %v", question.Content)
 
 		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
 			StatusCode: http.StatusBadRequest,
 			Success:    false,
-			Message:    "A maximum of 6 choices can be specified for the question: " + question.Content,
+			Message:    "A maximum of " + strconv.Itoa(maxChoices) + " choices can be specified for the question: " + question.Content,
+		})
+		return
+	}
+
+	if question.Type == schemas.QuestionTypeNumeric || question.Type == schemas.QuestionTypeTrueFalse {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Choices cannot be added to numeric or true/false questions.",
 		})
 		return
 	}
@@ -195,6 +220,22 @@ func CreateChoice(c *gin.Context, db *gorm.DB) {
 		Content:    reqBody.Content,
 	}
 
+	switch question.Type {
+	case schemas.QuestionTypeFreeText:
+		// Choices of free-text questions are accepted answers
+		isCorrect := true
+		choice.IsCorrect = &isCorrect
+	case schemas.QuestionTypeOrdering:
+		// New choices go to the end of the correct order
+		var position uint8
+		for _, existing := range question.Choices {
+			if existing.Position != nil && *existing.Position >= position {
+				position = *existing.Position + 1
+			}
+		}
+		choice.Position = &position
+	}
+
 	if err := gorm.G[schemas.Choice](db).Create(c, &choice); err != nil {
 		log.Printf("Error creating choice: %v", err)
 
@@ -205,6 +246,7 @@ func CreateChoice(c *gin.Context, db *gorm.DB) {
 		})
 		return
 	}
+	moderateQuizContent(c, db, moderator, question.QuizID, choice.Content)
 
 	choice.Question = nil
 	choice.CreatedAt = nil
@@ -257,12 +299,15 @@ func GetChoiceByID(c *gin.Context, db *gorm.DB) {
 
 	choice, err := gorm.G[schemas.Choice](db).Where("id = ?", choiceUuid).
 		Select("id, question_id, content, created_at, updated_at").
-		Preload("Question.Quiz", nil).
+		Preload("Question.Quiz", func(db gorm.PreloadBuilder) error {
+			db.Where(utils.VisibleQuiz(userUuid.String(), c.GetString("role")))
+			return nil
+		}).
 		First(c)
-	if err != nil {
+	if err != nil || choice.Question == nil || choice.Question.Quiz == nil {
 		log.Printf("Error fetching choice by ID: %v", err)
 
-		if err == gorm.ErrRecordNotFound {
+		if err == gorm.ErrRecordNotFound || err == nil {
 			c.JSON(http.StatusNotFound, types.NotFoundErrorResponseStruct{
 				StatusCode: http.StatusNotFound,
 				Success:    false,
@@ -300,7 +345,7 @@ func GetChoiceByID(c *gin.Context, db *gorm.DB) {
 // UpdateChoice godoc
 // @Summary Update a choice by ID
 // @Schemes
-// @Description Update a choice by its ID
+// @Description Update a choice by its ID. Choices of multiple_select questions can be marked correct or incorrect, while marking a choice of a single answer question correct unmarks the others. The last correct choice of a question cannot be unmarked. Content flagged by moderation keeps the quiz out of the public listings until reviewed.
 // @Tags choices
 // @Accept json
 // @Produce json
@@ -312,7 +357,7 @@ func GetChoiceByID(c *gin.Context, db *gorm.DB) {
 // @Failure 404 {object} types.NotFoundErrorResponseStruct
 // @Failure 500 {object} types.InternalServerErrorResponseStruct
 // @Router /choices/{choiceId} [patch]
-func UpdateChoice(c *gin.Context, db *gorm.DB) {
+func UpdateChoice(c *gin.Context, db *gorm.DB, moderator moderation.Moderator) {
 	choiceUuid, err := uuid.Parse(c.Param("choiceId"))
 	if err != nil {
 		log.Printf("Error parsing UUID: %v", err)
@@ -385,25 +430,38 @@ func UpdateChoice(c *gin.Context, db *gorm.DB) {
 		choice.Content = reqBody.Content
 	}
 
-	if reqBody.IsCorrect {
-		_, err := gorm.G[schemas.Choice](db).
-			Where("question_id = ?", choice.QuestionID).
-			Update(c, "is_correct", false)
-		if err != nil {
-			log.Printf("Error resetting other choices' is_correct: %v", err)
-
-			c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
-				StatusCode: http.StatusInternalServerError,
-				Success:    false,
-				Message:    "An error occurred while resetting other choices is_correct field.",
-			})
-			return
+	// Single answer questions always keep one correct choice, so their
+	// choices can only be marked correct, which unmarks the others
+	isSingleAnswer := choice.Question.Type == schemas.QuestionTypeSingleChoice || choice.Question.Type == schemas.QuestionTypeTrueFalse
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if reqBody.IsCorrect != nil && choice.Question.Type == schemas.QuestionTypeMultipleSelect {
+			if !*reqBody.IsCorrect && choice.IsCorrect != nil && *choice.IsCorrect {
+				if err := ensureOtherCorrectChoice(c, tx, choice); err != nil {
+					return err
+				}
+			}
+			choice.IsCorrect = reqBody.IsCorrect
+		} else if reqBody.IsCorrect != nil && *reqBody.IsCorrect && isSingleAnswer {
+			_, err := gorm.G[schemas.Choice](tx).
+				Where("question_id = ?", choice.QuestionID).
+				Update(c, "is_correct", false)
+			if err != nil {
+				return err
+			}
+			choice.IsCorrect = reqBody.IsCorrect
 		}
 
-		choice.IsCorrect = &reqBody.IsCorrect
+		return tx.Save(&choice).Error
+	})
+	if errors.Is(err, errLastCorrectChoice) {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Cannot unmark choice. A question must have at least 1 correct choice.",
+		})
+		return
 	}
-
-	if err := db.Save(&choice).Error; err != nil {
+	if err != nil {
 		log.Printf("Error updating choice: %v", err)
 
 		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
@@ -413,6 +471,9 @@ func UpdateChoice(c *gin.Context, db *gorm.DB) {
 		})
 		return
 	}
+	if reqBody.Content != "" {
+		moderateQuizContent(c, db, moderator, choice.Question.QuizID, choice.Content)
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"statusCode": http.StatusOK,
@@ -424,7 +485,7 @@ func UpdateChoice(c *gin.Context, db *gorm.DB) {
 // DeleteChoice godoc
 // @Summary Delete a choice by ID
 // @Schemes
-// @Description Delete a choice by its ID
+// @Description Delete a choice by its ID. The last correct choice of a question cannot be deleted
 // @Tags choices
 // @Produce json
 // @Param id path string true "Choice ID"
@@ -492,7 +553,18 @@ func DeleteChoice(c *gin.Context, db *gorm.DB) {
 		return
 	}
 
-	if len(choice.Question.Choices) <= 2 {
+	if choice.Question.Type == schemas.QuestionTypeFreeText {
+		if len(choice.Question.Choices) <= 1 {
+			log.Printf("Cannot delete accepted answer, minimum reached for question: %v", choice.Question.Content)
+
+			c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+				StatusCode: http.StatusBadRequest,
+				Success:    false,
+				Message:    "Cannot delete choice. A free-text question must have at least 1 accepted answer.",
+			})
+			return
+		}
+	} else if len(choice.Question.Choices) <= 2 {
 		log.Printf("Cannot delete choice, minimum choices reached for question: %v", choice.Question.Content)
 
 		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
@@ -503,8 +575,24 @@ func DeleteChoice(c *gin.Context, db *gorm.DB) {
 		return
 	}
 
-	_, err = gorm.G[schemas.Choice](db).Where("id = ?", choiceUuid.String()).Delete(c)
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if utils.RequiresCorrectChoice(choice.Question.Type) && choice.IsCorrect != nil && *choice.IsCorrect {
+			if err := ensureOtherCorrectChoice(c, tx, choice); err != nil {
+				return err
+			}
+		}
 
+		_, err := gorm.G[schemas.Choice](tx).Where("id = ?", choiceUuid.String()).Delete(c)
+		return err
+	})
+	if errors.Is(err, errLastCorrectChoice) {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Cannot delete choice. A question must have at least 1 correct choice.",
+		})
+		return
+	}
 	if err != nil {
 		log.Printf("Error deleting choice: %v", err)
 
@@ -522,3 +610,28 @@ func DeleteChoice(c *gin.Context, db *gorm.DB) {
 		"message":    "Choice deleted successfully.",
 	})
 }
+
+// ensureOtherCorrectChoice fails with errLastCorrectChoice when no choice of
+// the question but the given one is correct. The question is locked so that
+// concurrent changes to its choices are checked one after the other.
+func ensureOtherCorrectChoice(ctx context.Context, tx *gorm.DB, choice schemas.Choice) error {
+	_, err := gorm.G[schemas.Question](tx, clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", choice.QuestionID).
+		Select("id").
+		First(ctx)
+	if err != nil {
+		return err
+	}
+
+	correctChoices, err := gorm.G[schemas.Choice](tx).
+		Where("question_id = ? AND id <> ? AND is_correct", choice.QuestionID, choice.ID).
+		Count(ctx, "id")
+	if err != nil {
+		return err
+	}
+	if correctChoices == 0 {
+		return errLastCorrectChoice
+	}
+
+	return nil
+}
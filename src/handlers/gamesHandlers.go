@@ -1,13 +1,13 @@
 package handlers
 
 import (
+	"errors"
 	"intelliquiz/src/database/schemas"
 	"intelliquiz/src/types"
+	"intelliquiz/src/utils"
 	"log"
-	"math"
 	"math/rand"
 	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -15,6 +15,8 @@ import (
 	"gorm.io/gorm"
 )
 
+var errQuestionAnswered = errors.New("question already answered")
+
 // StartGame godoc
 // @Summary Start a new game
 // @Schemes
@@ -52,8 +54,9 @@ func StartGame(c *gin.Context, db *gorm.DB) {
 	}
 
 	quiz, err := gorm.G[schemas.Quiz](db).Where("id = ?", quizUuid).
+		Where(utils.VisibleQuiz(userUuid.String(), c.GetString("role"))).
 		Preload("Questions", func(db gorm.PreloadBuilder) error {
-			db.Select("id, quiz_id, content")
+			db.Select("id, quiz_id, content, type")
 			return nil
 		}).
 		Preload("Questions.Choices", func(db gorm.PreloadBuilder) error {
@@ -96,6 +99,12 @@ func StartGame(c *gin.Context, db *gorm.DB) {
 
 		gameId = game.ID
 
+		err = utils.CountQuizGames(tx, quiz.ID, 1)
+		if err != nil {
+			log.Printf("Error counting game of quiz in database: %v", err)
+			return err
+		}
+
 		var gameQuestions []schemas.GameQuestion
 		var position uint8 = 0
 
@@ -132,19 +141,14 @@ func StartGame(c *gin.Context, db *gorm.DB) {
 		return
 	}
 
-	// Shuffle choices of the first question
-	for i := range quiz.Questions[0].Choices {
-		j := rand.Intn(i + 1)
-		quiz.Questions[0].Choices[i], quiz.Questions[0].Choices[j] = quiz.Questions[0].Choices[j], quiz.Questions[0].Choices[i]
-	}
-
 	c.JSON(http.StatusCreated, gin.H{
 		"status_code": http.StatusCreated,
 		"success":     true,
 		"data": gin.H{
-			"game_id":         gameId,
-			"question":        quiz.Questions[0],
-			"total_questions": len(quiz.Questions),
+			"game_id":             gameId,
+			"question":            utils.PlayerQuestion(quiz.Questions[0]),
+			"total_questions":     len(quiz.Questions),
+			"question_time_limit": quiz.QuestionTimeLimit,
 		},
 	})
 }
@@ -152,17 +156,19 @@ func StartGame(c *gin.Context, db *gorm.DB) {
 // AnswerQuestion godoc
 // @Summary Answer a question in a game
 // @Schemes
-// @Description Submit an answer for the current question in a game session
+// @Description Submit an answer for the current question in a game session. The answer fields depend on the question type.
 // @Param gameId path string true "Game ID"
-// @Param choiceId path string true "Choice ID"
+// @Param data body types.AnswerQuestionRequestBody true "Answer Question Request Body"
 // @Tags games
 // @Produce json
 // @Success 200 {object} types.AnswerQuestionResponseStruct
 // @Failure 400 {object} types.BadRequestErrorResponseStruct
 // @Failure 403 {object} types.ForbiddenErrorResponseStruct
 // @Failure 404 {object} types.NotFoundErrorResponseStruct
+// @Failure 409 {object} types.ConflictErrorResponseStruct
+// @Failure 410 {object} types.DeletedQuestionResponseStruct
 // @Failure 500 {object} types.InternalServerErrorResponseStruct
-// @Router /games/{gameId}/answer/{choiceId} [post]
+// @Router /games/{gameId}/answer [post]
 func AnswerQuestion(c *gin.Context, db *gorm.DB) {
 	userUuid, err := uuid.Parse(c.MustGet("userID").(string))
 	if err != nil {
@@ -186,16 +192,33 @@ func AnswerQuestion(c *gin.Context, db *gorm.DB) {
 		return
 	}
 
-	choiceUuid, err := uuid.Parse(c.Param("choiceId"))
-	if err != nil {
+	// The choice may come from the path for single choice questions
+	var reqBody types.AnswerQuestionRequestBody
+	if choiceId := c.Param("choiceId"); choiceId != "" {
+		reqBody.ChoiceID = choiceId
+	} else if err := c.ShouldBindJSON(&reqBody); err != nil {
+		log.Printf("Error parsing request body: %v", err)
+
 		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
 			StatusCode: http.StatusBadRequest,
 			Success:    false,
-			Message:    "Invalid choice ID format.",
+			Message:    "An error occurred while parsing the request body.",
 		})
 		return
 	}
 
+	answer := utils.NewAnswer(reqBody.ChoiceID, reqBody.ChoiceIDs, reqBody.Text, reqBody.Number)
+	for _, choiceId := range answer.ChoiceIDs {
+		if _, err := uuid.Parse(choiceId); err != nil {
+			c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+				StatusCode: http.StatusBadRequest,
+				Success:    false,
+				Message:    "Invalid choice ID format.",
+			})
+			return
+		}
+	}
+
 	game, err := gorm.G[schemas.Game](db).Where("id = ?", gameUuid).
 		Preload("GameQuestions", func(db gorm.PreloadBuilder) error {
 			db.Where("answered_at IS NULL").
@@ -203,11 +226,15 @@ func AnswerQuestion(c *gin.Context, db *gorm.DB) {
 			return nil
 		}).
 		Preload("GameQuestions.Question", func(db gorm.PreloadBuilder) error {
-			db.Select("id, quiz_id, content")
+			db.Select("id, quiz_id, content, type, numeric_answer, numeric_tolerance, explanation")
 			return nil
 		}).
 		Preload("GameQuestions.Question.Choices", func(db gorm.PreloadBuilder) error {
-			db.Select("id, question_id, content, is_correct")
+			db.Select("id, question_id, content, is_correct, position")
+			return nil
+		}).
+		Preload("Quiz", func(db gorm.PreloadBuilder) error {
+			db.Select("id, question_time_limit")
 			return nil
 		}).
 		First(c)
@@ -248,46 +275,117 @@ func AnswerQuestion(c *gin.Context, db *gorm.DB) {
 		return
 	}
 
-	var isAnswerCorrect bool = false
-	var isAnswerFound bool = false
-	for i, choice := range game.GameQuestions[0].Question.Choices {
-		if choice.ID == choiceUuid.String() {
-			isAnswerFound = true
-			if choice.IsCorrect != nil && *choice.IsCorrect {
-				isAnswerCorrect = true
-			}
-		}
-		game.GameQuestions[0].Question.Choices[i].IsCorrect = nil
+	if len(game.GameQuestions) == 0 {
+		c.JSON(http.StatusConflict, types.ConflictErrorResponseStruct{
+			StatusCode: http.StatusConflict,
+			Success:    false,
+			Message:    "This game has no question left to answer.",
+		})
+		return
 	}
 
-	if !isAnswerFound {
+	currentQuestion := game.GameQuestions[0].Question
+	if currentQuestion == nil {
+		skipDeletedCurrentQuestion(c, db, game)
+		return
+	}
+
+	// Questions deleted during the game, such as through reports, are skipped
+	// on the way to the next question
+	pendingQuestions := game.GameQuestions[1:]
+	skippedQuestions := pendingQuestions[:deletedQuestionsCount(pendingQuestions)]
+	remainingQuestions := pendingQuestions[len(skippedQuestions):]
+
+	isAnswerCorrect, err := utils.EvaluateAnswer(*currentQuestion, answer)
+	if err != nil {
+		message := "The answer does not match the question type."
+		if err == utils.ErrChoiceNotInQuestion {
+			message = "Choice does not belong to the current question."
+		}
+
 		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
 			StatusCode: http.StatusBadRequest,
 			Success:    false,
-			Message:    "Choice does not belong to the current question.",
+			Message:    message,
 		})
 		return
 	}
 
+	// The current question was presented when the game started or when the
+	// previous one was answered
+	presentedAt := *game.CreatedAt
+	if game.GameQuestions[0].Position > 0 {
+		previousQuestion, err := gorm.G[schemas.GameQuestion](db).
+			Where("game_id = ? AND position = ?", game.ID, game.GameQuestions[0].Position-1).
+			Select("id, answered_at").
+			First(c)
+		if err != nil {
+			log.Printf("Error retrieving previous game question from database: %v", err)
+
+			c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+				StatusCode: http.StatusInternalServerError,
+				Success:    false,
+				Message:    "Internal server error while answering question.",
+			})
+			return
+		}
+
+		if previousQuestion.AnsweredAt != nil {
+			presentedAt = *previousQuestion.AnsweredAt
+		}
+	}
+
+	var timeLimit time.Duration
+	if game.Quiz != nil && game.Quiz.QuestionTimeLimit != nil {
+		timeLimit = time.Duration(*game.Quiz.QuestionTimeLimit) * time.Second
+	}
+
+	answeredAt := time.Now()
+	elapsed := answeredAt.Sub(presentedAt)
+	isTimedOut := utils.IsAnswerLate(elapsed, timeLimit)
+	if isTimedOut {
+		isAnswerCorrect = false
+	}
+	points := utils.QuestionPoints(isAnswerCorrect, elapsed, timeLimit)
+	game.Score += points
+
 	err = db.Transaction(func(tx *gorm.DB) error {
-		err = tx.Model(&schemas.GameQuestion{}).Where("id = ?", game.GameQuestions[0].ID).Updates(map[string]any{
-			"choice_id":   choiceUuid.String(),
+		// Only one of concurrent answers to the same question is recorded
+		result := tx.Model(&schemas.GameQuestion{}).Where("id = ? AND answered_at IS NULL", game.GameQuestions[0].ID).Updates(map[string]any{
+			"choice_id":   utils.AnswerChoiceID(currentQuestion.Type, answer),
+			"answer":      utils.FormatAnswer(currentQuestion.Type, answer),
 			"is_correct":  isAnswerCorrect,
-			"answered_at": time.Now(),
-		}).Error
+			"timed_out":   isTimedOut,
+			"points":      points,
+			"answered_at": answeredAt,
+		})
+		if result.Error != nil {
+			log.Printf("Error updating game question in database: %v", result.Error)
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return errQuestionAnswered
+		}
+
+		err = tx.Model(&schemas.Game{}).Where("id = ?", game.ID).
+			Update("score", gorm.Expr("score + ?", points)).Error
 		if err != nil {
-			log.Printf("Error updating game question in database: %v", err)
+			log.Printf("Error updating game score in database: %v", err)
+			return err
+		}
+
+		if err := skipGameQuestions(tx, skippedQuestions, answeredAt); err != nil {
+			log.Printf("Error skipping deleted game questions in database: %v", err)
 			return err
 		}
 
 		// If there are no more questions, finish the game
-		if len(game.GameQuestions) == 1 {
+		if len(remainingQuestions) == 0 {
 			finishTime := time.Now()
 
 			game.FinishedAt = &finishTime
 
-			_, err := gorm.G[schemas.Game](tx).Where("id = ?", game.ID).
-				Updates(c, game)
+			err := utils.FinishGame(c, tx, game)
 			if err != nil {
 				log.Printf("Error finishing game in database: %v", err)
 				return err
@@ -296,6 +394,14 @@ func AnswerQuestion(c *gin.Context, db *gorm.DB) {
 
 		return nil
 	})
+	if errors.Is(err, errQuestionAnswered) {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "This question has already been answered.",
+		})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
 			StatusCode: http.StatusInternalServerError,
@@ -310,8 +416,14 @@ func AnswerQuestion(c *gin.Context, db *gorm.DB) {
 			"status_code": http.StatusOK,
 			"success":     true,
 			"data": gin.H{
-				"is_correct":  isAnswerCorrect,
-				"is_finished": game.FinishedAt != nil,
+				"is_correct":      isAnswerCorrect,
+				"timed_out":       isTimedOut,
+				"points":          points,
+				"score":           game.Score,
+				"is_finished":     game.FinishedAt != nil,
+				"correct_choices": utils.CorrectChoices(*currentQuestion),
+				"correct_number":  currentQuestion.NumericAnswer,
+				"explanation":     currentQuestion.Explanation,
 			},
 		})
 		return
@@ -321,31 +433,129 @@ func AnswerQuestion(c *gin.Context, db *gorm.DB) {
 		"status_code": http.StatusOK,
 		"success":     true,
 		"data": gin.H{
-			"is_correct":    isAnswerCorrect,
-			"is_finished":   game.FinishedAt != nil,
-			"next_question": game.GameQuestions[1].Question,
+			"is_correct":      isAnswerCorrect,
+			"timed_out":       isTimedOut,
+			"points":          points,
+			"score":           game.Score,
+			"is_finished":     game.FinishedAt != nil,
+			"correct_choices": utils.CorrectChoices(*currentQuestion),
+			"correct_number":  currentQuestion.NumericAnswer,
+			"explanation":     currentQuestion.Explanation,
+			"next_question":   utils.PlayerQuestion(*remainingQuestions[0].Question),
 		},
 	})
 }
 
+// skipDeletedCurrentQuestion moves a game past its current question, and the
+// ones right after it, when they were deleted during the game, finishing the
+// game when no question is left.
+func skipDeletedCurrentQuestion(c *gin.Context, db *gorm.DB, game schemas.Game) {
+	skippedQuestions := game.GameQuestions[:deletedQuestionsCount(game.GameQuestions)]
+	remainingQuestions := game.GameQuestions[len(skippedQuestions):]
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		skippedAt := time.Now()
+		if err := skipGameQuestions(tx, skippedQuestions, skippedAt); err != nil {
+			return err
+		}
+
+		if len(remainingQuestions) == 0 {
+			game.FinishedAt = &skippedAt
+			return utils.FinishGame(c, tx, game)
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error skipping deleted game questions in database: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "Internal server error while answering question.",
+		})
+		return
+	}
+
+	data := gin.H{"is_finished": len(remainingQuestions) == 0}
+	if len(remainingQuestions) > 0 {
+		data["next_question"] = utils.PlayerQuestion(*remainingQuestions[0].Question)
+	}
+
+	c.JSON(http.StatusGone, gin.H{
+		"status_code": http.StatusGone,
+		"success":     false,
+		"message":     "The current question has been deleted.",
+		"data":        data,
+	})
+}
+
+// deletedQuestionsCount returns how many of the game questions, from the first
+// one, had their question deleted during the game.
+func deletedQuestionsCount(gameQuestions []schemas.GameQuestion) int {
+	for i, gameQuestion := range gameQuestions {
+		if gameQuestion.Question != nil {
+			return i
+		}
+	}
+
+	return len(gameQuestions)
+}
+
+// skipGameQuestions records the game questions as answered wrongly with no
+// points, so the game goes on past them.
+func skipGameQuestions(tx *gorm.DB, gameQuestions []schemas.GameQuestion, skippedAt time.Time) error {
+	if len(gameQuestions) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(gameQuestions))
+	for i, gameQuestion := range gameQuestions {
+		ids[i] = gameQuestion.ID
+	}
+
+	return tx.Model(&schemas.GameQuestion{}).Where("id IN ? AND answered_at IS NULL", ids).Updates(map[string]any{
+		"is_correct":  false,
+		"points":      0,
+		"answered_at": skippedAt,
+	}).Error
+}
+
+// AnswerQuestionWithChoice godoc
+// @Summary Answer a single choice question in a game
+// @Schemes
+// @Description Submit the chosen choice for the current single choice or true/false question in a game session
+// @Param gameId path string true "Game ID"
+// @Param choiceId path string true "Choice ID"
+// @Tags games
+// @Produce json
+// @Success 200 {object} types.AnswerQuestionResponseStruct
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 404 {object} types.NotFoundErrorResponseStruct
+// @Failure 409 {object} types.ConflictErrorResponseStruct
+// @Failure 410 {object} types.DeletedQuestionResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /games/{gameId}/answer/{choiceId} [post]
+func AnswerQuestionWithChoice(c *gin.Context, db *gorm.DB) {
+	AnswerQuestion(c, db)
+}
+
 // GamesResults godoc
 // @Summary Get user's finished games
 // @Schemes
-// @Description Retrieve all finished game sessions for the authenticated user
+// @Description Retrieve the finished game sessions of the authenticated user, newest first
 // @Tags games
 // @Produce json
+// @Param limit query int false "Limit of games per page (min: 5, max: 50)" default(10)
+// @Param cursor query string false "Cursor of the page, taken from next_cursor or prev_cursor"
+// @Param page query int false "Page number (0-indexed), used without a cursor" default(0)
 // @Success 200 {object} types.GamesResultsResponseStruct
 // @Failure 400 {object} types.BadRequestErrorResponseStruct
 // @Failure 403 {object} types.ForbiddenErrorResponseStruct
 // @Failure 500 {object} types.InternalServerErrorResponseStruct
 // @Router /me/games [get]
 func GamesResults(c *gin.Context, db *gorm.DB) {
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "0"))
-
-	limit = max(5, min(50, limit))
-	page = max(0, page)
-
 	userUuid, err := uuid.Parse(c.MustGet("userID").(string))
 	if err != nil {
 		log.Printf("Error parsing User UUID from context: %v", err)
@@ -358,35 +568,44 @@ func GamesResults(c *gin.Context, db *gorm.DB) {
 		return
 	}
 
-	gamesCount, err := gorm.G[schemas.Game](db).
-		Where("user_id = ? AND finished_at IS NOT NULL", userUuid.String()).
-		Count(c.Request.Context(), "id")
-	if err != nil {
-		log.Printf("Error retrieving games from database: %v", err)
-
-		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
-			StatusCode: http.StatusInternalServerError,
-			Success:    false,
-			Message:    "Internal server error while retrieving games.",
-		})
+	pagination, paginate, ok := parsePagination(c, "newest", []utils.SortKey{
+		{Column: "games.created_at", Desc: true, Parse: utils.TimeCursorValue},
+		{Column: "games.id", Desc: true, Parse: utils.UUIDCursorValue},
+	})
+	if !ok {
 		return
 	}
 
+	var gamesCount int64
+	if !pagination.UsesCursor() {
+		gamesCount, err = gorm.G[schemas.Game](db).
+			Where("user_id = ? AND finished_at IS NOT NULL", userUuid.String()).
+			Count(c.Request.Context(), "id")
+		if err != nil {
+			log.Printf("Error retrieving games from database: %v", err)
+
+			c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+				StatusCode: http.StatusInternalServerError,
+				Success:    false,
+				Message:    "Internal server error while retrieving games.",
+			})
+			return
+		}
+	}
+
 	games, err := gorm.G[schemas.Game](db).
 		Where("user_id = ? AND finished_at IS NOT NULL", userUuid.String()).
-		Select("id, user_id, created_at, updated_at, finished_at").
+		Select("id, user_id, score, created_at, updated_at, finished_at").
 		Preload("GameQuestions", nil).
 		Preload("GameQuestions.Question", func(db gorm.PreloadBuilder) error {
-			db.Select("id, content")
+			db.Select("id, content, type")
 			return nil
 		}).
 		Preload("GameQuestions.Choice", func(db gorm.PreloadBuilder) error {
 			db.Select("id, content")
 			return nil
 		}).
-		Limit(limit).
-		Offset(page * limit).
-		Order("created_at DESC").
+		Scopes(paginate).
 		Find(c)
 	if err != nil {
 		log.Printf("Error retrieving games from database: %v", err)
@@ -399,16 +618,11 @@ func GamesResults(c *gin.Context, db *gorm.DB) {
 		return
 	}
 
+	games, cursors := utils.PageItems(pagination, "newest", games, func(game schemas.Game) []any {
+		return []any{game.CreatedAt, game.ID}
+	})
 	if len(games) == 0 {
-		c.JSON(http.StatusOK, gin.H{
-			"status_code": http.StatusOK,
-			"success":     true,
-			"data": gin.H{
-				"games":   []schemas.Game{},
-				"maxPage": math.Ceil(float64(gamesCount)/float64(limit)) - 1,
-			},
-		})
-		return
+		games = []schemas.Game{}
 	}
 
 	var correctAnswersCount uint = 0
@@ -431,13 +645,19 @@ func GamesResults(c *gin.Context, db *gorm.DB) {
 		correctAnswersCount = 0
 	}
 
+	data := gin.H{
+		"games":       games,
+		"next_cursor": cursors.Next,
+		"prev_cursor": cursors.Prev,
+	}
+	if !pagination.UsesCursor() {
+		data["maxPage"] = pagination.MaxPage(gamesCount)
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"status_code": http.StatusOK,
 		"success":     true,
-		"data": gin.H{
-			"games":   games,
-			"maxPage": int(gamesCount)/limit - 1,
-		},
+		"data":        data,
 	})
 }
 
@@ -478,10 +698,14 @@ func GameResultById(c *gin.Context, db *gorm.DB) {
 	}
 
 	game, err := gorm.G[schemas.Game](db).Where("id = ?", gameUuid).
-		Select("id, user_id, created_at, updated_at, finished_at").
+		Select("id, user_id, score, created_at, updated_at, finished_at").
 		Preload("GameQuestions", nil).
 		Preload("GameQuestions.Question", func(db gorm.PreloadBuilder) error {
-			db.Select("id, content")
+			db.Select("id, content, type, numeric_answer, explanation")
+			return nil
+		}).
+		Preload("GameQuestions.Question.Choices", func(db gorm.PreloadBuilder) error {
+			db.Select("id, question_id, content, is_correct, position")
 			return nil
 		}).
 		Preload("GameQuestions.Choice", func(db gorm.PreloadBuilder) error {
@@ -532,6 +756,11 @@ func GameResultById(c *gin.Context, db *gorm.DB) {
 			correctAnswersCount++
 		}
 
+		if gameQuestion.Question != nil {
+			game.GameQuestions[i].CorrectChoices = utils.CorrectChoices(*gameQuestion.Question)
+			game.GameQuestions[i].Question.Choices = nil
+		}
+
 		if i == 0 {
 			game.GameQuestions[i].SecondsTaken = uint(gameQuestion.AnsweredAt.Sub(*game.CreatedAt).Seconds())
 		} else {
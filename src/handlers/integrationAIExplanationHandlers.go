@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"intelliquiz/src/ai"
+	"intelliquiz/src/database/schemas"
+	"intelliquiz/src/types"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GenerateExplanationAI godoc
+// @Summary Generate an explanation for a question
+// @Schemes
+// @Description Generate an explanation of why the correct answer of an own question is correct. Players see the explanation after answering the question. The explanation is only stored in the question when save is true, otherwise it can be edited and applied with the question update endpoint.
+// @Tags ai
+// @Accept json
+// @Produce json
+// @Param data body types.GenerateExplanationRequestDTO true "Generate Explanation Request Body"
+// @Success 200 {object} types.GenerateExplanationSuccessResponseDTO
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 402 {object} types.PaymentRequiredErrorResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 404 {object} types.NotFoundErrorResponseStruct
+// @Failure 422 {object} types.UnprocessableEntityErrorResponseStruct
+// @Failure 429 {object} types.TooManyRequestsErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Failure 503 {object} types.ServiceUnavailableErrorResponseStruct
+// @Failure 504 {object} types.GatewayTimeoutErrorResponseStruct
+// @Router /ai/generate-explanation [post]
+func GenerateExplanationAI(c *gin.Context, db *gorm.DB, aiProvider ai.AIProvider) {
+	if aiProvider == nil {
+		log.Printf("AI provider is not configured")
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "AI service is not configured properly. Please verify the server environment settings.",
+		})
+		return
+	}
+
+	userUuid, err := uuid.Parse(c.MustGet("userID").(string))
+	if err != nil {
+		log.Printf("Error parsing UUID from token: %v", err)
+
+		c.JSON(http.StatusForbidden, types.ForbiddenErrorResponseStruct{
+			StatusCode: http.StatusForbidden,
+			Success:    false,
+			Message:    "Invalid user ID format on claims.",
+		})
+		return
+	}
+
+	var reqBody types.GenerateExplanationRequestDTO
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		log.Printf("Error parsing request body: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "An error occurred while parsing the request body.",
+		})
+		return
+	}
+
+	question, err := gorm.G[schemas.Question](db).Where("id = ?", reqBody.QuestionID).
+		Select("id, content, type, quiz_id, numeric_answer, numeric_tolerance").
+		Preload("Choices", func(db gorm.PreloadBuilder) error {
+			db.Select("id, content, is_correct, position, question_id").
+				Order("position ASC, created_at ASC")
+			return nil
+		}).
+		Preload("Quiz", func(db gorm.PreloadBuilder) error {
+			db.Select("id, name, category_id, created_by")
+			return nil
+		}).
+		Preload("Quiz.Category", func(db gorm.PreloadBuilder) error {
+			db.Select("id, name")
+			return nil
+		}).
+		First(c.Request.Context())
+	if err != nil {
+		log.Printf("Error fetching question by ID: %v", err)
+
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, types.NotFoundErrorResponseStruct{
+				StatusCode: http.StatusNotFound,
+				Success:    false,
+				Message:    "Question not found.",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while fetching the question.",
+		})
+		return
+	}
+
+	if question.Quiz == nil || question.Quiz.CreatedBy != userUuid.String() {
+		c.JSON(http.StatusForbidden, types.ForbiddenErrorResponseStruct{
+			StatusCode: http.StatusForbidden,
+			Success:    false,
+			Message:    "You do not have permission to explain this question.",
+		})
+		return
+	}
+
+	req := ai.ExplanationRequest{
+		QuizTitle: question.Quiz.Name,
+		Question:  authoredQuestion(question),
+	}
+	if question.Quiz.Category != nil {
+		req.Category = question.Quiz.Category.Name
+	}
+
+	explanation, err := aiProvider.GenerateExplanation(c.Request.Context(), req)
+	if err != nil {
+		respondAIError(c, err)
+		return
+	}
+	explanation = truncateRunes(strings.TrimSpace(explanation), 1000)
+
+	if reqBody.Save {
+		_, err := gorm.G[schemas.Question](db).Where("id = ?", question.ID).
+			Update(c.Request.Context(), "explanation", explanation)
+		if err != nil {
+			log.Printf("Error saving question explanation: %v", err)
+
+			c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+				StatusCode: http.StatusInternalServerError,
+				Success:    false,
+				Message:    "An error occurred while saving the explanation.",
+			})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"statusCode": http.StatusOK,
+		"success":    true,
+		"data": types.GeneratedExplanationDataDTO{
+			QuestionID:  question.ID,
+			Explanation: explanation,
+			Saved:       reqBody.Save,
+		},
+	})
+}
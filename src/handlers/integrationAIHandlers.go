@@ -1,34 +1,49 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"intelliquiz/src/ai"
 	"intelliquiz/src/database/schemas"
+	"intelliquiz/src/moderation"
 	"intelliquiz/src/types"
+	"io"
 	"log"
 	"net/http"
-	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
-	openai "github.com/sashabaranov/go-openai"
-	"github.com/sashabaranov/go-openai/jsonschema"
 	"gorm.io/gorm"
 )
 
+const (
+	maxSourceFileSize = 10 << 20
+	// maxSourceBodySize leaves room for the other fields of the form
+	maxSourceBodySize = maxSourceFileSize + 1<<20
+)
+
 // GenerateQuizAI godoc
 // @Summary Generate Full Quiz with Questions and Choices
 // @Schemes
-// @Description Generate a complete quiz with title, questions and choices using AI
+// @Description Generate a complete quiz with title, questions and choices using AI, saved as a draft of the user. The number of questions (default: 5), difficulty (default: mixed), language (default: pt-BR), choices per question (default: 2 to 6) and topic can be customized
 // @Tags ai
 // @Produce json
 // @Param data body types.GenerateQuizRequestDTO true "Generate Quiz Request Body"
 // @Success 200 {object} types.GenerateQuizSuccessResponseDTO
 // @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 402 {object} types.PaymentRequiredErrorResponseStruct
 // @Failure 403 {object} types.ForbiddenErrorResponseStruct
 // @Failure 404 {object} types.NotFoundErrorResponseStruct
+// @Failure 422 {object} types.UnprocessableEntityErrorResponseStruct
+// @Failure 429 {object} types.TooManyRequestsErrorResponseStruct
 // @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Failure 503 {object} types.ServiceUnavailableErrorResponseStruct
+// @Failure 504 {object} types.GatewayTimeoutErrorResponseStruct
 // @Router /ai/generate-quiz [post]
-func GenerateQuizAI(c *gin.Context, db *gorm.DB, openAIClient *openai.Client) {
-	if openAIClient == nil {
-		log.Printf("OpenAI client is not initialized")
+func GenerateQuizAI(c *gin.Context, db *gorm.DB, aiProvider ai.AIProvider) {
+	if aiProvider == nil {
+		log.Printf("AI provider is not configured")
 
 		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
 			StatusCode: http.StatusInternalServerError,
@@ -38,145 +53,113 @@ func GenerateQuizAI(c *gin.Context, db *gorm.DB, openAIClient *openai.Client) {
 		return
 	}
 
-	var reqBody types.GenerateQuizRequestDTO
-	if err := c.ShouldBindJSON(&reqBody); err != nil {
-		log.Printf("Error parsing request body: %v", err)
-
-		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
-			StatusCode: http.StatusBadRequest,
-			Success:    false,
-			Message:    "An error occurred while parsing the request body.",
-		})
+	generation, ok := bindGenerateQuizRequest(c, db)
+	if !ok {
 		return
 	}
 
-	category, err := gorm.G[schemas.Category](db).
-		Where("id = ?", reqBody.CategoryID).
-		First(c.Request.Context())
+	result, err := aiProvider.GenerateQuiz(c.Request.Context(), generation.request)
 	if err != nil {
-		log.Printf("Error fetching category: %v", err)
-
-		if err == gorm.ErrRecordNotFound {
-			c.JSON(http.StatusNotFound, types.NotFoundErrorResponseStruct{
-				StatusCode: http.StatusNotFound,
-				Success:    false,
-				Message:    "Category not found.",
-			})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
-			StatusCode: http.StatusInternalServerError,
-			Success:    false,
-			Message:    "An error occurred while fetching the category.",
-		})
+		respondAIError(c, err)
 		return
 	}
 
-	type Choice struct {
-		Content   string `json:"content"`
-		IsCorrect bool   `json:"is_correct"`
-	}
-	type Question struct {
-		QuestionContent string   `json:"question_content"`
-		Choices         []Choice `json:"choices"`
+	draft := schemas.QuizDraft{
+		Source:     schemas.QuizDraftSourceQuizAI,
+		Name:       result.QuizTitle,
+		CategoryID: generation.categoryID,
 	}
-	type Result struct {
-		QuizTitle string     `json:"quiz_title"`
-		Questions []Question `json:"questions"`
-	}
-	var result Result
-	schema, err := jsonschema.GenerateSchemaForType(result)
-	if err != nil {
-		log.Printf("Error generating JSON schema: %v", err)
 
-		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
-			StatusCode: http.StatusInternalServerError,
-			Success:    false,
-			Message:    "An error occurred while preparing the AI request.",
-		})
-		return
+	// Convert internal result to DTO
+	questions := make([]types.GeneratedQuizQuestionDTO, len(result.Questions))
+	for i, question := range result.Questions {
+		draft.Questions = append(draft.Questions, generatedDraftQuestion(question, ""))
+		choices := make([]types.GeneratedChoiceDTO, len(question.Choices))
+		for j, choice := range question.Choices {
+			choices[j] = types.GeneratedChoiceDTO{
+				Content:   choice.Content,
+				IsCorrect: choice.IsCorrect,
+			}
+		}
+		questions[i] = types.GeneratedQuizQuestionDTO{
+			QuestionContent: question.QuestionContent,
+			Choices:         choices,
+		}
 	}
 
-	response, err := openAIClient.CreateChatCompletion(c.Request.Context(), openai.ChatCompletionRequest{
-		Model: openai.GPT4oMini,
-		Messages: []openai.ChatCompletionMessage{
-			{
-				Role: openai.ChatMessageRoleUser,
-				Content: "Você é um gerador de quizzes completos em pt-BR.\n" +
-					"Tarefa: dado category (categoria do quiz), produza 1 (um) quiz completo com título e 5 (cinco) questões, cada uma com 2 (duas) a 6 (seis) alternativas de resposta.\n" +
-					"Regras para o título do quiz:\n" +
-					"- \"seja relevante à categoria;\"\n" +
-					"- \"tenha no máximo 60 caracteres;\"\n" +
-					"- \"use português correto com contrações apropriadas (de+o=do; de+a=da; de+os=dos; de+as=das) e acentuação;\"\n" +
-					"- \"capitalize a primeira letra;\"\n" +
-					"- \"seja criativo e atrativo;\"\n" +
-					"Regras para cada questão:\n" +
-					"- \"seja relevante à categoria e coerente com o título do quiz;\"\n" +
-					"- \"tenha 1 linha e no máximo 255 caracteres;\"\n" +
-					"- \"use português correto, com contrações apropriadas (de+o=do; de+a=da; de+os=dos; de+as=das) e acentuação;\"\n" +
-					"- \"varie formas interrogativas quando fizer sentido (qual/quanto/onde/quando/quem/como/por que);\"\n" +
-					"- \"quando necessário, termine o enunciado da questão com '?';\"\n" +
-					"- \"capitalize a primeira letra;\"\n" +
-					"- \"as questões devem variar em dificuldade e tópicos dentro da categoria;\"\n" +
-					"Regras para as alternativas de cada questão:\n" +
-					"- \"gere de 2 a 6 alternativas por questão;\"\n" +
-					"- \"apenas 1 alternativa deve ser correta (is_correct: true);\"\n" +
-					"- \"as outras alternativas incorretas devem ser convincentes e plausíveis;\"\n" +
-					"- \"cada alternativa deve ter no máximo 150 caracteres;\"\n" +
-					"- \"use português correto com contrações e acentuação apropriadas;\"\n" +
-					"- \"capitalize a primeira letra de cada alternativa;\"\n" +
-					"- \"as alternativas não devem ser óbvias demais;\"\n" +
-					"Saída apenas em JSON conforme o schema fornecido.\n\n" +
-					"category: " + category.Name + "\n",
-			},
-		},
-		ResponseFormat: &openai.ChatCompletionResponseFormat{
-			Type: openai.ChatCompletionResponseFormatTypeJSONSchema,
-			JSONSchema: &openai.ChatCompletionResponseFormatJSONSchema{
-				Name:   "generate-full-quiz",
-				Schema: schema,
-				Strict: true,
-			},
+	c.JSON(http.StatusOK, types.GenerateQuizSuccessResponseDTO{
+		StatusCode: http.StatusOK,
+		Success:    true,
+		Data: types.GeneratedQuizDataDTO{
+			DraftID:   saveGeneratedDraft(c, db, draft),
+			QuizTitle: result.QuizTitle,
+			Questions: questions,
 		},
 	})
-	if err != nil {
-		log.Printf("Error from OpenAI API: %v", err)
+}
+
+// GenerateQuizFromTextAI godoc
+// @Summary Generate Quiz from Source Material
+// @Schemes
+// @Description Generate a quiz grounded in pasted text or an uploaded PDF, Markdown or plain text file (max 10 MB). Each question carries the source excerpt it came from so it can be verified before publishing the quiz, and the result is saved as a draft of the user. The same fields can also be sent as a JSON body with the text
+// @Tags ai
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file false "PDF, Markdown or plain text file"
+// @Param text formData string false "Source text, used when no file is uploaded"
+// @Param category_id formData string false "Category ID"
+// @Param topic formData string false "Topic"
+// @Param question_count formData int false "Number of questions (min: 2, max: 50)" default(5)
+// @Param difficulty formData string false "Difficulty" Enums(easy, medium, hard, mixed) default(mixed)
+// @Param language formData string false "Language" default(pt-BR)
+// @Param min_choices formData int false "Minimum number of choices per question (min: 2, max: 6)" default(2)
+// @Param max_choices formData int false "Maximum number of choices per question (min: 2, max: 6)" default(6)
+// @Success 200 {object} types.GenerateQuizFromTextSuccessResponseDTO
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 402 {object} types.PaymentRequiredErrorResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 404 {object} types.NotFoundErrorResponseStruct
+// @Failure 422 {object} types.UnprocessableEntityErrorResponseStruct
+// @Failure 429 {object} types.TooManyRequestsErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Failure 503 {object} types.ServiceUnavailableErrorResponseStruct
+// @Failure 504 {object} types.GatewayTimeoutErrorResponseStruct
+// @Router /ai/generate-quiz-from-text [post]
+func GenerateQuizFromTextAI(c *gin.Context, db *gorm.DB, aiProvider ai.AIProvider) {
+	if aiProvider == nil {
+		log.Printf("AI provider is not configured")
 
 		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
 			StatusCode: http.StatusInternalServerError,
 			Success:    false,
-			Message:    "An error occurred while communicating with the AI service.",
+			Message:    "AI service is not configured properly. Please verify the server environment settings.",
 		})
 		return
 	}
 
-	if len(response.Choices) == 0 {
-		log.Printf("No choices returned from OpenAI API")
-
-		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
-			StatusCode: http.StatusInternalServerError,
-			Success:    false,
-			Message:    "AI service did not return any suggestions.",
-		})
+	generation, ok := bindSourceQuizRequest(c, db)
+	if !ok {
 		return
 	}
 
-	err = schema.Unmarshal(response.Choices[0].Message.Content, &result)
+	result, err := ai.GenerateQuizFromSource(c.Request.Context(), aiProvider, generation.text, generation.request)
 	if err != nil {
-		log.Printf("Error unmarshaling AI response: %v", err)
-
-		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
-			StatusCode: http.StatusInternalServerError,
-			Success:    false,
-			Message:    "An error occurred while processing the AI response.",
-		})
+		respondAIError(c, err)
 		return
 	}
 
-	// Convert internal result to DTO
-	questions := make([]types.GeneratedQuizQuestionDTO, len(result.Questions))
+	draft := schemas.QuizDraft{
+		Source:     schemas.QuizDraftSourceTextAI,
+		Name:       result.QuizTitle,
+		CategoryID: generation.categoryID,
+	}
+
+	questions := make([]types.GeneratedSourceQuestionDTO, len(result.Questions))
 	for i, question := range result.Questions {
+		draft.Questions = append(draft.Questions, generatedDraftQuestion(ai.GeneratedQuestion{
+			QuestionContent: question.QuestionContent,
+			Choices:         question.Choices,
+		}, question.SourceExcerpt))
 		choices := make([]types.GeneratedChoiceDTO, len(question.Choices))
 		for j, choice := range question.Choices {
 			choices[j] = types.GeneratedChoiceDTO{
@@ -184,16 +167,18 @@ func GenerateQuizAI(c *gin.Context, db *gorm.DB, openAIClient *openai.Client) {
 				IsCorrect: choice.IsCorrect,
 			}
 		}
-		questions[i] = types.GeneratedQuizQuestionDTO{
+		questions[i] = types.GeneratedSourceQuestionDTO{
 			QuestionContent: question.QuestionContent,
 			Choices:         choices,
+			SourceExcerpt:   question.SourceExcerpt,
 		}
 	}
 
-	c.JSON(http.StatusOK, types.GenerateQuizSuccessResponseDTO{
+	c.JSON(http.StatusOK, types.GenerateQuizFromTextSuccessResponseDTO{
 		StatusCode: http.StatusOK,
 		Success:    true,
-		Data: types.GeneratedQuizDataDTO{
+		Data: types.GeneratedSourceQuizDataDTO{
+			DraftID:   saveGeneratedDraft(c, db, draft),
 			QuizTitle: result.QuizTitle,
 			Questions: questions,
 		},
@@ -203,19 +188,24 @@ func GenerateQuizAI(c *gin.Context, db *gorm.DB, openAIClient *openai.Client) {
 // GenerateQuestionAI godoc
 // @Summary Generate Full Question with Choices
 // @Schemes
-// @Description Generate a complete question with multiple choices using AI
+// @Description Generate a complete question with multiple choices using AI. The question is appended to the draft given by draft_id, or saved as a new draft
 // @Tags ai
 // @Produce json
 // @Param data body types.GenerateQuestionRequestDTO true "Generate Question Request Body"
 // @Success 200 {object} types.GenerateQuestionSuccessResponseDTO
 // @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 402 {object} types.PaymentRequiredErrorResponseStruct
 // @Failure 403 {object} types.ForbiddenErrorResponseStruct
 // @Failure 404 {object} types.NotFoundErrorResponseStruct
+// @Failure 422 {object} types.UnprocessableEntityErrorResponseStruct
+// @Failure 429 {object} types.TooManyRequestsErrorResponseStruct
 // @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Failure 503 {object} types.ServiceUnavailableErrorResponseStruct
+// @Failure 504 {object} types.GatewayTimeoutErrorResponseStruct
 // @Router /ai/generate-question [post]
-func GenerateQuestionAI(c *gin.Context, db *gorm.DB, openAIClient *openai.Client) {
-	if openAIClient == nil {
-		log.Printf("OpenAI client is not initialized")
+func GenerateQuestionAI(c *gin.Context, db *gorm.DB, aiProvider ai.AIProvider) {
+	if aiProvider == nil {
+		log.Printf("AI provider is not configured")
 
 		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
 			StatusCode: http.StatusInternalServerError,
@@ -237,6 +227,15 @@ func GenerateQuestionAI(c *gin.Context, db *gorm.DB, openAIClient *openai.Client
 		return
 	}
 
+	var draft *schemas.QuizDraft
+	if reqBody.DraftID != "" {
+		ownDraft, ok := findOwnDraft(c, db, reqBody.DraftID, "update")
+		if !ok {
+			return
+		}
+		draft = &ownDraft
+	}
+
 	category, err := gorm.G[schemas.Category](db).
 		Where("id = ?", reqBody.CategoryID).
 		First(c.Request.Context())
@@ -260,95 +259,29 @@ func GenerateQuestionAI(c *gin.Context, db *gorm.DB, openAIClient *openai.Client
 		return
 	}
 
-	type Choice struct {
-		Content   string `json:"content"`
-		IsCorrect bool   `json:"is_correct"`
-	}
-	type Result struct {
-		QuestionContent string   `json:"question_content"`
-		Choices         []Choice `json:"choices"`
-	}
-	var result Result
-	schema, err := jsonschema.GenerateSchemaForType(result)
-	if err != nil {
-		log.Printf("Error generating JSON schema: %v", err)
-
-		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
-			StatusCode: http.StatusInternalServerError,
-			Success:    false,
-			Message:    "An error occurred while preparing the AI request.",
-		})
-		return
-	}
-
-	response, err := openAIClient.CreateChatCompletion(c.Request.Context(), openai.ChatCompletionRequest{
-		Model: openai.GPT4oMini,
-		Messages: []openai.ChatCompletionMessage{
-			{
-				Role: openai.ChatMessageRoleUser,
-				Content: "Você é um gerador de questões de quiz completas em pt-BR.\n" +
-					"Tarefa: dado category (categoria do quiz) e quiz_title (nome do quiz), produza 1 (uma) questão completa com 2 (duas) a 6 (seis) alternativas de resposta.\n" +
-					"Regras para a questão:\n" +
-					"- \"seja relevante à categoria e coerente com o quiz_title;\"\n" +
-					"- \"tenha 1 linha e no máximo 255 caracteres;\"\n" +
-					"- \"use português correto, com contrações apropriadas (de+o=do; de+a=da; de+os=dos; de+as=das) e acentuação;\"\n" +
-					"- \"varie formas interrogativas quando fizer sentido (qual/quanto/onde/quando/quem/como/por que);\"\n" +
-					"- \"quando necessário, termine o enunciado da questão com '?';\"\n" +
-					"- \"capitalize a primeira letra;\"\n" +
-					"Regras para as alternativas:\n" +
-					"- \"gere de 2 a 6 alternativas;\"\n" +
-					"- \"apenas 1 alternativa deve ser correta (is_correct: true);\"\n" +
-					"- \"as outras alternativas incorretas devem ser convincentes e plausíveis;\"\n" +
-					"- \"cada alternativa deve ter no máximo 150 caracteres;\"\n" +
-					"- \"use português correto com contrações e acentuação apropriadas;\"\n" +
-					"- \"capitalize a primeira letra de cada alternativa;\"\n" +
-					"- \"as alternativas não devem ser óbvias demais;\"\n" +
-					"Saída apenas em JSON conforme o schema fornecido.\n\n" +
-					"category: " + category.Name + "\n" +
-					"quiz_title: " + reqBody.QuizTitle + "\n",
-			},
-		},
-		ResponseFormat: &openai.ChatCompletionResponseFormat{
-			Type: openai.ChatCompletionResponseFormatTypeJSONSchema,
-			JSONSchema: &openai.ChatCompletionResponseFormatJSONSchema{
-				Name:   "generate-full-question",
-				Schema: schema,
-				Strict: true,
-			},
-		},
+	result, err := aiProvider.GenerateQuestion(c.Request.Context(), ai.QuestionRequest{
+		Category:  category.Name,
+		QuizTitle: reqBody.QuizTitle,
 	})
 	if err != nil {
-		log.Printf("Error from OpenAI API: %v", err)
-
-		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
-			StatusCode: http.StatusInternalServerError,
-			Success:    false,
-			Message:    "An error occurred while communicating with the AI service.",
-		})
-		return
-	}
-
-	if len(response.Choices) == 0 {
-		log.Printf("No choices returned from OpenAI API")
-
-		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
-			StatusCode: http.StatusInternalServerError,
-			Success:    false,
-			Message:    "AI service did not return any suggestions.",
-		})
+		respondAIError(c, err)
 		return
 	}
 
-	err = schema.Unmarshal(response.Choices[0].Message.Content, &result)
-	if err != nil {
-		log.Printf("Error unmarshaling AI response: %v", err)
-
-		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
-			StatusCode: http.StatusInternalServerError,
-			Success:    false,
-			Message:    "An error occurred while processing the AI response.",
+	var draftId string
+	if draft != nil {
+		var ok bool
+		draftId, ok = appendGeneratedQuestion(c, db, *draft, generatedDraftQuestion(*result, ""))
+		if !ok {
+			return
+		}
+	} else {
+		draftId = saveGeneratedDraft(c, db, schemas.QuizDraft{
+			Source:     schemas.QuizDraftSourceQuestionAI,
+			Name:       reqBody.QuizTitle,
+			CategoryID: &category.ID,
+			Questions:  []schemas.QuizDraftQuestion{generatedDraftQuestion(*result, "")},
 		})
-		return
 	}
 
 	// Convert internal result to DTO
@@ -364,6 +297,7 @@ func GenerateQuestionAI(c *gin.Context, db *gorm.DB, openAIClient *openai.Client
 		StatusCode: http.StatusOK,
 		Success:    true,
 		Data: types.GeneratedQuestionDataDTO{
+			DraftID:         draftId,
 			QuestionContent: result.QuestionContent,
 			Choices:         choices,
 		},
@@ -379,12 +313,17 @@ func GenerateQuestionAI(c *gin.Context, db *gorm.DB, openAIClient *openai.Client
 // @Param data body types.AutocompleteQuizRequestDTO true "Autocomplete Quiz Request Body"
 // @Success 200 {object} types.AutocompleteQuizSuccessResponseDTO
 // @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 402 {object} types.PaymentRequiredErrorResponseStruct
 // @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 422 {object} types.UnprocessableEntityErrorResponseStruct
+// @Failure 429 {object} types.TooManyRequestsErrorResponseStruct
 // @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Failure 503 {object} types.ServiceUnavailableErrorResponseStruct
+// @Failure 504 {object} types.GatewayTimeoutErrorResponseStruct
 // @Router /ai/autocomplete-quiz [post]
-func AutocompleteQuiz(c *gin.Context, db *gorm.DB, openAIClient *openai.Client) {
-	if openAIClient == nil {
-		log.Printf("OpenAI client is not initialized")
+func AutocompleteQuiz(c *gin.Context, db *gorm.DB, aiProvider ai.AIProvider) {
+	if aiProvider == nil {
+		log.Printf("AI provider is not configured")
 
 		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
 			StatusCode: http.StatusInternalServerError,
@@ -429,88 +368,19 @@ func AutocompleteQuiz(c *gin.Context, db *gorm.DB, openAIClient *openai.Client)
 		return
 	}
 
-	type Result struct {
-		SuggestedContent string `json:"suggested_content"`
-	}
-	var result Result
-	schema, err := jsonschema.GenerateSchemaForType(result)
-	if err != nil {
-		log.Printf("Error generating JSON schema: %v", err)
-
-		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
-			StatusCode: http.StatusInternalServerError,
-			Success:    false,
-			Message:    "An error occurred while preparing the AI request.",
-		})
-		return
-	}
-
-	response, err := openAIClient.CreateChatCompletion(c.Request.Context(), openai.ChatCompletionRequest{
-		Model: openai.GPT4oMini,
-		Messages: []openai.ChatCompletionMessage{
-			{
-				Role: openai.ChatMessageRoleUser,
-				Content: "Você é um gerador de sugestões de nomes de quiz em pt-BR.\n" +
-					"Tarefa: dado category (categoria do quiz), partial (texto inicial que o usuário digitou) e limit (máximo de caracteres para o título), produza 1(um) título que complete o partial já inserido sem alterar seus caracteres.\n" +
-					"- \"completem naturalmente o texto inicial (partial), mantendo o estilo e a capitalização;\"\n" +
-					"- \"tenham 1 linha cada e no máx. 60 caracteres;\"\n" +
-					"- \"sejam relevantes à categoria e variem entre lugares/temas/tempos diferentes;\"\n" +
-					"- \"usem preposições e artigos corretos em português (ex.: de + o = do; de + a = da; de + os = dos; de + as = das), incluindo contrações antes de nomes de países/continentes/estados/cidades (\"Geografia do Brasil\", \"da França\", \"dos Estados Unidos\", \"da Inglaterra\");\"\n" +
-					"- \"não repitam sugestões nem \"…\" no final.\"\n" +
-					"- \"nos casos em que o nome seja gerada e não autocompletada, capitalize a primeira letra;\"\n" +
-					"Saída apenas em JSON conforme o schema fornecido.\n\n" +
-					"category: " + category.Name + "\n" +
-					"initial: " + reqBody.Content + "\n" +
-					"limit: 60\n\n",
-			},
-		},
-		ResponseFormat: &openai.ChatCompletionResponseFormat{
-			Type: openai.ChatCompletionResponseFormatTypeJSONSchema,
-			JSONSchema: &openai.ChatCompletionResponseFormatJSONSchema{
-				Name:   "autocompletion-quiz-title",
-				Schema: schema,
-				Strict: true,
-			},
-		},
+	suggestion, err := aiProvider.AutocompleteQuiz(c.Request.Context(), ai.QuizAutocompleteRequest{
+		Category: category.Name,
+		Partial:  reqBody.Content,
 	})
 	if err != nil {
-		log.Printf("Error from OpenAI API: %v", err)
-
-		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
-			StatusCode: http.StatusInternalServerError,
-			Success:    false,
-			Message:    "An error occurred while communicating with the AI service.",
-		})
-		return
-	}
-
-	if len(response.Choices) == 0 {
-		log.Printf("No choices returned from OpenAI API")
-
-		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
-			StatusCode: http.StatusInternalServerError,
-			Success:    false,
-			Message:    "AI service did not return any suggestions.",
-		})
-		return
-	}
-
-	err = schema.Unmarshal(response.Choices[0].Message.Content, &result)
-	if err != nil {
-		log.Printf("Error unmarshaling AI response: %v", err)
-
-		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
-			StatusCode: http.StatusInternalServerError,
-			Success:    false,
-			Message:    "An error occurred while processing the AI response.",
-		})
+		respondAIError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, types.AutocompleteQuizSuccessResponseDTO{
 		StatusCode: http.StatusOK,
 		Success:    true,
-		Data:       result.SuggestedContent,
+		Data:       suggestion,
 	})
 }
 
@@ -523,12 +393,17 @@ func AutocompleteQuiz(c *gin.Context, db *gorm.DB, openAIClient *openai.Client)
 // @Param data body types.AutocompleteQuestionRequestDTO true "Autocomplete Question Request Body"
 // @Success 200 {object} types.AutocompleteQuestionSuccessResponseDTO
 // @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 402 {object} types.PaymentRequiredErrorResponseStruct
 // @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 422 {object} types.UnprocessableEntityErrorResponseStruct
+// @Failure 429 {object} types.TooManyRequestsErrorResponseStruct
 // @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Failure 503 {object} types.ServiceUnavailableErrorResponseStruct
+// @Failure 504 {object} types.GatewayTimeoutErrorResponseStruct
 // @Router /ai/autocomplete-question [post]
-func AutocompleteQuestion(c *gin.Context, db *gorm.DB, openAIClient *openai.Client) {
-	if openAIClient == nil {
-		log.Printf("OpenAI client is not initialized")
+func AutocompleteQuestion(c *gin.Context, db *gorm.DB, aiProvider ai.AIProvider) {
+	if aiProvider == nil {
+		log.Printf("AI provider is not configured")
 
 		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
 			StatusCode: http.StatusInternalServerError,
@@ -573,90 +448,20 @@ func AutocompleteQuestion(c *gin.Context, db *gorm.DB, openAIClient *openai.Clie
 		return
 	}
 
-	type Result struct {
-		SuggestedContent string `json:"suggested_content"`
-	}
-	var result Result
-	schema, err := jsonschema.GenerateSchemaForType(result)
-	if err != nil {
-		log.Printf("Error generating JSON schema: %v", err)
-
-		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
-			StatusCode: http.StatusInternalServerError,
-			Success:    false,
-			Message:    "An error occurred while preparing the AI request.",
-		})
-		return
-	}
-
-	response, err := openAIClient.CreateChatCompletion(c.Request.Context(), openai.ChatCompletionRequest{
-		Model: openai.GPT4oMini,
-		Messages: []openai.ChatCompletionMessage{
-			{
-				Role: openai.ChatMessageRoleUser,
-				Content: "Você é um gerador de enunciados de questões em pt-BR.\n" +
-					"Tarefa: dado category (categoria do quiz), quiz_title (nome do quiz) e o partial (texto inicial que o usuário digitou), produza 1 (uma) enunciado que continue exatamente o partial já inserido, sem remover nem alterar os caracteres existentes, e que termine com \"?\".\n" +
-					"- \"continue exatamente o texto em partial (sem remover/alterar o que já existe);\"\n" +
-					"- \"seja relevante à categoria e coerente com o quiz_title;\"\n" +
-					"- \"tenha 1 linha e no máximo 255 caracteres;\"\n" +
-					"- \"use português correto, com contrações apropriadas (de+o=do; de+a=da; de+os=dos; de+as=das) e acentuação;\"\n" +
-					"- \"varie formas interrogativas quando fizer sentido (qual/quanto/onde/quando/quem/como/por que);\"\n" +
-					"- \"não use reticências no final;\"\n" +
-					"- \"nos casos em que a questão seja gerada e não autocompletada, capitalize a primeira letra;\"\n" +
-					"Saída apenas em JSON conforme o schema fornecido.\n\n" +
-					"category: " + category.Name + "\n" +
-					"quiz_title: " + reqBody.QuizTitle + "\n" +
-					"partial: " + reqBody.Content + "\n" +
-					"limit: 255",
-			},
-		},
-		ResponseFormat: &openai.ChatCompletionResponseFormat{
-			Type: openai.ChatCompletionResponseFormatTypeJSONSchema,
-			JSONSchema: &openai.ChatCompletionResponseFormatJSONSchema{
-				Name:   "autocompletion-question-content",
-				Schema: schema,
-				Strict: true,
-			},
-		},
+	suggestion, err := aiProvider.AutocompleteQuestion(c.Request.Context(), ai.QuestionAutocompleteRequest{
+		Category:  category.Name,
+		QuizTitle: reqBody.QuizTitle,
+		Partial:   reqBody.Content,
 	})
 	if err != nil {
-		log.Printf("Error from OpenAI API: %v", err)
-
-		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
-			StatusCode: http.StatusInternalServerError,
-			Success:    false,
-			Message:    "An error occurred while communicating with the AI service.",
-		})
-		return
-	}
-
-	if len(response.Choices) == 0 {
-		log.Printf("No choices returned from OpenAI API")
-
-		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
-			StatusCode: http.StatusInternalServerError,
-			Success:    false,
-			Message:    "AI service did not return any suggestions.",
-		})
-		return
-	}
-
-	err = schema.Unmarshal(response.Choices[0].Message.Content, &result)
-	if err != nil {
-		log.Printf("Error unmarshaling AI response: %v", err)
-
-		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
-			StatusCode: http.StatusInternalServerError,
-			Success:    false,
-			Message:    "An error occurred while processing the AI response.",
-		})
+		respondAIError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, types.AutocompleteQuizSuccessResponseDTO{
 		StatusCode: http.StatusOK,
 		Success:    true,
-		Data:       result.SuggestedContent,
+		Data:       suggestion,
 	})
 }
 
@@ -669,12 +474,17 @@ func AutocompleteQuestion(c *gin.Context, db *gorm.DB, openAIClient *openai.Clie
 // @Param data body types.AutocompleteChoiceRequestDTO true "Autocomplete Choice Request Body"
 // @Success 200 {object} types.AutocompleteChoiceSuccessResponseDTO
 // @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 402 {object} types.PaymentRequiredErrorResponseStruct
 // @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 422 {object} types.UnprocessableEntityErrorResponseStruct
+// @Failure 429 {object} types.TooManyRequestsErrorResponseStruct
 // @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Failure 503 {object} types.ServiceUnavailableErrorResponseStruct
+// @Failure 504 {object} types.GatewayTimeoutErrorResponseStruct
 // @Router /ai/autocomplete-choice [post]
-func AutocompleteChoice(c *gin.Context, db *gorm.DB, openAIClient *openai.Client) {
-	if openAIClient == nil {
-		log.Printf("OpenAI client is not initialized")
+func AutocompleteChoice(c *gin.Context, db *gorm.DB, aiProvider ai.AIProvider) {
+	if aiProvider == nil {
+		log.Printf("AI provider is not configured")
 
 		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
 			StatusCode: http.StatusInternalServerError,
@@ -719,91 +529,309 @@ func AutocompleteChoice(c *gin.Context, db *gorm.DB, openAIClient *openai.Client
 		return
 	}
 
-	type Result struct {
-		SuggestedContent string `json:"suggested_content"`
+	suggestion, err := aiProvider.AutocompleteChoice(c.Request.Context(), ai.ChoiceAutocompleteRequest{
+		Category:        category.Name,
+		QuizTitle:       reqBody.QuizTitle,
+		QuestionContent: reqBody.QuestionContent,
+		IsCorrect:       *reqBody.IsCorrect,
+		Partial:         reqBody.Content,
+	})
+	if err != nil {
+		respondAIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, types.AutocompleteChoiceSuccessResponseDTO{
+		StatusCode: http.StatusOK,
+		Success:    true,
+		Data:       suggestion,
+	})
+}
+
+// quizGeneration is a validated quiz generation request along with the
+// category the generated draft belongs to.
+type quizGeneration struct {
+	request    ai.QuizRequest
+	categoryID *string
+}
+
+type sourceQuizGeneration struct {
+	text       string
+	request    ai.SourceQuizRequest
+	categoryID *string
+}
+
+// bindGenerateQuizRequest binds and validates the body of the quiz generation
+// endpoints, responding with the error when it is invalid.
+func bindGenerateQuizRequest(c *gin.Context, db *gorm.DB) (quizGeneration, bool) {
+	var reqBody types.GenerateQuizRequestDTO
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		log.Printf("Error parsing request body: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "An error occurred while parsing the request body.",
+		})
+		return quizGeneration{}, false
 	}
-	var result Result
-	schema, err := jsonschema.GenerateSchemaForType(result)
+
+	if reqBody.MinChoices != 0 && reqBody.MaxChoices != 0 && reqBody.MinChoices > reqBody.MaxChoices {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "The minimum number of choices cannot be greater than the maximum.",
+		})
+		return quizGeneration{}, false
+	}
+
+	category, err := gorm.G[schemas.Category](db).
+		Where("id = ?", reqBody.CategoryID).
+		First(c.Request.Context())
 	if err != nil {
-		log.Printf("Error generating JSON schema: %v", err)
+		log.Printf("Error fetching category: %v", err)
+
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, types.NotFoundErrorResponseStruct{
+				StatusCode: http.StatusNotFound,
+				Success:    false,
+				Message:    "Category not found.",
+			})
+			return quizGeneration{}, false
+		}
 
 		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
 			StatusCode: http.StatusInternalServerError,
 			Success:    false,
-			Message:    "An error occurred while preparing the AI request.",
+			Message:    "An error occurred while fetching the category.",
 		})
-		return
+		return quizGeneration{}, false
 	}
 
-	response, err := openAIClient.CreateChatCompletion(c.Request.Context(), openai.ChatCompletionRequest{
-		Model: openai.GPT4oMini,
-		Messages: []openai.ChatCompletionMessage{
-			{
-				Role: openai.ChatMessageRoleUser,
-				Content: "Você é um gerador de alternativas de resposta para questões de quiz em pt-BR.\n" +
-					"Tarefa: dado category (categoria do quiz), quiz_title (nome do quiz), question_content (enunciado da questão), is_correct (se a resposta deve ser a correta) e partial (texto inicial que o usuário digitou), produza 1 (uma) alternativa que continue exatamente o partial já inserido, sem remover nem alterar os caracteres existentes.\n" +
-					"- \"continue exatamente o texto em partial (sem remover/alterar o que já existe);\"\n" +
-					"- \"seja relevante à categoria, coerente com o quiz_title e uma possível resposta para question_content;\"\n" +
-					"- \"tenha 1 linha e no máximo 150 caracteres;\"\n" +
-					"- \"use português correto, com contrações apropriadas (de+o=do; de+a=da; de+os=dos; de+as=das) e acentuação;\"\n" +
-					"- \"caso is_correct seja true, gere uma resposta correta, caso contrário gere uma resposta convincente falsa;\"\n" +
-					"- \"não use reticências no final;\"\n" +
-					"- \"nos casos em que a resposta seja gerada e não autocompletada, capitalize a primeira letra;\"\n" +
-					"Saída apenas em JSON conforme o schema fornecido.\n\n" +
-					"category: " + category.Name + "\n" +
-					"quiz_title: " + reqBody.QuizTitle + "\n" +
-					"question_content: " + reqBody.QuestionContent + "\n" +
-					"is_correct: " + strconv.FormatBool(*reqBody.IsCorrect) + "\n" +
-					"partial: " + reqBody.Content + "\n" +
-					"limit: 150",
-			},
+	return quizGeneration{
+		request: ai.QuizRequest{
+			Category:      category.Name,
+			Topic:         strings.TrimSpace(reqBody.Topic),
+			QuestionCount: reqBody.QuestionCount,
+			Difficulty:    reqBody.Difficulty,
+			Language:      reqBody.Language,
+			MinChoices:    reqBody.MinChoices,
+			MaxChoices:    reqBody.MaxChoices,
 		},
-		ResponseFormat: &openai.ChatCompletionResponseFormat{
-			Type: openai.ChatCompletionResponseFormatTypeJSONSchema,
-			JSONSchema: &openai.ChatCompletionResponseFormatJSONSchema{
-				Name:   "autocompletion-choice-content",
-				Schema: schema,
-				Strict: true,
+		categoryID: &category.ID,
+	}, true
+}
+
+// bindSourceQuizRequest binds and validates the body of the endpoints that
+// generate quizzes from source material, extracting the text of the material.
+func bindSourceQuizRequest(c *gin.Context, db *gorm.DB) (sourceQuizGeneration, bool) {
+	// The body is cut off before it is bound, since a file over the limit
+	// would otherwise be read in full
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSourceBodySize)
+
+	var reqBody types.GenerateQuizFromTextRequestDTO
+	if err := c.ShouldBind(&reqBody); err != nil {
+		log.Printf("Error parsing request body: %v", err)
+
+		if isBodyTooLarge(err) {
+			c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+				StatusCode: http.StatusBadRequest,
+				Success:    false,
+				Message:    "The source material must be at most 10 MB.",
+			})
+			return sourceQuizGeneration{}, false
+		}
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "An error occurred while parsing the request body.",
+		})
+		return sourceQuizGeneration{}, false
+	}
+
+	if reqBody.MinChoices != 0 && reqBody.MaxChoices != 0 && reqBody.MinChoices > reqBody.MaxChoices {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "The minimum number of choices cannot be greater than the maximum.",
+		})
+		return sourceQuizGeneration{}, false
+	}
+
+	text := reqBody.Text
+	if fileHeader, err := c.FormFile("file"); err == nil {
+		if fileHeader.Size > maxSourceFileSize {
+			c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+				StatusCode: http.StatusBadRequest,
+				Success:    false,
+				Message:    "The source file must be at most 10 MB.",
+			})
+			return sourceQuizGeneration{}, false
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			log.Printf("Error opening uploaded file: %v", err)
+
+			c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+				StatusCode: http.StatusInternalServerError,
+				Success:    false,
+				Message:    "An error occurred while reading the source file.",
+			})
+			return sourceQuizGeneration{}, false
+		}
+		defer file.Close()
+
+		content, err := io.ReadAll(file)
+		if err != nil {
+			log.Printf("Error reading uploaded file: %v", err)
+
+			c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+				StatusCode: http.StatusInternalServerError,
+				Success:    false,
+				Message:    "An error occurred while reading the source file.",
+			})
+			return sourceQuizGeneration{}, false
+		}
+
+		text, err = ai.ExtractSourceText(fileHeader.Filename, content)
+		if err != nil {
+			log.Printf("Error extracting source text: %v", err)
+
+			message := "The text of the source file could not be extracted."
+			switch {
+			case errors.Is(err, ai.ErrUnsupportedSource):
+				message = "Invalid source file. Supported files are PDF, Markdown and plain text."
+			case errors.Is(err, ai.ErrEmptySource):
+				message = "The source file has no text."
+			}
+
+			c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+				StatusCode: http.StatusBadRequest,
+				Success:    false,
+				Message:    message,
+			})
+			return sourceQuizGeneration{}, false
+		}
+	}
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Source text or a source file is required.",
+		})
+		return sourceQuizGeneration{}, false
+	}
+	if utf8.RuneCountInString(text) > ai.MaxSourceLength {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "The source material must have at most 200000 characters.",
+		})
+		return sourceQuizGeneration{}, false
+	}
+
+	var categoryName string
+	var categoryID *string
+	if reqBody.CategoryID != "" {
+		category, err := gorm.G[schemas.Category](db).
+			Where("id = ?", reqBody.CategoryID).
+			First(c.Request.Context())
+		if err != nil {
+			log.Printf("Error fetching category: %v", err)
+
+			if err == gorm.ErrRecordNotFound {
+				c.JSON(http.StatusNotFound, types.NotFoundErrorResponseStruct{
+					StatusCode: http.StatusNotFound,
+					Success:    false,
+					Message:    "Category not found.",
+				})
+				return sourceQuizGeneration{}, false
+			}
+
+			c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+				StatusCode: http.StatusInternalServerError,
+				Success:    false,
+				Message:    "An error occurred while fetching the category.",
+			})
+			return sourceQuizGeneration{}, false
+		}
+		categoryName = category.Name
+		categoryID = &category.ID
+	}
+
+	return sourceQuizGeneration{
+		text: text,
+		request: ai.SourceQuizRequest{
+			QuizRequest: ai.QuizRequest{
+				Category:      categoryName,
+				Topic:         strings.TrimSpace(reqBody.Topic),
+				QuestionCount: reqBody.QuestionCount,
+				Difficulty:    reqBody.Difficulty,
+				Language:      reqBody.Language,
+				MinChoices:    reqBody.MinChoices,
+				MaxChoices:    reqBody.MaxChoices,
 			},
 		},
-	})
-	if err != nil {
-		log.Printf("Error from OpenAI API: %v", err)
+		categoryID: categoryID,
+	}, true
+}
 
-		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
-			StatusCode: http.StatusInternalServerError,
+// respondAIError maps the errors of the AI provider to the responses shared by
+// the AI endpoints.
+func respondAIError(c *gin.Context, err error) {
+	log.Printf("Error from AI provider: %v", err)
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		c.JSON(http.StatusGatewayTimeout, types.GatewayTimeoutErrorResponseStruct{
+			StatusCode: http.StatusGatewayTimeout,
 			Success:    false,
-			Message:    "An error occurred while communicating with the AI service.",
+			Message:    aiErrorMessage(err),
 		})
 		return
 	}
 
-	if len(response.Choices) == 0 {
-		log.Printf("No choices returned from OpenAI API")
-
-		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
-			StatusCode: http.StatusInternalServerError,
+	if errors.Is(err, moderation.ErrFlagged) {
+		c.JSON(http.StatusUnprocessableEntity, types.UnprocessableEntityErrorResponseStruct{
+			StatusCode: http.StatusUnprocessableEntity,
 			Success:    false,
-			Message:    "AI service did not return any suggestions.",
+			Message:    aiErrorMessage(err),
 		})
 		return
 	}
 
-	err = schema.Unmarshal(response.Choices[0].Message.Content, &result)
-	if err != nil {
-		log.Printf("Error unmarshaling AI response: %v", err)
-
-		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
-			StatusCode: http.StatusInternalServerError,
+	if errors.Is(err, ai.ErrProviderUnavailable) {
+		c.JSON(http.StatusServiceUnavailable, types.ServiceUnavailableErrorResponseStruct{
+			StatusCode: http.StatusServiceUnavailable,
 			Success:    false,
-			Message:    "An error occurred while processing the AI response.",
+			Message:    aiErrorMessage(err),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, types.AutocompleteChoiceSuccessResponseDTO{
-		StatusCode: http.StatusOK,
-		Success:    true,
-		Data:       result.SuggestedContent,
+	c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+		StatusCode: http.StatusInternalServerError,
+		Success:    false,
+		Message:    aiErrorMessage(err),
 	})
 }
+
+func aiErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return "AI service took too long to respond. Please try again."
+	case errors.Is(err, ai.ErrProviderUnavailable):
+		return "AI service is temporarily unavailable. Please try again later."
+	case errors.Is(err, moderation.ErrFlagged):
+		return "The AI generated content did not pass moderation. Please try again with a different request."
+	case errors.Is(err, ai.ErrEmptyResponse):
+		return "AI service did not return any suggestions."
+	case errors.Is(err, ai.ErrInvalidResponse):
+		return "An error occurred while processing the AI response."
+	}
+
+	return "An error occurred while communicating with the AI service."
+}
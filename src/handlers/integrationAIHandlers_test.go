@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"intelliquiz/src/ai"
+	"intelliquiz/src/database/schemas"
+	"intelliquiz/src/database/testdb"
+	"intelliquiz/src/moderation"
+	"intelliquiz/src/types"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// testDryRunDB returns a database that runs no queries, for the requests
+// that are answered without reading any records. Writes succeed without
+// effect.
+func testDryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open: %v", err)
+	}
+	return db
+}
+
+// testDatabase connects to the test database, see testdb.Open, and seeds a
+// category removed after the test.
+func testDatabase(t *testing.T) (*gorm.DB, schemas.Category) {
+	t.Helper()
+
+	db := testdb.Open(t)
+	category := schemas.Category{Name: "Geografia", Slug: "geografia-" + uuid.NewString()}
+	if err := db.Create(&category).Error; err != nil {
+		t.Fatalf("seeding the category: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Unscoped().Where("category_id = ?", category.ID).Delete(&schemas.QuizDraft{})
+		db.Unscoped().Delete(&category)
+	})
+
+	return db, category
+}
+
+// testAIRouter registers the AI endpoints like setupRouter does, as a user
+// already authenticated.
+func testAIRouter(t *testing.T, db *gorm.DB, aiProvider ai.AIProvider) *gin.Engine {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	aiRoutes := router.Group("/ai", func(c *gin.Context) { c.Set("userID", uuid.New().String()) })
+	aiRoutes.POST("/generate-quiz", func(c *gin.Context) { GenerateQuizAI(c, db, aiProvider) })
+	aiRoutes.POST("/generate-quiz-from-text", func(c *gin.Context) { GenerateQuizFromTextAI(c, db, aiProvider) })
+	aiRoutes.POST("/generate-quiz-from-text/stream", func(c *gin.Context) { StreamGenerateQuizFromTextAI(c, db, aiProvider) })
+	aiRoutes.POST("/autocomplete-quiz", func(c *gin.Context) { AutocompleteQuiz(c, db, aiProvider) })
+	aiRoutes.POST("/autocomplete-question", func(c *gin.Context) { AutocompleteQuestion(c, db, aiProvider) })
+	aiRoutes.POST("/autocomplete-choice", func(c *gin.Context) { AutocompleteChoice(c, db, aiProvider) })
+
+	return router
+}
+
+func testMockProvider(t *testing.T) ai.AIProvider {
+	t.Helper()
+
+	provider, err := ai.NewMockProvider("")
+	if err != nil {
+		t.Fatalf("NewMockProvider: %v", err)
+	}
+	return provider
+}
+
+func postJSON(router *gin.Engine, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestAIEndpointsInvalidRequests(t *testing.T) {
+	categoryID := uuid.NewString()
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		noProvider bool
+		want       int
+	}{
+		{"generate quiz without provider", "/ai/generate-quiz", `{}`, true, http.StatusInternalServerError},
+		{"autocomplete quiz without provider", "/ai/autocomplete-quiz", `{}`, true, http.StatusInternalServerError},
+		{"generate quiz from text without provider", "/ai/generate-quiz-from-text", `{"text": "Some text."}`, true, http.StatusInternalServerError},
+		{"generate quiz without category", "/ai/generate-quiz", `{}`, false, http.StatusBadRequest},
+		{"autocomplete quiz with invalid body", "/ai/autocomplete-quiz", `{`, false, http.StatusBadRequest},
+		{"autocomplete choice without correctness", "/ai/autocomplete-choice", fmt.Sprintf(`{"category_id": %q}`, categoryID), false, http.StatusBadRequest},
+		{"generate quiz from text without text", "/ai/generate-quiz-from-text", `{"text": "  "}`, false, http.StatusBadRequest},
+		{"generate quiz from text with too few questions", "/ai/generate-quiz-from-text", `{"text": "Some text.", "question_count": 1}`, false, http.StatusBadRequest},
+		{"generate quiz from text with inverted choice limits", "/ai/generate-quiz-from-text", `{"text": "Some text.", "min_choices": 5, "max_choices": 3}`, false, http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var provider ai.AIProvider
+			if !test.noProvider {
+				provider = testMockProvider(t)
+			}
+
+			w := postJSON(testAIRouter(t, testDryRunDB(t), provider), test.path, test.body)
+			if w.Code != test.want {
+				t.Errorf("got status %d, want %d: %s", w.Code, test.want, w.Body)
+			}
+		})
+	}
+}
+
+func TestGenerateQuizFromTextAI(t *testing.T) {
+	router := testAIRouter(t, testDryRunDB(t), testMockProvider(t))
+	body, _ := json.Marshal(types.GenerateQuizFromTextRequestDTO{
+		Text:          "The Nile is the longest river. It flows through eleven countries. Its delta is in Egypt.",
+		QuestionCount: 3,
+	})
+
+	w := postJSON(router, "/ai/generate-quiz-from-text", string(body))
+	if w.Code != http.StatusOK {
+		t.Fatalf("got status %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+
+	var res types.GenerateQuizFromTextSuccessResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		t.Fatalf("decoding the response: %v", err)
+	}
+	if res.Data.DraftID == "" {
+		t.Error("the generated quiz was not saved as a draft")
+	}
+	if len(res.Data.Questions) != 3 {
+		t.Fatalf("got %d questions, want 3", len(res.Data.Questions))
+	}
+	for i, question := range res.Data.Questions {
+		if question.SourceExcerpt == "" || !strings.Contains(string(body), question.SourceExcerpt) {
+			t.Errorf("question %d quotes %q, which is not in the text", i+1, question.SourceExcerpt)
+		}
+	}
+}
+
+func TestStreamGenerateQuizFromTextAI(t *testing.T) {
+	router := testAIRouter(t, testDryRunDB(t), testMockProvider(t))
+
+	w := postJSON(router, "/ai/generate-quiz-from-text/stream", `{"text": "The Nile is the longest river. It flows through eleven countries.", "question_count": 2}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("got status %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	if contentType := w.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/event-stream") {
+		t.Errorf("got content type %q, want an event stream", contentType)
+	}
+
+	var events []string
+	for line := range bytes.Lines(w.Body.Bytes()) {
+		if name, ok := bytes.CutPrefix(bytes.TrimSpace(line), []byte("event:")); ok {
+			events = append(events, string(name))
+		}
+	}
+	if want := []string{"title", "question", "question", "done"}; strings.Join(events, ",") != strings.Join(want, ",") {
+		t.Errorf("got events %v, want %v", events, want)
+	}
+}
+
+func TestGenerateQuizFromTextAIRejectsLargeFiles(t *testing.T) {
+	router := testAIRouter(t, testDryRunDB(t), testMockProvider(t))
+
+	w, read := postLargeFile(router, "/ai/generate-quiz-from-text", "source.txt", 4*maxSourceBodySize)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "at most 10 MB") {
+		t.Errorf("got status %d, want %d: %s", w.Code, http.StatusBadRequest, w.Body)
+	}
+	if read > maxSourceBodySize+64<<10 {
+		t.Errorf("read %d bytes of the body, want the limit of %d", read, maxSourceBodySize)
+	}
+}
+
+func TestRespondAIError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{context.DeadlineExceeded, http.StatusGatewayTimeout},
+		{fmt.Errorf("generating: %w", moderation.ErrFlagged), http.StatusUnprocessableEntity},
+		{ai.ErrProviderUnavailable, http.StatusServiceUnavailable},
+		{ai.ErrInvalidResponse, http.StatusInternalServerError},
+		{errors.New("connection reset"), http.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		respondAIError(c, test.err)
+		if w.Code != test.want {
+			t.Errorf("error %v: got status %d, want %d", test.err, w.Code, test.want)
+		}
+	}
+}
+
+func TestAIEndpointsWithDatabase(t *testing.T) {
+	db, category := testDatabase(t)
+	router := testAIRouter(t, db, testMockProvider(t))
+	unknownCategoryID := uuid.NewString()
+
+	tests := []struct {
+		name string
+		path string
+		body string
+		want int
+	}{
+		{"autocomplete quiz", "/ai/autocomplete-quiz", fmt.Sprintf(`{"category_id": %q, "content": "Capitais"}`, category.ID), http.StatusOK},
+		{"autocomplete question", "/ai/autocomplete-question", fmt.Sprintf(`{"quiz_title": "Capitais", "category_id": %q, "content": "Qual é a capital"}`, category.ID), http.StatusOK},
+		{"autocomplete choice", "/ai/autocomplete-choice", fmt.Sprintf(`{"quiz_title": "Capitais", "category_id": %q, "question_content": "Qual é a capital da França?", "is_correct": true, "content": "Par"}`, category.ID), http.StatusOK},
+		{"generate quiz", "/ai/generate-quiz", fmt.Sprintf(`{"category_id": %q, "question_count": 3}`, category.ID), http.StatusOK},
+		{"generate quiz from text in a category", "/ai/generate-quiz-from-text", fmt.Sprintf(`{"text": "Paris is the capital of France.", "category_id": %q}`, category.ID), http.StatusOK},
+		{"autocomplete quiz with unknown category", "/ai/autocomplete-quiz", fmt.Sprintf(`{"category_id": %q}`, unknownCategoryID), http.StatusNotFound},
+		{"generate quiz with unknown category", "/ai/generate-quiz", fmt.Sprintf(`{"category_id": %q}`, unknownCategoryID), http.StatusNotFound},
+		{"generate quiz from text with unknown category", "/ai/generate-quiz-from-text", fmt.Sprintf(`{"text": "Some text.", "category_id": %q}`, unknownCategoryID), http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := postJSON(router, test.path, test.body)
+			if w.Code != test.want {
+				t.Errorf("got status %d, want %d: %s", w.Code, test.want, w.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"intelliquiz/src/ai"
+	"intelliquiz/src/database/schemas"
+	"intelliquiz/src/types"
+	"log"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReviewQuizAI godoc
+// @Summary Review a quiz with AI
+// @Schemes
+// @Description Review the questions and choices of an own quiz, pointing out ambiguous wording, factual doubts, questions with more than one plausible answer, obviously wrong distractors and spelling issues. Suggested rewrites can be applied with the question and choice update endpoints.
+// @Tags ai
+// @Produce json
+// @Param quizId path string true "Quiz ID"
+// @Success 200 {object} types.ReviewQuizSuccessResponseDTO
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 402 {object} types.PaymentRequiredErrorResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 404 {object} types.NotFoundErrorResponseStruct
+// @Failure 422 {object} types.UnprocessableEntityErrorResponseStruct
+// @Failure 429 {object} types.TooManyRequestsErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Failure 503 {object} types.ServiceUnavailableErrorResponseStruct
+// @Failure 504 {object} types.GatewayTimeoutErrorResponseStruct
+// @Router /ai/review-quiz/{quizId} [post]
+func ReviewQuizAI(c *gin.Context, db *gorm.DB, aiProvider ai.AIProvider) {
+	if aiProvider == nil {
+		log.Printf("AI provider is not configured")
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "AI service is not configured properly. Please verify the server environment settings.",
+		})
+		return
+	}
+
+	userUuid, err := uuid.Parse(c.MustGet("userID").(string))
+	if err != nil {
+		log.Printf("Error parsing UUID from token: %v", err)
+
+		c.JSON(http.StatusForbidden, types.ForbiddenErrorResponseStruct{
+			StatusCode: http.StatusForbidden,
+			Success:    false,
+			Message:    "Invalid user ID format on claims.",
+		})
+		return
+	}
+
+	quizUuid, err := uuid.Parse(c.Param("quizId"))
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Invalid quiz ID format.",
+		})
+		return
+	}
+
+	quiz, err := gorm.G[schemas.Quiz](db).Where("id = ?", quizUuid).
+		Select("id, name, category_id, created_by").
+		Preload("Category", func(db gorm.PreloadBuilder) error {
+			db.Select("id, name")
+			return nil
+		}).
+		Preload("Questions", func(db gorm.PreloadBuilder) error {
+			db.Select("id, content, type, quiz_id, numeric_answer, numeric_tolerance").
+				Order("created_at ASC")
+			return nil
+		}).
+		Preload("Questions.Choices", func(db gorm.PreloadBuilder) error {
+			db.Select("id, content, is_correct, position, question_id").
+				Order("position ASC, created_at ASC")
+			return nil
+		}).
+		First(c.Request.Context())
+	if err != nil {
+		log.Printf("Error fetching quiz by ID: %v", err)
+
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, types.NotFoundErrorResponseStruct{
+				StatusCode: http.StatusNotFound,
+				Success:    false,
+				Message:    "Quiz not found.",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while fetching the quiz.",
+		})
+		return
+	}
+
+	if quiz.CreatedBy != userUuid.String() {
+		c.JSON(http.StatusForbidden, types.ForbiddenErrorResponseStruct{
+			StatusCode: http.StatusForbidden,
+			Success:    false,
+			Message:    "You do not have permission to review this quiz.",
+		})
+		return
+	}
+
+	req := ai.QuizReviewRequest{
+		QuizTitle: quiz.Name,
+		Questions: make([]ai.AuthoredQuestion, len(quiz.Questions)),
+	}
+	if quiz.Category != nil {
+		req.Category = quiz.Category.Name
+	}
+	for i, question := range quiz.Questions {
+		req.Questions[i] = authoredQuestion(question)
+	}
+
+	review, err := aiProvider.ReviewQuiz(c.Request.Context(), req)
+	if err != nil {
+		respondAIError(c, err)
+		return
+	}
+
+	data := types.QuizReviewDataDTO{
+		QuizID:    quiz.ID,
+		Summary:   review.Summary,
+		Questions: make([]types.QuestionReviewDTO, len(quiz.Questions)),
+	}
+	for i, question := range quiz.Questions {
+		data.Questions[i] = types.QuestionReviewDTO{
+			QuestionID:      question.ID,
+			QuestionContent: question.Content,
+			Issues:          reviewIssueDTOs(question, review.Questions),
+		}
+		data.IssueCount += len(data.Questions[i].Issues)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"statusCode": http.StatusOK,
+		"success":    true,
+		"data":       data,
+	})
+}
+
+// reviewIssueDTOs returns the issues the review found in the question. Issues
+// pointing to choices of other questions, or to choices that do not exist, are
+// dropped so every suggestion can be applied where it says.
+func reviewIssueDTOs(question schemas.Question, reviews []ai.QuestionReview) []types.ReviewIssueDTO {
+	issues := []types.ReviewIssueDTO{}
+	for _, review := range reviews {
+		if review.QuestionID != question.ID {
+			continue
+		}
+
+		for _, issue := range review.Issues {
+			if issue.ChoiceID != "" && !slices.ContainsFunc(question.Choices, func(choice schemas.Choice) bool { return choice.ID == issue.ChoiceID }) {
+				continue
+			}
+
+			issues = append(issues, types.ReviewIssueDTO{
+				Kind:             issue.Kind,
+				ChoiceID:         issue.ChoiceID,
+				Description:      issue.Description,
+				SuggestedContent: issue.SuggestedContent,
+			})
+		}
+	}
+
+	return issues
+}
+
+// authoredQuestion converts a question loaded with its choices for the AI
+// provider, which expects the choices of ordering questions in the correct
+// order.
+func authoredQuestion(question schemas.Question) ai.AuthoredQuestion {
+	authored := ai.AuthoredQuestion{
+		ID:               question.ID,
+		Type:             question.Type,
+		Content:          question.Content,
+		Choices:          make([]ai.AuthoredChoice, len(question.Choices)),
+		NumericAnswer:    question.NumericAnswer,
+		NumericTolerance: question.NumericTolerance,
+	}
+	for i, choice := range question.Choices {
+		authored.Choices[i] = ai.AuthoredChoice{
+			ID:        choice.ID,
+			Content:   choice.Content,
+			IsCorrect: choice.IsCorrect != nil && *choice.IsCorrect,
+		}
+	}
+
+	return authored
+}
@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"intelliquiz/src/ai"
+	"intelliquiz/src/database/schemas"
+	"intelliquiz/src/types"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Streaming endpoints answer with Server-Sent Events: a title event, then a
+// question event for every question as soon as it is generated, and finally a
+// done event with the ID of the saved draft, or an error event if the
+// generation fails midway. Generation stops as soon as the client disconnects.
+
+// StreamGenerateQuizAI godoc
+// @Summary Stream Full Quiz Generation
+// @Schemes
+// @Description Generate a complete quiz like /ai/generate-quiz, streaming it as Server-Sent Events: "title" (QuizStreamTitleEventDTO), one "question" per question (QuizStreamQuestionEventDTO), then "done" (QuizStreamDoneEventDTO) or "error" (QuizStreamErrorEventDTO)
+// @Tags ai
+// @Accept json
+// @Produce text/event-stream
+// @Param data body types.GenerateQuizRequestDTO true "Generate Quiz Request Body"
+// @Success 200 {object} types.QuizStreamQuestionEventDTO
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 402 {object} types.PaymentRequiredErrorResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 404 {object} types.NotFoundErrorResponseStruct
+// @Failure 429 {object} types.TooManyRequestsErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /ai/generate-quiz/stream [post]
+func StreamGenerateQuizAI(c *gin.Context, db *gorm.DB, aiProvider ai.AIProvider) {
+	if aiProvider == nil {
+		log.Printf("AI provider is not configured")
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "AI service is not configured properly. Please verify the server environment settings.",
+		})
+		return
+	}
+
+	generation, ok := bindGenerateQuizRequest(c, db)
+	if !ok {
+		return
+	}
+
+	startEventStream(c)
+
+	draft := schemas.QuizDraft{
+		Source:     schemas.QuizDraftSourceQuizAI,
+		CategoryID: generation.categoryID,
+	}
+	err := aiProvider.StreamQuiz(c.Request.Context(), generation.request, ai.QuizStream[ai.GeneratedQuestion]{
+		OnTitle: func(title string) error {
+			draft.Name = title
+			return sendEvent(c, "title", types.QuizStreamTitleEventDTO{QuizTitle: title})
+		},
+		OnQuestion: func(question ai.GeneratedQuestion) error {
+			event := types.QuizStreamQuestionEventDTO{
+				Index:           len(draft.Questions),
+				QuestionContent: question.QuestionContent,
+				Choices:         generatedChoiceDTOs(question.Choices),
+			}
+			draft.Questions = append(draft.Questions, generatedDraftQuestion(question, ""))
+			return sendEvent(c, "question", event)
+		},
+	})
+
+	finishEventStream(c, db, draft, err)
+}
+
+// StreamGenerateQuizFromTextAI godoc
+// @Summary Stream Quiz Generation from Source Material
+// @Schemes
+// @Description Generate a quiz from source material like /ai/generate-quiz-from-text, streaming it as Server-Sent Events: "title" (QuizStreamTitleEventDTO), one "question" per question with its source excerpt (QuizStreamQuestionEventDTO), then "done" (QuizStreamDoneEventDTO) or "error" (QuizStreamErrorEventDTO)
+// @Tags ai
+// @Accept multipart/form-data
+// @Produce text/event-stream
+// @Param file formData file false "PDF, Markdown or plain text file"
+// @Param text formData string false "Source text, used when no file is uploaded"
+// @Param category_id formData string false "Category ID"
+// @Param topic formData string false "Topic"
+// @Param question_count formData int false "Number of questions (min: 2, max: 50)" default(5)
+// @Param difficulty formData string false "Difficulty" Enums(easy, medium, hard, mixed) default(mixed)
+// @Param language formData string false "Language" default(pt-BR)
+// @Param min_choices formData int false "Minimum number of choices per question (min: 2, max: 6)" default(2)
+// @Param max_choices formData int false "Maximum number of choices per question (min: 2, max: 6)" default(6)
+// @Success 200 {object} types.QuizStreamQuestionEventDTO
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 402 {object} types.PaymentRequiredErrorResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 404 {object} types.NotFoundErrorResponseStruct
+// @Failure 429 {object} types.TooManyRequestsErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /ai/generate-quiz-from-text/stream [post]
+func StreamGenerateQuizFromTextAI(c *gin.Context, db *gorm.DB, aiProvider ai.AIProvider) {
+	if aiProvider == nil {
+		log.Printf("AI provider is not configured")
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "AI service is not configured properly. Please verify the server environment settings.",
+		})
+		return
+	}
+
+	generation, ok := bindSourceQuizRequest(c, db)
+	if !ok {
+		return
+	}
+
+	startEventStream(c)
+
+	draft := schemas.QuizDraft{
+		Source:     schemas.QuizDraftSourceTextAI,
+		CategoryID: generation.categoryID,
+	}
+	err := ai.StreamQuizFromSource(c.Request.Context(), aiProvider, generation.text, generation.request, ai.QuizStream[ai.GeneratedSourceQuestion]{
+		OnTitle: func(title string) error {
+			draft.Name = title
+			return sendEvent(c, "title", types.QuizStreamTitleEventDTO{QuizTitle: title})
+		},
+		OnQuestion: func(question ai.GeneratedSourceQuestion) error {
+			event := types.QuizStreamQuestionEventDTO{
+				Index:           len(draft.Questions),
+				QuestionContent: question.QuestionContent,
+				Choices:         generatedChoiceDTOs(question.Choices),
+				SourceExcerpt:   question.SourceExcerpt,
+			}
+			draft.Questions = append(draft.Questions, generatedDraftQuestion(ai.GeneratedQuestion{
+				QuestionContent: question.QuestionContent,
+				Choices:         question.Choices,
+			}, question.SourceExcerpt))
+			return sendEvent(c, "question", event)
+		},
+	})
+
+	finishEventStream(c, db, draft, err)
+}
+
+func startEventStream(c *gin.Context) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// Keeps reverse proxies such as nginx from buffering the events
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+}
+
+// sendEvent writes the event and flushes it to the client, failing with the
+// context error once the client is gone so the generation stops.
+func sendEvent(c *gin.Context, name string, data any) error {
+	if err := c.Request.Context().Err(); err != nil {
+		return err
+	}
+
+	c.SSEvent(name, data)
+	c.Writer.Flush()
+
+	return nil
+}
+
+// finishEventStream sends the final event. Completed generations are saved as
+// a draft whose ID is sent along; canceled or failed ones are not saved.
+func finishEventStream(c *gin.Context, db *gorm.DB, draft schemas.QuizDraft, err error) {
+	if c.Request.Context().Err() != nil {
+		log.Printf("AI generation stream canceled by the client after %d questions", len(draft.Questions))
+		return
+	}
+
+	if err != nil {
+		log.Printf("Error from AI provider: %v", err)
+
+		sendEvent(c, "error", types.QuizStreamErrorEventDTO{Message: aiErrorMessage(err)})
+		return
+	}
+
+	sendEvent(c, "done", types.QuizStreamDoneEventDTO{
+		DraftID:       saveGeneratedDraft(c, db, draft),
+		QuestionCount: len(draft.Questions),
+	})
+}
+
+func generatedChoiceDTOs(choices []ai.GeneratedChoice) []types.GeneratedChoiceDTO {
+	dtos := make([]types.GeneratedChoiceDTO, len(choices))
+	for i, choice := range choices {
+		dtos[i] = types.GeneratedChoiceDTO{
+			Content:   choice.Content,
+			IsCorrect: choice.IsCorrect,
+		}
+	}
+
+	return dtos
+}
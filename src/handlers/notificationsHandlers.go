@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"intelliquiz/src/database/schemas"
+	"intelliquiz/src/types"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GetOwnNotifications godoc
+// @Summary Get own notifications
+// @Schemes
+// @Description Get the notifications of the authenticated user, newest first, such as the outcome of their reports or moderation decisions on their quizzes
+// @Tags notifications
+// @Produce json
+// @Param unread query bool false "Only return unread notifications" default(false)
+// @Param page query int false "Page number (starting from 0)" default(0)
+// @Param limit query int false "Limit of notifications per page (min: 5, max: 50)" default(10)
+// @Success 200 {object} types.GetNotificationsSuccessResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /me/notifications [get]
+func GetOwnNotifications(c *gin.Context, db *gorm.DB) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "0"))
+	unreadOnly, _ := strconv.ParseBool(c.DefaultQuery("unread", "false"))
+
+	limit = max(5, min(50, limit))
+	page = max(0, page)
+
+	userUuid, err := uuid.Parse(c.MustGet("userID").(string))
+	if err != nil {
+		c.JSON(http.StatusForbidden, types.ForbiddenErrorResponseStruct{
+			StatusCode: http.StatusForbidden,
+			Success:    false,
+			Message:    "Invalid user ID format on claims.",
+		})
+		return
+	}
+
+	unreadCount, err := gorm.G[schemas.Notification](db).
+		Where("user_id = ? AND read_at IS NULL", userUuid.String()).
+		Count(c.Request.Context(), "id")
+	if err != nil {
+		log.Printf("Error counting unread notifications: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while fetching notifications count.",
+		})
+		return
+	}
+
+	query := gorm.G[schemas.Notification](db).Where("user_id = ?", userUuid.String())
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	notificationsCount, err := query.Count(c.Request.Context(), "id")
+	if err != nil {
+		log.Printf("Error counting notifications: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while fetching notifications count.",
+		})
+		return
+	}
+
+	notifications, err := query.
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(page * limit).
+		Find(c.Request.Context())
+	if err != nil {
+		log.Printf("Error fetching notifications: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while fetching notifications.",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"statusCode": http.StatusOK,
+		"success":    true,
+		"data": gin.H{
+			"notifications": notifications,
+			"unreadCount":   unreadCount,
+			"maxPage":       math.Ceil(float64(notificationsCount)/float64(limit)) - 1,
+		},
+	})
+}
+
+// ReadNotification godoc
+// @Summary Mark a notification as read
+// @Schemes
+// @Description Mark one of the notifications of the authenticated user as read
+// @Tags notifications
+// @Produce json
+// @Param notificationId path string true "Notification ID"
+// @Success 200 {object} types.SuccessResponseStruct
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 404 {object} types.NotFoundErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /me/notifications/{notificationId}/read [post]
+func ReadNotification(c *gin.Context, db *gorm.DB) {
+	notificationUuid, err := uuid.Parse(c.Param("notificationId"))
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Invalid notification ID format.",
+		})
+		return
+	}
+
+	userUuid, err := uuid.Parse(c.MustGet("userID").(string))
+	if err != nil {
+		c.JSON(http.StatusForbidden, types.ForbiddenErrorResponseStruct{
+			StatusCode: http.StatusForbidden,
+			Success:    false,
+			Message:    "Invalid user ID format on claims.",
+		})
+		return
+	}
+
+	notification, err := gorm.G[schemas.Notification](db).
+		Where("id = ? AND user_id = ?", notificationUuid, userUuid.String()).
+		First(c.Request.Context())
+	if err != nil {
+		log.Printf("Error fetching notification by ID: %v", err)
+
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, types.NotFoundErrorResponseStruct{
+				StatusCode: http.StatusNotFound,
+				Success:    false,
+				Message:    "Notification not found.",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while fetching the notification.",
+		})
+		return
+	}
+
+	if notification.ReadAt == nil {
+		_, err := gorm.G[schemas.Notification](db).
+			Where("id = ?", notification.ID).
+			Update(c.Request.Context(), "read_at", time.Now())
+		if err != nil {
+			log.Printf("Error marking notification as read: %v", err)
+
+			c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+				StatusCode: http.StatusInternalServerError,
+				Success:    false,
+				Message:    "An error occurred while updating the notification.",
+			})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"statusCode": http.StatusOK,
+		"success":    true,
+		"message":    "Notification marked as read.",
+	})
+}
+
+// ReadAllNotifications godoc
+// @Summary Mark all notifications as read
+// @Schemes
+// @Description Mark every unread notification of the authenticated user as read
+// @Tags notifications
+// @Produce json
+// @Success 200 {object} types.SuccessResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /me/notifications/read [post]
+func ReadAllNotifications(c *gin.Context, db *gorm.DB) {
+	userUuid, err := uuid.Parse(c.MustGet("userID").(string))
+	if err != nil {
+		c.JSON(http.StatusForbidden, types.ForbiddenErrorResponseStruct{
+			StatusCode: http.StatusForbidden,
+			Success:    false,
+			Message:    "Invalid user ID format on claims.",
+		})
+		return
+	}
+
+	_, err = gorm.G[schemas.Notification](db).
+		Where("user_id = ? AND read_at IS NULL", userUuid.String()).
+		Update(c.Request.Context(), "read_at", time.Now())
+	if err != nil {
+		log.Printf("Error marking notifications as read: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while updating the notifications.",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"statusCode": http.StatusOK,
+		"success":    true,
+		"message":    "Notifications marked as read.",
+	})
+}
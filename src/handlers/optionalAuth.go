@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"intelliquiz/src/auth"
+	"intelliquiz/src/middlewares"
+	"intelliquiz/src/types"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// optionalUserID reads the user of the access token on public routes, which
+// show more to logged in users. Without a token, or with an expired or
+// malformed one, the user ID is empty and the route is served anonymously. A
+// revoked session writes an error response and returns false. The role of the
+// user is set on the context, as the token middleware does.
+func optionalUserID(c *gin.Context, db *gorm.DB) (string, bool) {
+	tokenStr := middlewares.BearerFromHeader(c)
+	if tokenStr == "" {
+		return "", true
+	}
+
+	claims, err := auth.ParseAccess(tokenStr)
+	if err != nil {
+		log.Printf("Ignoring invalid access token on a public route: %v", err)
+		return "", true
+	}
+
+	active, err := auth.IsSessionActive(c.Request.Context(), db, claims.SessionID)
+	if err != nil {
+		log.Printf("Error verifying session: %v", err)
+
+		c.AbortWithStatusJSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while verifying the session.",
+		})
+		return "", false
+	}
+	if !active {
+		c.AbortWithStatusJSON(http.StatusForbidden, types.ForbiddenErrorResponseStruct{
+			StatusCode: http.StatusForbidden,
+			Success:    false,
+			Message:    "Session has been revoked.",
+		})
+		return "", false
+	}
+
+	c.Set("role", claims.Role)
+
+	return claims.Subject, true
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"intelliquiz/src/types"
+	"intelliquiz/src/utils"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// parsePagination reads the page of a list sorted by the keys, writing the
+// error response when the cursor is invalid.
+func parsePagination(c *gin.Context, sort string, keys []utils.SortKey) (utils.Pagination, func(*gorm.Statement), bool) {
+	pagination, err := utils.ParsePagination(c)
+	if err != nil {
+		log.Printf("Error parsing pagination: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Invalid cursor.",
+		})
+		return pagination, nil, false
+	}
+
+	paginate, err := pagination.Paginate(sort, keys)
+	if err != nil {
+		log.Printf("Error parsing pagination: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Invalid cursor.",
+		})
+		return pagination, nil, false
+	}
+
+	return pagination, paginate, true
+}
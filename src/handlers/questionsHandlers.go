@@ -2,9 +2,12 @@ package handlers
 
 import (
 	"intelliquiz/src/database/schemas"
+	"intelliquiz/src/moderation"
 	"intelliquiz/src/types"
+	"intelliquiz/src/utils"
 	"log"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	uuidG "github.com/google/uuid"
@@ -22,7 +25,7 @@ import (
 // @Router /questions [get]
 func GetQuestions(c *gin.Context, db *gorm.DB) {
 	questions, err := gorm.G[schemas.Question](db).
-		Select("id, content, quiz_id").
+		Select("id, content, type, quiz_id").
 		Find(c.Request.Context())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
@@ -43,7 +46,7 @@ func GetQuestions(c *gin.Context, db *gorm.DB) {
 // CreateQuestion godoc
 // @Summary Create a new question
 // @Schemes
-// @Description Create a new question
+// @Description Create a new question. Content flagged by moderation keeps the quiz out of the public listings until reviewed.
 // @Tags questions
 // @Produce json
 // @Param data body types.CreateQuestionRequestBody true "Create Question Request Body"
@@ -51,7 +54,7 @@ func GetQuestions(c *gin.Context, db *gorm.DB) {
 // @Failure 400 {object} types.BadRequestErrorResponseStruct
 // @Failure 500 {object} types.InternalServerErrorResponseStruct
 // @Router /questions [post]
-func CreateQuestion(c *gin.Context, db *gorm.DB) {
+func CreateQuestion(c *gin.Context, db *gorm.DB, moderator moderation.Moderator) {
 	var reqBody types.CreateQuestionRequestBody
 	if err := c.ShouldBindJSON(&reqBody); err != nil {
 		log.Printf("Error parsing request body: %v", err)
@@ -76,49 +79,41 @@ func CreateQuestion(c *gin.Context, db *gorm.DB) {
 		return
 	}
 
-	alreadyCorrect := false
-	var choices []schemas.Choice
+	questionInput := types.CreateQuizQuestionsStruct{
+		Content:          reqBody.Content,
+		Type:             reqBody.Type,
+		AcceptedAnswers:  reqBody.AcceptedAnswers,
+		NumericAnswer:    reqBody.NumericAnswer,
+		NumericTolerance: reqBody.NumericTolerance,
+		Explanation:      reqBody.Explanation,
+	}
 	for _, choiceDTO := range reqBody.Choices {
-		if alreadyCorrect && *choiceDTO.IsCorrect {
-			log.Printf("Multiple correct choices provided")
-
-			c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
-				StatusCode: http.StatusBadRequest,
-				Success:    false,
-				Message:    "A question can have only one correct choice.",
-			})
-			return
-		}
-		if *choiceDTO.IsCorrect {
-			alreadyCorrect = true
-		}
-
-		choice := schemas.Choice{
+		questionInput.Choices = append(questionInput.Choices, types.CreateQuizQuestionChoiceStruct{
 			Content:   choiceDTO.Content,
 			IsCorrect: choiceDTO.IsCorrect,
-		}
-		choices = append(choices, choice)
+		})
 	}
 
-	// Ensure at least two choices are provided, even though binding should handle it
-	if len(choices) < 2 {
-		log.Printf("Not enough choices provided")
+	question, err := utils.BuildQuestion(questionInput)
+	if err != nil {
+		log.Printf("Invalid question: %v", err)
 
 		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
 			StatusCode: http.StatusBadRequest,
 			Success:    false,
-			Message:    "A question must have at least two choices.",
+			Message:    err.Error(),
 		})
 		return
 	}
+	question.QuizID = quizUuid.String()
 
-	question := schemas.Question{
-		Content: reqBody.Content,
-		QuizID:  quizUuid.String(),
-		Choices: choices,
-	}
-
-	if err := gorm.G[schemas.Question](db).Create(c, &question); err != nil {
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := gorm.G[schemas.Question](tx).Create(c, &question); err != nil {
+			return err
+		}
+		return utils.IncrementQuizStat(tx, question.QuizID, utils.QuizStatQuestions, 1)
+	})
+	if err != nil {
 		log.Printf("Error creating question: %v", err)
 
 		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
@@ -128,6 +123,7 @@ func CreateQuestion(c *gin.Context, db *gorm.DB) {
 		})
 		return
 	}
+	moderateQuizContent(c, db, moderator, question.QuizID, utils.QuestionTexts(question)...)
 
 	question.Quiz = nil
 	question.CreatedAt = nil
@@ -168,7 +164,7 @@ func GetQuestionByID(c *gin.Context, db *gorm.DB) {
 	}
 
 	question, err := gorm.G[schemas.Question](db).Where("id = ?", uuid).
-		Select("id, content, quiz_id").
+		Select("id, content, type, quiz_id").
 		First(c)
 	if err != nil {
 		log.Printf("Error fetching question by ID: %v", err)
@@ -200,7 +196,7 @@ func GetQuestionByID(c *gin.Context, db *gorm.DB) {
 // UpdateQuestion godoc
 // @Summary Update a question by ID
 // @Schemes
-// @Description Update a question by its ID
+// @Description Update a question by its ID. Content flagged by moderation keeps the quiz out of the public listings until reviewed.
 // @Tags questions
 // @Produce json
 // @Param id path string true "Question ID"
@@ -210,7 +206,7 @@ func GetQuestionByID(c *gin.Context, db *gorm.DB) {
 // @Failure 404 {object} types.NotFoundErrorResponseStruct
 // @Failure 500 {object} types.InternalServerErrorResponseStruct
 // @Router /questions/{questionId} [patch]
-func UpdateQuestion(c *gin.Context, db *gorm.DB) {
+func UpdateQuestion(c *gin.Context, db *gorm.DB, moderator moderation.Moderator) {
 	questionId := c.Param("questionId")
 
 	uuid, err := uuidG.Parse(questionId)
@@ -263,6 +259,9 @@ func UpdateQuestion(c *gin.Context, db *gorm.DB) {
 	if reqBody.Content != "" {
 		question.Content = reqBody.Content
 	}
+	if reqBody.Explanation != nil {
+		question.Explanation = strings.TrimSpace(*reqBody.Explanation)
+	}
 
 	if err := db.Save(&question).Error; err != nil {
 		log.Printf("Error updating question: %v", err)
@@ -274,6 +273,7 @@ func UpdateQuestion(c *gin.Context, db *gorm.DB) {
 		})
 		return
 	}
+	moderateQuizContent(c, db, moderator, question.QuizID, question.Content, question.Explanation)
 
 	question.Quiz = nil
 	question.CreatedAt = nil
@@ -313,9 +313,22 @@ func DeleteQuestion(c *gin.Context, db *gorm.DB) {
 		return
 	}
 
-	r, err := gorm.G[schemas.Question](db).
-		Where("id = ?", uuid).
-		Delete(c)
+	var r int
+	err = db.Transaction(func(tx *gorm.DB) error {
+		question, err := gorm.G[schemas.Question](tx).Select("id, quiz_id").Where("id = ?", uuid).First(c)
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		r, err = gorm.G[schemas.Question](tx).Where("id = ?", question.ID).Delete(c)
+		if err != nil || r == 0 {
+			return err
+		}
+		return utils.IncrementQuizStat(tx, question.QuizID, utils.QuizStatQuestions, -1)
+	})
 	if err != nil {
 		log.Printf("Error deleting question: %v", err)
 
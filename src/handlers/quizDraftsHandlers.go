@@ -0,0 +1,550 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"intelliquiz/src/ai"
+	"intelliquiz/src/database/schemas"
+	"intelliquiz/src/moderation"
+	"intelliquiz/src/types"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var errDraftPublished = errors.New("draft already published or discarded")
+
+// GetDrafts godoc
+// @Summary Get own quiz drafts
+// @Schemes
+// @Description Get the quiz drafts of the authenticated user, most recently updated first
+// @Tags drafts
+// @Produce json
+// @Param page query int false "Page number (starting from 0)" default(0)
+// @Param limit query int false "Limit of drafts per page (min: 5, max: 50)" default(10)
+// @Success 200 {object} types.GetDraftsSuccessResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /drafts [get]
+func GetDrafts(c *gin.Context, db *gorm.DB) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "0"))
+
+	limit = max(5, min(50, limit))
+	page = max(0, page)
+
+	userUuid, err := uuid.Parse(c.MustGet("userID").(string))
+	if err != nil {
+		c.JSON(http.StatusForbidden, types.ForbiddenErrorResponseStruct{
+			StatusCode: http.StatusForbidden,
+			Success:    false,
+			Message:    "Invalid user ID format on claims.",
+		})
+		return
+	}
+
+	draftsCount, err := gorm.G[schemas.QuizDraft](db).
+		Where("created_by = ?", userUuid.String()).
+		Count(c.Request.Context(), "id")
+	if err != nil {
+		log.Printf("Error counting drafts: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while fetching drafts count.",
+		})
+		return
+	}
+
+	drafts, err := gorm.G[schemas.QuizDraft](db).
+		Where("created_by = ?", userUuid.String()).
+		Order("updated_at DESC").
+		Limit(limit).
+		Offset(page * limit).
+		Find(c.Request.Context())
+	if err != nil {
+		log.Printf("Error fetching drafts: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while fetching drafts.",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"statusCode": http.StatusOK,
+		"success":    true,
+		"data": gin.H{
+			"drafts":  drafts,
+			"maxPage": math.Ceil(float64(draftsCount)/float64(limit)) - 1,
+		},
+	})
+}
+
+// GetDraftByID godoc
+// @Summary Get a quiz draft by ID
+// @Schemes
+// @Description Get one of the quiz drafts of the authenticated user
+// @Tags drafts
+// @Produce json
+// @Param draftId path string true "Draft ID"
+// @Success 200 {object} types.GetDraftSuccessResponseStruct
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 404 {object} types.NotFoundErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /drafts/{draftId} [get]
+func GetDraftByID(c *gin.Context, db *gorm.DB) {
+	draft, ok := findOwnDraft(c, db, c.Param("draftId"), "view")
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"statusCode": http.StatusOK,
+		"success":    true,
+		"data":       draft,
+	})
+}
+
+// UpdateDraft godoc
+// @Summary Update a quiz draft
+// @Schemes
+// @Description Replace the content of a quiz draft. Drafts can be left incomplete, they are only fully validated when published
+// @Tags drafts
+// @Accept json
+// @Produce json
+// @Param draftId path string true "Draft ID"
+// @Param data body types.UpdateDraftRequestBody true "Update Draft Request Body"
+// @Success 200 {object} types.GetDraftSuccessResponseStruct
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 404 {object} types.NotFoundErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /drafts/{draftId} [put]
+func UpdateDraft(c *gin.Context, db *gorm.DB) {
+	var reqBody types.UpdateDraftRequestBody
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		log.Printf("Error parsing request body: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "An error occurred while parsing the request body.",
+		})
+		return
+	}
+
+	draft, ok := findOwnDraft(c, db, c.Param("draftId"), "update")
+	if !ok {
+		return
+	}
+
+	draft, err := updateDraft(c.Request.Context(), db, draft.ID, func(draft *schemas.QuizDraft) {
+		draft.Name = reqBody.Name
+		draft.CategoryID = nil
+		if reqBody.CategoryID != "" {
+			draft.CategoryID = &reqBody.CategoryID
+		}
+		draft.ImageUrl = reqBody.ImageUrl
+		draft.QuestionTimeLimit = reqBody.QuestionTimeLimit
+		draft.Questions = make([]schemas.QuizDraftQuestion, len(reqBody.Questions))
+		for i, question := range reqBody.Questions {
+			draft.Questions[i] = draftQuestionFromDTO(question)
+		}
+	})
+	if errors.Is(err, errDraftPublished) {
+		c.JSON(http.StatusNotFound, types.NotFoundErrorResponseStruct{
+			StatusCode: http.StatusNotFound,
+			Success:    false,
+			Message:    "Draft not found.",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("Error updating draft: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while updating the draft.",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"statusCode": http.StatusOK,
+		"success":    true,
+		"data":       draft,
+	})
+}
+
+// DeleteDraft godoc
+// @Summary Discard a quiz draft
+// @Schemes
+// @Description Discard one of the quiz drafts of the authenticated user
+// @Tags drafts
+// @Produce json
+// @Param draftId path string true "Draft ID"
+// @Success 200 {object} types.SuccessResponseStruct
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 404 {object} types.NotFoundErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /drafts/{draftId} [delete]
+func DeleteDraft(c *gin.Context, db *gorm.DB) {
+	draft, ok := findOwnDraft(c, db, c.Param("draftId"), "discard")
+	if !ok {
+		return
+	}
+
+	if err := db.WithContext(c.Request.Context()).Delete(&draft).Error; err != nil {
+		log.Printf("Error deleting draft: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while discarding the draft.",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"statusCode": http.StatusOK,
+		"success":    true,
+		"message":    "Draft discarded successfully.",
+	})
+}
+
+// PublishDraft godoc
+// @Summary Publish a quiz draft
+// @Schemes
+// @Description Create a quiz from a draft, with the same validation and moderation as quiz creation, and discard the draft
+// @Tags drafts
+// @Produce json
+// @Param draftId path string true "Draft ID"
+// @Success 201 {object} types.CreateQuizSuccessResponseStruct
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 404 {object} types.NotFoundErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /drafts/{draftId}/publish [post]
+func PublishDraft(c *gin.Context, db *gorm.DB, moderator moderation.Moderator) {
+	draft, ok := findOwnDraft(c, db, c.Param("draftId"), "publish")
+	if !ok {
+		return
+	}
+
+	reqBody := types.CreateQuizRequestBody{
+		Name:              strings.TrimSpace(draft.Name),
+		ImageUrl:          draft.ImageUrl,
+		QuestionTimeLimit: draft.QuestionTimeLimit,
+		Questions:         make([]types.CreateQuizQuestionsStruct, len(draft.Questions)),
+	}
+	if draft.CategoryID != nil {
+		reqBody.CategoryID = *draft.CategoryID
+	}
+	for i, question := range draft.Questions {
+		if strings.TrimSpace(question.Content) == "" {
+			c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+				StatusCode: http.StatusBadRequest,
+				Success:    false,
+				Message:    fmt.Sprintf("Question %d has no content.", i+1),
+			})
+			return
+		}
+		reqBody.Questions[i] = quizQuestionFromDraft(question)
+	}
+
+	switch {
+	case reqBody.Name == "":
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "A quiz name is required to publish the draft.",
+		})
+		return
+	case reqBody.CategoryID == "":
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "A category is required to publish the draft.",
+		})
+		return
+	}
+
+	if err := binding.Validator.ValidateStruct(reqBody); err != nil {
+		log.Printf("Invalid draft %s: %v", draft.ID, err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "The draft is not a valid quiz.",
+		})
+		return
+	}
+
+	quiz, ok := buildQuiz(c, db, reqBody, uuid.MustParse(draft.CreatedBy))
+	if !ok {
+		return
+	}
+	moderateQuiz(c.Request.Context(), moderator, &quiz)
+
+	err := db.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		// Discarding the draft first makes concurrent publishes of it wait
+		// for each other, and only the first one creates the quiz
+		result := tx.Delete(&draft)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return errDraftPublished
+		}
+		return gorm.G[schemas.Quiz](tx).Create(c.Request.Context(), &quiz)
+	})
+	if errors.Is(err, errDraftPublished) {
+		c.JSON(http.StatusNotFound, types.NotFoundErrorResponseStruct{
+			StatusCode: http.StatusNotFound,
+			Success:    false,
+			Message:    "Draft not found.",
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("Error publishing draft: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while publishing the draft.",
+		})
+		return
+	}
+
+	quiz.Category = nil
+	quiz.User = nil
+	quiz.CreatedAt = nil
+	quiz.UpdatedAt = nil
+
+	c.JSON(http.StatusCreated, gin.H{
+		"statusCode": http.StatusCreated,
+		"success":    true,
+		"data":       quiz,
+	})
+}
+
+// findOwnDraft fetches a draft of the authenticated user, responding with the
+// error when it does not exist or belongs to someone else.
+func findOwnDraft(c *gin.Context, db *gorm.DB, draftId string, action string) (schemas.QuizDraft, bool) {
+	draftUuid, err := uuid.Parse(draftId)
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Invalid draft ID format.",
+		})
+		return schemas.QuizDraft{}, false
+	}
+
+	userUuid, err := uuid.Parse(c.MustGet("userID").(string))
+	if err != nil {
+		log.Printf("Error parsing User UUID from context: %v", err)
+
+		c.JSON(http.StatusForbidden, types.ForbiddenErrorResponseStruct{
+			StatusCode: http.StatusForbidden,
+			Success:    false,
+			Message:    "Invalid user ID format on claims.",
+		})
+		return schemas.QuizDraft{}, false
+	}
+
+	draft, err := gorm.G[schemas.QuizDraft](db).Where("id = ?", draftUuid).First(c.Request.Context())
+	if err != nil {
+		log.Printf("Error fetching draft by ID: %v", err)
+
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, types.NotFoundErrorResponseStruct{
+				StatusCode: http.StatusNotFound,
+				Success:    false,
+				Message:    "Draft not found.",
+			})
+			return schemas.QuizDraft{}, false
+		}
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while fetching the draft.",
+		})
+		return schemas.QuizDraft{}, false
+	}
+
+	if draft.CreatedBy != userUuid.String() {
+		c.JSON(http.StatusForbidden, types.ForbiddenErrorResponseStruct{
+			StatusCode: http.StatusForbidden,
+			Success:    false,
+			Message:    "You do not have permission to " + action + " this draft.",
+		})
+		return schemas.QuizDraft{}, false
+	}
+
+	return draft, true
+}
+
+// saveGeneratedDraft stores AI generated content as a draft of the
+// authenticated user. Failing to save the draft does not fail the generation,
+// so the error is only logged and an empty ID returned.
+func saveGeneratedDraft(c *gin.Context, db *gorm.DB, draft schemas.QuizDraft) string {
+	userUuid, err := uuid.Parse(c.MustGet("userID").(string))
+	if err != nil {
+		log.Printf("Error parsing User UUID from context: %v", err)
+		return ""
+	}
+	draft.CreatedBy = userUuid.String()
+	draft.Name = truncateRunes(draft.Name, 60)
+
+	// The request context may be canceled by then when streaming
+	if err := gorm.G[schemas.QuizDraft](db).Create(context.WithoutCancel(c.Request.Context()), &draft); err != nil {
+		log.Printf("Error saving generated draft: %v", err)
+		return ""
+	}
+
+	return draft.ID
+}
+
+// appendGeneratedQuestion adds an AI generated question to an existing draft,
+// returning an empty ID when it could not be saved like saveGeneratedDraft. It
+// responds with the error when the draft was published or discarded meanwhile.
+func appendGeneratedQuestion(c *gin.Context, db *gorm.DB, draft schemas.QuizDraft, question schemas.QuizDraftQuestion) (string, bool) {
+	_, err := updateDraft(c.Request.Context(), db, draft.ID, func(draft *schemas.QuizDraft) {
+		draft.Questions = append(draft.Questions, question)
+	})
+	if errors.Is(err, errDraftPublished) {
+		c.JSON(http.StatusNotFound, types.NotFoundErrorResponseStruct{
+			StatusCode: http.StatusNotFound,
+			Success:    false,
+			Message:    "Draft not found.",
+		})
+		return "", false
+	}
+	if err != nil {
+		log.Printf("Error appending generated question to draft: %v", err)
+		return "", true
+	}
+
+	return draft.ID, true
+}
+
+// updateDraft applies the change to the current content of the draft and saves
+// it, locking the draft so concurrent changes apply one after the other. It
+// fails with errDraftPublished once the draft was published or discarded, as
+// the draft may have been fetched long before, such as before an AI call.
+func updateDraft(ctx context.Context, db *gorm.DB, draftId string, change func(*schemas.QuizDraft)) (schemas.QuizDraft, error) {
+	var draft schemas.QuizDraft
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		draft, err = gorm.G[schemas.QuizDraft](tx, clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND deleted_at IS NULL", draftId).
+			First(ctx)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errDraftPublished
+		}
+		if err != nil {
+			return err
+		}
+
+		change(&draft)
+
+		result := tx.Model(&draft).
+			Select("name", "category_id", "image_url", "question_time_limit", "questions").
+			Updates(&draft)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return errDraftPublished
+		}
+
+		return nil
+	})
+
+	return draft, err
+}
+
+func generatedDraftQuestion(question ai.GeneratedQuestion, sourceExcerpt string) schemas.QuizDraftQuestion {
+	draftQuestion := schemas.QuizDraftQuestion{
+		Content:       question.QuestionContent,
+		Type:          schemas.QuestionTypeSingleChoice,
+		Choices:       make([]schemas.QuizDraftChoice, len(question.Choices)),
+		SourceExcerpt: sourceExcerpt,
+	}
+	for i, choice := range question.Choices {
+		draftQuestion.Choices[i] = schemas.QuizDraftChoice{
+			Content:   choice.Content,
+			IsCorrect: choice.IsCorrect,
+		}
+	}
+
+	return draftQuestion
+}
+
+func draftQuestionFromDTO(question types.QuizDraftQuestionDTO) schemas.QuizDraftQuestion {
+	draftQuestion := schemas.QuizDraftQuestion{
+		Content:          question.Content,
+		Type:             question.Type,
+		AcceptedAnswers:  question.AcceptedAnswers,
+		NumericAnswer:    question.NumericAnswer,
+		NumericTolerance: question.NumericTolerance,
+		Explanation:      question.Explanation,
+		SourceExcerpt:    question.SourceExcerpt,
+	}
+	for _, choice := range question.Choices {
+		draftQuestion.Choices = append(draftQuestion.Choices, schemas.QuizDraftChoice{
+			Content:   choice.Content,
+			IsCorrect: choice.IsCorrect,
+		})
+	}
+
+	return draftQuestion
+}
+
+func quizQuestionFromDraft(question schemas.QuizDraftQuestion) types.CreateQuizQuestionsStruct {
+	quizQuestion := types.CreateQuizQuestionsStruct{
+		Content:          strings.TrimSpace(question.Content),
+		Type:             question.Type,
+		AcceptedAnswers:  question.AcceptedAnswers,
+		NumericAnswer:    question.NumericAnswer,
+		NumericTolerance: question.NumericTolerance,
+		Explanation:      question.Explanation,
+	}
+	for _, choice := range question.Choices {
+		quizQuestion.Choices = append(quizQuestion.Choices, types.CreateQuizQuestionChoiceStruct{
+			Content:   choice.Content,
+			IsCorrect: choice.IsCorrect,
+		})
+	}
+
+	return quizQuestion
+}
+
+func truncateRunes(s string, length int) string {
+	if utf8.RuneCountInString(s) <= length {
+		return s
+	}
+	return string([]rune(s)[:length])
+}
@@ -0,0 +1,453 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"intelliquiz/src/database/schemas"
+	"intelliquiz/src/moderation"
+	"intelliquiz/src/transfer"
+	"intelliquiz/src/types"
+	"intelliquiz/src/utils"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	maxImportFileSize = 5 << 20
+	// maxImportBodySize leaves room for the other fields of the form
+	maxImportBodySize = maxImportFileSize + 1<<20
+)
+
+// ExportQuiz godoc
+// @Summary Export a quiz
+// @Schemes
+// @Description Download one of the user's quizzes with its questions in JSON, CSV, GIFT or Moodle XML format. Ordering questions are left out of GIFT exports.
+// @Tags quizzes
+// @Produce json
+// @Produce plain
+// @Produce xml
+// @Param quizId path string true "Quiz ID"
+// @Param format query string false "Export format" Enums(json, csv, gift, moodlexml) default(json)
+// @Success 200 {file} file
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 404 {object} types.NotFoundErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /quizzes/{quizId}/export [get]
+func ExportQuiz(c *gin.Context, db *gorm.DB) {
+	userUuid, err := uuid.Parse(c.MustGet("userID").(string))
+	if err != nil {
+		log.Printf("Error parsing User UUID from context: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.ForbiddenErrorResponseStruct{
+			StatusCode: http.StatusForbidden,
+			Success:    false,
+			Message:    "Invalid user ID format on claims.",
+		})
+		return
+	}
+
+	quizUuid, err := uuid.Parse(c.Param("quizId"))
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Invalid quiz ID format.",
+		})
+		return
+	}
+
+	format := strings.ToLower(c.DefaultQuery("format", transfer.FormatJSON))
+	if !transfer.IsSupportedFormat(format) {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Invalid format. Supported formats are json, csv, gift and moodlexml.",
+		})
+		return
+	}
+
+	quiz, err := gorm.G[schemas.Quiz](db).Where("id = ?", quizUuid).
+		Where(utils.VisibleQuiz(userUuid.String(), c.GetString("role"))).
+		Select("id, name, category_id, created_by, image_url, question_time_limit").
+		Preload("Questions", func(db gorm.PreloadBuilder) error {
+			db.Select("id, content, type, quiz_id, numeric_answer, numeric_tolerance, explanation").
+				Order("created_at ASC")
+			return nil
+		}).
+		Preload("Questions.Choices", func(db gorm.PreloadBuilder) error {
+			db.Select("id, content, is_correct, position, question_id").
+				Order("created_at ASC")
+			return nil
+		}).
+		First(c)
+	if err != nil {
+		log.Printf("Error fetching quiz by ID: %v", err)
+
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, types.NotFoundErrorResponseStruct{
+				StatusCode: http.StatusNotFound,
+				Success:    false,
+				Message:    "Quiz not found.",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while fetching the quiz.",
+		})
+		return
+	}
+
+	if quiz.CreatedBy != userUuid.String() {
+		c.JSON(http.StatusForbidden, types.ForbiddenErrorResponseStruct{
+			StatusCode: http.StatusForbidden,
+			Success:    false,
+			Message:    "You do not have permission to export this quiz.",
+		})
+		return
+	}
+
+	var exported bytes.Buffer
+	if err := transfer.Export(format, quiz, &exported); err != nil {
+		log.Printf("Error exporting quiz: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while exporting the quiz.",
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="quiz-%s.%s"`, quiz.ID, transfer.FileExtension(format)))
+	c.Data(http.StatusOK, transfer.ContentType(format), exported.Bytes())
+}
+
+// ImportQuiz godoc
+// @Summary Import a quiz
+// @Schemes
+// @Description Create a quiz from a JSON, CSV, GIFT or Moodle XML file. The questions are validated with the same rules as quiz creation and every invalid row is reported. The format is guessed from the file extension when not given. Form fields take precedence over the quiz data of JSON files. Names longer than 60 characters are cut. Quizzes flagged by moderation are kept out of the public listings until reviewed.
+// @Tags quizzes
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Quiz file"
+// @Param format formData string false "File format" Enums(json, csv, gift, moodlexml)
+// @Param name formData string false "Quiz name, required unless given by a JSON file"
+// @Param category_id formData string false "Category ID, required unless given by a JSON file"
+// @Param image_url formData string false "Image URL"
+// @Param question_time_limit formData int false "Seconds allowed per question, between 5 and 300"
+// @Success 201 {object} types.CreateQuizSuccessResponseStruct
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 422 {object} types.ImportQuizErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /quizzes/import [post]
+func ImportQuiz(c *gin.Context, db *gorm.DB, moderator moderation.Moderator) {
+	userUuid, err := uuid.Parse(c.MustGet("userID").(string))
+	if err != nil {
+		log.Printf("Error parsing User UUID from context: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.ForbiddenErrorResponseStruct{
+			StatusCode: http.StatusForbidden,
+			Success:    false,
+			Message:    "Invalid user ID format on claims.",
+		})
+		return
+	}
+
+	// The form is cut off before it is parsed, since a file over the limit
+	// would otherwise be read in full
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportBodySize)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		log.Printf("Error reading uploaded file: %v", err)
+
+		if isBodyTooLarge(err) {
+			c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+				StatusCode: http.StatusBadRequest,
+				Success:    false,
+				Message:    "The quiz file must be at most 5 MB.",
+			})
+			return
+		}
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "A quiz file must be uploaded in the file field.",
+		})
+		return
+	}
+	if fileHeader.Size > maxImportFileSize {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "The quiz file must be at most 5 MB.",
+		})
+		return
+	}
+
+	format := strings.ToLower(c.PostForm("format"))
+	if format == "" {
+		format = transfer.FormatFromFilename(fileHeader.Filename)
+	}
+	if !transfer.IsSupportedFormat(format) {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Invalid format. Supported formats are json, csv, gift and moodlexml.",
+		})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		log.Printf("Error opening uploaded file: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while reading the quiz file.",
+		})
+		return
+	}
+	defer file.Close()
+
+	parsed, rowErrors, err := transfer.Parse(format, file)
+	if err != nil {
+		log.Printf("Error parsing quiz file: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "The quiz file could not be parsed as " + format + ".",
+		})
+		return
+	}
+
+	if name := c.PostForm("name"); name != "" {
+		parsed.Name = name
+	}
+	if categoryId := c.PostForm("category_id"); categoryId != "" {
+		parsed.CategoryID = categoryId
+	}
+	if imageUrl := c.PostForm("image_url"); imageUrl != "" {
+		parsed.ImageUrl = imageUrl
+	}
+	if timeLimit := c.PostForm("question_time_limit"); timeLimit != "" {
+		seconds, err := strconv.ParseUint(timeLimit, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+				StatusCode: http.StatusBadRequest,
+				Success:    false,
+				Message:    "Invalid question time limit.",
+			})
+			return
+		}
+		questionTimeLimit := uint(seconds)
+		parsed.QuestionTimeLimit = &questionTimeLimit
+	}
+
+	if parsed.Name == "" {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "A quiz name is required.",
+		})
+		return
+	}
+	// Names from other tools can be longer than ours, so cut them like the
+	// names of drafts rather than rejecting the file
+	parsed.Name = truncateRunes(parsed.Name, 60)
+
+	if parsed.QuestionTimeLimit != nil && (*parsed.QuestionTimeLimit < 5 || *parsed.QuestionTimeLimit > 300) {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Question time limit must be between 5 and 300 seconds.",
+		})
+		return
+	}
+
+	categoryUuid, err := uuid.Parse(parsed.CategoryID)
+	if err != nil {
+		log.Printf("Error parsing Category UUID: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Invalid category ID format.",
+		})
+		return
+	}
+
+	if _, err := gorm.G[schemas.Category](db).Where("id = ?", categoryUuid).First(c); err != nil {
+		log.Printf("Error fetching category by ID: %v", err)
+
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+				StatusCode: http.StatusBadRequest,
+				Success:    false,
+				Message:    "Category not found.",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while verifying the category.",
+		})
+		return
+	}
+
+	matched, err := regexp.MatchString(`^(?:(?<scheme>[^:\/?#]+):)?(?:\/\/(?<authority>[^\/?#]*))?(?<path>[^?#]*\/)?(?<file>[^?#]*\.(?<extension>[Jj][Pp][Ee]?[Gg]|[Pp][Nn][Gg]|[Gg][Ii][Ff]|[Ww][Ee][Bb][Pp]))(?:\?(?<query>[^#]*))?(?:#(?<fragment>.*))?$`, parsed.ImageUrl)
+	if err != nil {
+		log.Printf("Error validating image URL: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while validating the image URL.",
+		})
+		return
+	} else if parsed.ImageUrl != "" && !matched {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Invalid image URL format. Image URL must end with .jpg, .jpeg, .png, .webp, or .gif and be a valid URL.",
+		})
+		return
+	}
+
+	// Keep validating after the first invalid row so the whole file can be fixed at once
+	questions := []schemas.Question{}
+	for _, parsedQuestion := range parsed.Questions {
+		if strings.TrimSpace(parsedQuestion.Question.Content) == "" {
+			rowErrors = append(rowErrors, types.ImportQuizRowErrorDTO{
+				Row:     parsedQuestion.Row,
+				Message: "The question content is required.",
+			})
+			continue
+		}
+
+		if err := binding.Validator.ValidateStruct(parsedQuestion.Question); err != nil {
+			log.Printf("Invalid imported question on row %d: %v", parsedQuestion.Row, err)
+
+			rowErrors = append(rowErrors, types.ImportQuizRowErrorDTO{
+				Row:     parsedQuestion.Row,
+				Message: questionValidationMessage(err),
+			})
+			continue
+		}
+
+		question, err := utils.BuildQuestion(parsedQuestion.Question)
+		if err != nil {
+			rowErrors = append(rowErrors, types.ImportQuizRowErrorDTO{
+				Row:     parsedQuestion.Row,
+				Message: err.Error(),
+			})
+			continue
+		}
+
+		questions = append(questions, question)
+	}
+
+	if len(parsed.Questions) < 2 || len(parsed.Questions) > 50 {
+		rowErrors = append(rowErrors, types.ImportQuizRowErrorDTO{
+			Row:     0,
+			Message: "Number of questions must be between 2 and 50.",
+		})
+	}
+
+	if len(rowErrors) > 0 {
+		log.Printf("Quiz import rejected with %d invalid rows", len(rowErrors))
+
+		c.JSON(http.StatusUnprocessableEntity, types.ImportQuizErrorResponseStruct{
+			StatusCode: http.StatusUnprocessableEntity,
+			Success:    false,
+			Message:    "The quiz file has invalid rows.",
+			Errors:     rowErrors,
+		})
+		return
+	}
+
+	quiz := schemas.Quiz{
+		Name:              parsed.Name,
+		CategoryID:        categoryUuid.String(),
+		CreatedBy:         userUuid.String(),
+		Questions:         questions,
+		QuestionsCount:    len(questions),
+		ImageUrl:          parsed.ImageUrl,
+		QuestionTimeLimit: parsed.QuestionTimeLimit,
+	}
+	moderateQuiz(c.Request.Context(), moderator, &quiz)
+
+	if err := gorm.G[schemas.Quiz](db).Create(c, &quiz); err != nil {
+		log.Printf("Error creating quiz: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while creating the quiz.",
+		})
+		return
+	}
+
+	quiz.Category = nil
+	quiz.User = nil
+	quiz.CreatedAt = nil
+	quiz.UpdatedAt = nil
+
+	c.JSON(http.StatusCreated, gin.H{
+		"statusCode": http.StatusCreated,
+		"success":    true,
+		"data":       quiz,
+	})
+}
+
+// isBodyTooLarge reports whether reading the request body failed for going
+// over the limit of http.MaxBytesReader.
+func isBodyTooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr)
+}
+
+// questionValidationMessage describes the fields of an imported question that
+// failed validation, as the row error of the question
+func questionValidationMessage(err error) string {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return "The question is not valid."
+	}
+
+	var messages []string
+	for _, fieldError := range validationErrors {
+		switch fieldError.Field() {
+		case "Explanation":
+			messages = append(messages, "The explanation must be at most "+fieldError.Param()+" characters.")
+		case "Type":
+			messages = append(messages, "Invalid question type.")
+		case "NumericTolerance":
+			messages = append(messages, "The numeric tolerance can't be negative.")
+		default:
+			messages = append(messages, "Invalid "+strings.ToLower(fieldError.Field())+".")
+		}
+	}
+	return strings.Join(messages, " ")
+}
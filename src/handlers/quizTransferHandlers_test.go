@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r    io.Reader
+	read atomic.Int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.read.Add(int64(n))
+	return n, err
+}
+
+// filler reads as an endless run of the same letter.
+type filler struct{}
+
+func (filler) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 'a'
+	}
+	return len(p), nil
+}
+
+// postLargeFile uploads a file of size bytes in the file field of a form,
+// writing the form as it is read, and reports how much of it was read.
+func postLargeFile(router *gin.Engine, path, filename string, size int64) (*httptest.ResponseRecorder, int64) {
+	pr, pw := io.Pipe()
+	form := multipart.NewWriter(pw)
+	go func() {
+		file, err := form.CreateFormFile("file", filename)
+		if err == nil {
+			_, err = io.CopyN(file, filler{}, size)
+		}
+		if err == nil {
+			err = form.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	body := &countingReader{r: pr}
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, path, body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	router.ServeHTTP(w, req)
+	pr.Close()
+
+	return w, body.read.Load()
+}
+
+func TestImportQuizRejectsLargeFiles(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.POST("/quizzes/import", func(c *gin.Context) {
+		c.Set("userID", uuid.NewString())
+		ImportQuiz(c, nil, nil)
+	})
+
+	w, read := postLargeFile(router, "/quizzes/import", "quiz.csv", 4*maxImportBodySize)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "at most 5 MB") {
+		t.Errorf("got status %d, want %d: %s", w.Code, http.StatusBadRequest, w.Body)
+	}
+	if read > maxImportBodySize+64<<10 {
+		t.Errorf("read %d bytes of the body, want the limit of %d", read, maxImportBodySize)
+	}
+}
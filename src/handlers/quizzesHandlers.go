@@ -1,15 +1,15 @@
 package handlers
 
 import (
-	"intelliquiz/src/auth"
+	"context"
 	"intelliquiz/src/database/schemas"
-	"intelliquiz/src/middlewares"
+	"intelliquiz/src/moderation"
 	"intelliquiz/src/types"
+	"intelliquiz/src/utils"
 	"log"
-	"math"
 	"net/http"
 	"regexp"
-	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -19,68 +19,157 @@ import (
 // GetQuizzes godoc
 // @Summary Get all quizzes
 // @Schemes
-// @Description Retrieve a list of all quizzes
+// @Description Retrieve a list of all quizzes, leaving out the ones quarantined by moderation. The search matches the quiz name, author, category and questions in Portuguese and English, ignoring case and accents, and sorts the quizzes by relevance unless another sort is given. The filters can be combined. The facets count the matching quizzes by category and curator pick, leaving out the filter on the facet itself, and are only returned on the first page.
 // @Tags quizzes
 // @Produce json
 // @Param limit query int false "Limit of quizzes per page (min: 5, max: 50)" default(10)
-// @Param page query int false "Page number (0-indexed)" default(0)
-// @Param name query string false "Filter quizzes by name, category name, user name, or username"
+// @Param cursor query string false "Cursor of the page, taken from next_cursor or prev_cursor"
+// @Param page query int false "Page number (0-indexed), used without a cursor" default(0)
+// @Param q query string false "Search terms. Quotes match phrases, OR matches either term and -term leaves a term out"
+// @Param name query string false "Deprecated: same as q"
+// @Param sort query string false "Sort of the quizzes, relevance by default when searching and newest otherwise. Trending quizzes are the most played in the last 7 days" Enums(relevance, newest, most_liked, most_played, trending, alphabetical)
+// @Param category_id query []string false "Category IDs, including their subcategories" collectionFormat(multi)
+// @Param curator_pick query bool false "Keep only the curator picks, or leave them out"
+// @Param created_by query string false "ID of the quiz author"
+// @Param min_questions query int false "Minimum number of questions"
+// @Param created_after query string false "Keep the quizzes created from this date on (2025-10-25 or RFC 3339)"
+// @Param created_before query string false "Keep the quizzes created before this date (2025-10-25 or RFC 3339)"
+// @Param has_image query bool false "Keep only the quizzes with an image, or without one"
 // @Success 200 {object} types.GetQuizzesSuccessResponseStruct
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
 // @Failure 500 {object} types.InternalServerErrorResponseStruct
 // @Router /quizzes [get]
 func GetQuizzes(c *gin.Context, db *gorm.DB) {
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "0"))
-	quizNameFilter := c.DefaultQuery("name", "")
+	search := strings.TrimSpace(c.Query("q"))
+	if search == "" {
+		search = strings.TrimSpace(c.Query("name"))
+	}
 
-	limit = max(5, min(50, limit))
-	page = max(0, page)
+	sort := c.Query("sort")
+	if sort == "" {
+		sort = "newest"
+		if search != "" {
+			sort = "relevance"
+		}
+	}
 
-	var quizzesCount int64
-	err := db.Model(&schemas.Quiz{}).
-		WithContext(c.Request.Context()).
-		Where("quizzes.name LIKE ?", "%"+quizNameFilter+"%").
-		Or("categories.name LIKE ?", "%"+quizNameFilter+"%").
-		Or("users.name LIKE ?", "%"+quizNameFilter+"%").
-		Or("users.username LIKE ?", "%"+quizNameFilter+"%").
-		Joins("LEFT JOIN categories ON categories.id = quizzes.category_id").
-		Joins("LEFT JOIN users ON users.id = quizzes.created_by").
-		Count(&quizzesCount).
-		Error
+	sortKeys, sortValues, ok := utils.QuizSortKeys(sort, search)
+	if !ok {
+		message := "Invalid sort. It must be one of: " + strings.Join(utils.QuizSorts, ", ") + "."
+		if sort == "relevance" {
+			message = "Sorting by relevance requires a search."
+		}
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    message,
+		})
+		return
+	}
+
+	filters, err := utils.ParseQuizFilters(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
-			StatusCode: http.StatusInternalServerError,
+		log.Printf("Invalid quiz filters: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
 			Success:    false,
-			Message:    "An error occurred while fetching quizzes count",
+			Message:    err.Error(),
 		})
 		return
 	}
 
+	pagination, paginate, ok := parsePagination(c, sort, sortKeys)
+	if !ok {
+		return
+	}
+
+	filteredQuizzes := func(filters utils.QuizFilters) *gorm.DB {
+		query := db.Model(&schemas.Quiz{}).
+			WithContext(c.Request.Context()).
+			Where("quizzes.moderation_status = ?", schemas.ModerationApproved)
+
+		return filters.Apply(utils.SearchQuizzes(query, search))
+	}
+
+	var quizzesCount int64
+	if !pagination.UsesCursor() {
+		err := filteredQuizzes(filters).
+			Count(&quizzesCount).
+			Error
+		if err != nil {
+			log.Printf("Error counting quizzes: %v", err)
+
+			c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+				StatusCode: http.StatusInternalServerError,
+				Success:    false,
+				Message:    "An error occurred while fetching quizzes count",
+			})
+			return
+		}
+	}
+
+	// The facets are the same on every page, so they are only counted on
+	// the first one
+	var facets *types.QuizFacetsDTO
+	if pagination.IsFirstPage() {
+		facets = &types.QuizFacetsDTO{
+			Categories:  []types.CategoryFacetDTO{},
+			CuratorPick: []types.CuratorPickFacetDTO{},
+		}
+		categoryFilters := filters
+		categoryFilters.CategoryIDs = nil
+		err = filteredQuizzes(categoryFilters).
+			Select("categories.id, categories.name, COUNT(*) AS count").
+			Joins("JOIN categories ON categories.id = quizzes.category_id").
+			Group("categories.id, categories.name").
+			Order("count DESC, categories.name").
+			Scan(&facets.Categories).
+			Error
+		if err == nil {
+			curatorPickFilters := filters
+			curatorPickFilters.CuratorPick = nil
+			err = filteredQuizzes(curatorPickFilters).
+				Select("quizzes.curator_pick AS value, COUNT(*) AS count").
+				Group("quizzes.curator_pick").
+				Order("value DESC").
+				Scan(&facets.CuratorPick).
+				Error
+		}
+		if err != nil {
+			log.Printf("Error counting quiz facets: %v", err)
+
+			c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+				StatusCode: http.StatusInternalServerError,
+				Success:    false,
+				Message:    "An error occurred while fetching quizzes facets",
+			})
+			return
+		}
+	}
+
+	columns := "quizzes.id, quizzes.name, quizzes.category_id, quizzes.created_by, quizzes.curator_pick, quizzes.image_url, quizzes.question_time_limit, quizzes.created_at, quizzes.updated_at, quizzes.likes, quizzes.games_played, quizzes.recent_plays"
+	query := filteredQuizzes(filters).Select(columns)
+	if search != "" {
+		rank := utils.QuizSearchRank(search)
+		query = filteredQuizzes(filters).Select(columns+", "+rank.Column+" AS score", rank.Vars...)
+	}
+
 	var quizzes []schemas.Quiz
-	err = db.Model(&schemas.Quiz{}).
-		WithContext(c.Request.Context()).
-		Select("quizzes.id, quizzes.name, quizzes.category_id, quizzes.created_by, quizzes.curator_pick, quizzes.image_url, quizzes.created_at, quizzes.updated_at").
-		Where("quizzes.name LIKE ?", "%"+quizNameFilter+"%").
-		Or("categories.name LIKE ?", "%"+quizNameFilter+"%").
-		Or("users.name LIKE ?", "%"+quizNameFilter+"%").
-		Or("users.username LIKE ?", "%"+quizNameFilter+"%").
-		Joins("LEFT JOIN categories ON categories.id = quizzes.category_id").
-		Joins("LEFT JOIN users ON users.id = quizzes.created_by").
-		Preload("UserLikes", func(db *gorm.DB) *gorm.DB {
-			return db.Select("id")
-		}).
+	err = query.
 		Preload("Category", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id, name")
 		}).
 		Preload("User", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id, username, name")
 		}).
-		Preload("Games", nil).
-		Limit(limit).
-		Offset(page * limit).
+		Scopes(utils.Scope(paginate)).
 		Find(&quizzes).
 		Error
 	if err != nil {
+		log.Printf("Error fetching quizzes: %v", err)
+
 		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
 			StatusCode: http.StatusInternalServerError,
 			Success:    false,
@@ -89,44 +178,45 @@ func GetQuizzes(c *gin.Context, db *gorm.DB) {
 		return
 	}
 
-	for i := range quizzes {
-		quizzes[i].GamesPlayed = len(quizzes[i].Games)
-		quizzes[i].Likes = len(quizzes[i].UserLikes)
-		quizzes[i].Games = nil
-		quizzes[i].UserLikes = nil
+	quizzes, cursors := utils.PageItems(pagination, sort, quizzes, sortValues)
+
+	data := gin.H{
+		"quizzes":     quizzes,
+		"next_cursor": cursors.Next,
+		"prev_cursor": cursors.Prev,
+	}
+	if facets != nil {
+		data["facets"] = facets
+	}
+	if !pagination.UsesCursor() {
+		data["maxPage"] = pagination.MaxPage(quizzesCount)
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"statusCode": http.StatusOK,
 		"success":    true,
-		"data": gin.H{
-			"quizzes": quizzes,
-			"maxPage": math.Ceil(float64(quizzesCount)/float64(limit)) - 1,
-		},
+		"data":       data,
 	})
 }
 
 // GetOwnQuizzes godoc
 // @Summary Get own quizzes
 // @Schemes
-// @Description Retrieve a list of quizzes created by the authenticated user
+// @Description Retrieve a list of quizzes created by the authenticated user, including the ones quarantined by moderation
 // @Tags quizzes
 // @Produce json
 // @Param limit query int false "Limit of quizzes per page (min: 5, max: 50)" default(10)
-// @Param page query int false "Page number (0-indexed)" default(0)
+// @Param cursor query string false "Cursor of the page, taken from next_cursor or prev_cursor"
+// @Param page query int false "Page number (0-indexed), used without a cursor" default(0)
 // @Param name query string false "Filter quizzes by name, category name, user name, or username"
 // @Success 200 {object} types.GetOwnQuizzesSuccessResponseStruct
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
 // @Failure 403 {object} types.ForbiddenErrorResponseStruct
 // @Failure 500 {object} types.InternalServerErrorResponseStruct
 // @Router /me/quizzes [get]
 func GetOwnQuizzes(c *gin.Context, db *gorm.DB) {
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "0"))
 	quizNameFilter := c.DefaultQuery("name", "")
 
-	limit = max(5, min(50, limit))
-	page = max(0, page)
-
 	userUuid, err := uuid.Parse(c.MustGet("userID").(string))
 	if err != nil {
 		c.JSON(http.StatusForbidden, types.ForbiddenErrorResponseStruct{
@@ -137,33 +227,43 @@ func GetOwnQuizzes(c *gin.Context, db *gorm.DB) {
 		return
 	}
 
-	var quizzesCount int64
-	err = db.Model(&schemas.Quiz{}).
-		WithContext(c.Request.Context()).
-		Where("quizzes.created_by = ?", userUuid.String()).
-		Where(
-			db.Where("quizzes.name LIKE ?", "%"+quizNameFilter+"%").
-				Or("categories.name LIKE ?", "%"+quizNameFilter+"%").
-				Or("users.name LIKE ?", "%"+quizNameFilter+"%").
-				Or("users.username LIKE ?", "%"+quizNameFilter+"%"),
-		).
-		Joins("LEFT JOIN categories ON categories.id = quizzes.category_id").
-		Joins("LEFT JOIN users ON users.id = quizzes.created_by").
-		Count(&quizzesCount).
-		Error
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
-			StatusCode: http.StatusInternalServerError,
-			Success:    false,
-			Message:    "An error occurred while fetching quizzes count",
-		})
+	pagination, paginate, ok := parsePagination(c, "newest", []utils.SortKey{
+		{Column: "quizzes.created_at", Desc: true, Parse: utils.TimeCursorValue},
+		{Column: "quizzes.id", Desc: true, Parse: utils.UUIDCursorValue},
+	})
+	if !ok {
 		return
 	}
 
+	var quizzesCount int64
+	if !pagination.UsesCursor() {
+		err := db.Model(&schemas.Quiz{}).
+			WithContext(c.Request.Context()).
+			Where("quizzes.created_by = ?", userUuid.String()).
+			Where(
+				db.Where("quizzes.name LIKE ?", "%"+quizNameFilter+"%").
+					Or("categories.name LIKE ?", "%"+quizNameFilter+"%").
+					Or("users.name LIKE ?", "%"+quizNameFilter+"%").
+					Or("users.username LIKE ?", "%"+quizNameFilter+"%"),
+			).
+			Joins("LEFT JOIN categories ON categories.id = quizzes.category_id").
+			Joins("LEFT JOIN users ON users.id = quizzes.created_by").
+			Count(&quizzesCount).
+			Error
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+				StatusCode: http.StatusInternalServerError,
+				Success:    false,
+				Message:    "An error occurred while fetching quizzes count",
+			})
+			return
+		}
+	}
+
 	var quizzes []schemas.Quiz
 	err = db.Model(&schemas.Quiz{}).
 		WithContext(c.Request.Context()).
-		Select("quizzes.id, quizzes.name, quizzes.category_id, quizzes.created_by, quizzes.curator_pick, quizzes.image_url, quizzes.created_at, quizzes.updated_at").
+		Select("quizzes.id, quizzes.name, quizzes.category_id, quizzes.created_by, quizzes.curator_pick, quizzes.moderation_status, quizzes.moderation_reason, quizzes.image_url, quizzes.question_time_limit, quizzes.created_at, quizzes.updated_at, quizzes.likes, quizzes.games_played").
 		Where("quizzes.created_by = ?", userUuid.String()).
 		Where(
 			db.Where("quizzes.name LIKE ?", "%"+quizNameFilter+"%").
@@ -173,18 +273,13 @@ func GetOwnQuizzes(c *gin.Context, db *gorm.DB) {
 		).
 		Joins("LEFT JOIN categories ON categories.id = quizzes.category_id").
 		Joins("LEFT JOIN users ON users.id = quizzes.created_by").
-		Preload("UserLikes", func(db *gorm.DB) *gorm.DB {
-			return db.Select("id")
-		}).
 		Preload("Category", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id, name")
 		}).
 		Preload("User", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id, username, name")
 		}).
-		Preload("Games", nil).
-		Limit(limit).
-		Offset(page * limit).
+		Scopes(utils.Scope(paginate)).
 		Find(&quizzes).
 		Error
 	if err != nil {
@@ -196,27 +291,30 @@ func GetOwnQuizzes(c *gin.Context, db *gorm.DB) {
 		return
 	}
 
-	for i := range quizzes {
-		quizzes[i].GamesPlayed = len(quizzes[i].Games)
-		quizzes[i].Likes = len(quizzes[i].UserLikes)
-		quizzes[i].Games = nil
-		quizzes[i].UserLikes = nil
+	quizzes, cursors := utils.PageItems(pagination, "newest", quizzes, func(quiz schemas.Quiz) []any {
+		return []any{quiz.CreatedAt, quiz.ID}
+	})
+
+	data := gin.H{
+		"quizzes":     quizzes,
+		"next_cursor": cursors.Next,
+		"prev_cursor": cursors.Prev,
+	}
+	if !pagination.UsesCursor() {
+		data["maxPage"] = pagination.MaxPage(quizzesCount)
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"statusCode": http.StatusOK,
 		"success":    true,
-		"data": gin.H{
-			"quizzes": quizzes,
-			"maxPage": math.Ceil(float64(quizzesCount)/float64(limit)) - 1,
-		},
+		"data":       data,
 	})
 }
 
 // CreateQuiz godoc
 // @Summary Create a new quiz
 // @Schemes
-// @Description Create a new quiz
+// @Description Create a new quiz. Quizzes whose content is flagged by moderation are created, but kept out of the public listings until reviewed.
 // @Tags quizzes
 // @Produce json
 // @Param data body types.CreateQuizRequestBody true "Create Quiz Request Body"
@@ -225,7 +323,7 @@ func GetOwnQuizzes(c *gin.Context, db *gorm.DB) {
 // @Failure 403 {object} types.ForbiddenErrorResponseStruct
 // @Failure 500 {object} types.InternalServerErrorResponseStruct
 // @Router /quizzes [post]
-func CreateQuiz(c *gin.Context, db *gorm.DB) {
+func CreateQuiz(c *gin.Context, db *gorm.DB, moderator moderation.Moderator) {
 	var reqBody types.CreateQuizRequestBody
 	if err := c.ShouldBindJSON(&reqBody); err != nil {
 		log.Printf("Error parsing request body: %v", err)
@@ -238,6 +336,50 @@ func CreateQuiz(c *gin.Context, db *gorm.DB) {
 		return
 	}
 
+	userUuid, err := uuid.Parse(c.MustGet("userID").(string))
+	if err != nil {
+		log.Printf("Error parsing User UUID from context: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.ForbiddenErrorResponseStruct{
+			StatusCode: http.StatusForbidden,
+			Success:    false,
+			Message:    "Invalid user ID format on claims.",
+		})
+		return
+	}
+
+	quiz, ok := buildQuiz(c, db, reqBody, userUuid)
+	if !ok {
+		return
+	}
+	moderateQuiz(c.Request.Context(), moderator, &quiz)
+
+	if err := gorm.G[schemas.Quiz](db).Create(c, &quiz); err != nil {
+		log.Printf("Error creating quiz: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while creating the quiz.",
+		})
+		return
+	}
+
+	quiz.Category = nil
+	quiz.User = nil
+	quiz.CreatedAt = nil
+	quiz.UpdatedAt = nil
+
+	c.JSON(http.StatusCreated, gin.H{
+		"statusCode": http.StatusCreated,
+		"success":    true,
+		"data":       quiz,
+	})
+}
+
+// buildQuiz validates a quiz creation payload and converts it into a quiz ready
+// to be created, responding with the error when it is invalid.
+func buildQuiz(c *gin.Context, db *gorm.DB, reqBody types.CreateQuizRequestBody, userUuid uuid.UUID) (schemas.Quiz, bool) {
 	categoryUuid, err := uuid.Parse(reqBody.CategoryID)
 	if err != nil {
 		log.Printf("Error parsing Category UUID: %v", err)
@@ -247,7 +389,7 @@ func CreateQuiz(c *gin.Context, db *gorm.DB) {
 			Success:    false,
 			Message:    "Invalid category ID format.",
 		})
-		return
+		return schemas.Quiz{}, false
 	}
 
 	if _, err := gorm.G[schemas.Category](db).Where("id = ?", categoryUuid).First(c); err != nil {
@@ -259,7 +401,7 @@ func CreateQuiz(c *gin.Context, db *gorm.DB) {
 				Success:    false,
 				Message:    "Category not found.",
 			})
-			return
+			return schemas.Quiz{}, false
 		}
 
 		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
@@ -267,19 +409,7 @@ func CreateQuiz(c *gin.Context, db *gorm.DB) {
 			Success:    false,
 			Message:    "An error occurred while verifying the category.",
 		})
-		return
-	}
-
-	userUuid, err := uuid.Parse(c.MustGet("userID").(string))
-	if err != nil {
-		log.Printf("Error parsing User UUID from context: %v", err)
-
-		c.JSON(http.StatusBadRequest, types.ForbiddenErrorResponseStruct{
-			StatusCode: http.StatusForbidden,
-			Success:    false,
-			Message:    "Invalid user ID format on claims.",
-		})
-		return
+		return schemas.Quiz{}, false
 	}
 
 	matched, err := regexp.MatchString(`^(?:(?<scheme>[^:\/?#]+):)?(?:\/\/(?<authority>[^\/?#]*))?(?<path>[^?#]*\/)?(?<file>[^?#]*\.(?<extension>[Jj][Pp][Ee]?[Gg]|[Pp][Nn][Gg]|[Gg][Ii][Ff]|[Ww][Ee][Bb][Pp]))(?:\?(?<query>[^#]*))?(?:#(?<fragment>.*))?$`, reqBody.ImageUrl)
@@ -291,7 +421,7 @@ func CreateQuiz(c *gin.Context, db *gorm.DB) {
 			Success:    false,
 			Message:    "An error occurred while validating the image URL.",
 		})
-		return
+		return schemas.Quiz{}, false
 	} else if reqBody.ImageUrl != "" && !matched {
 		log.Printf("Invalid image URL format: %v", reqBody.ImageUrl)
 
@@ -300,76 +430,21 @@ func CreateQuiz(c *gin.Context, db *gorm.DB) {
 			Success:    false,
 			Message:    "Invalid image URL format. Image URL must end with .jpg, .jpeg, .png, .webp, or .gif and be a valid URL.",
 		})
-		return
+		return schemas.Quiz{}, false
 	}
 
 	questions := []schemas.Question{}
 	for _, q := range reqBody.Questions {
-		hasCorrectChoice := false
-		choices := []schemas.Choice{}
-
-		for _, choice := range q.Choices {
-			if choice.IsCorrect {
-				if hasCorrectChoice {
-					log.Printf("Multiple correct choices found for question: %v", q.Content)
-
-					errMessage := "Only one correct choice can be specified for the question: " + q.Content
-					c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
-						StatusCode: http.StatusBadRequest,
-						Success:    false,
-						Message:    errMessage,
-					})
-					return
-				}
-
-				hasCorrectChoice = true
-			}
-
-			if len(choices) == 6 {
-				log.Printf("Too many choices for question: %v", q.Content)
-
-				errMessage := "A maximum of 6 choices can be specified for the question: " + q.Content
-				c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
-					StatusCode: http.StatusBadRequest,
-					Success:    false,
-					Message:    errMessage,
-				})
-				return
-			}
-
-			choices = append(choices, schemas.Choice{
-				Content:   choice.Content,
-				IsCorrect: &choice.IsCorrect,
-			})
-		}
-
-		if !hasCorrectChoice {
-			log.Printf("No correct choice found for question: %v", q.Content)
-
-			errMessage := "A correct choice must be specified for the question: " + q.Content
-			c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
-				StatusCode: http.StatusBadRequest,
-				Success:    false,
-				Message:    errMessage,
-			})
-			return
-		}
-
-		if len(choices) < 2 {
-			log.Printf("Not enough choices for question: %v", q.Content)
+		question, err := utils.BuildQuestion(q)
+		if err != nil {
+			log.Printf("Invalid question %q: %v", q.Content, err)
 
-			errMessage := "At least two choices must be specified for the question: " + q.Content
 			c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
 				StatusCode: http.StatusBadRequest,
 				Success:    false,
-				Message:    errMessage,
+				Message:    err.Error(),
 			})
-			return
-		}
-
-		question := schemas.Question{
-			Content: q.Content,
-			Choices: choices,
+			return schemas.Quiz{}, false
 		}
 
 		questions = append(questions, question)
@@ -383,44 +458,48 @@ func CreateQuiz(c *gin.Context, db *gorm.DB) {
 			Success:    false,
 			Message:    "Number of questions must be between 2 and 50.",
 		})
-		return
+		return schemas.Quiz{}, false
 	}
 
-	quiz := schemas.Quiz{
-		Name:       reqBody.Name,
-		CategoryID: categoryUuid.String(),
-		CreatedBy:  userUuid.String(),
-		Questions:  questions,
-		ImageUrl:   reqBody.ImageUrl,
-	}
+	return schemas.Quiz{
+		Name:              reqBody.Name,
+		CategoryID:        categoryUuid.String(),
+		CreatedBy:         userUuid.String(),
+		Questions:         questions,
+		QuestionsCount:    len(questions),
+		ImageUrl:          reqBody.ImageUrl,
+		QuestionTimeLimit: reqBody.QuestionTimeLimit,
+	}, true
+}
 
-	if err := gorm.G[schemas.Quiz](db).Create(c, &quiz); err != nil {
-		log.Printf("Error creating quiz: %v", err)
+// moderateQuiz screens the content of a quiz about to be created, quarantining
+// it when flagged.
+func moderateQuiz(ctx context.Context, moderator moderation.Moderator, quiz *schemas.Quiz) {
+	quiz.ModerationStatus = schemas.ModerationApproved
+	if verdict := utils.ModerateContent(ctx, moderator, utils.QuizTexts(*quiz)...); verdict.Flagged {
+		quiz.ModerationStatus = schemas.ModerationFlagged
+		quiz.ModerationReason = verdict.Reason()
+	}
+}
 
-		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
-			StatusCode: http.StatusInternalServerError,
-			Success:    false,
-			Message:    "An error occurred while creating the quiz.",
-		})
+// moderateQuizContent screens content added to an existing quiz, quarantining
+// the quiz when it is flagged. Failing to quarantine it is only logged, as the
+// content is already saved.
+func moderateQuizContent(c *gin.Context, db *gorm.DB, moderator moderation.Moderator, quizID string, texts ...string) {
+	verdict := utils.ModerateContent(c.Request.Context(), moderator, texts...)
+	if !verdict.Flagged {
 		return
 	}
 
-	quiz.Category = nil
-	quiz.User = nil
-	quiz.CreatedAt = nil
-	quiz.UpdatedAt = nil
-
-	c.JSON(http.StatusCreated, gin.H{
-		"statusCode": http.StatusCreated,
-		"success":    true,
-		"data":       quiz,
-	})
+	if err := utils.FlagQuiz(c.Request.Context(), db, quizID, verdict); err != nil {
+		log.Printf("Error quarantining flagged quiz: %v", err)
+	}
 }
 
 // GetQuizByID godoc
 // @Summary Get a quiz by ID
 // @Schemes
-// @Description Retrieve a quiz by its ID
+// @Description Retrieve a quiz by its ID. Logged-in users also get its questions, with the answers only for the author and the admins.
 // @Tags quizzes
 // @Produce json
 // @Param id path string true "Quiz ID"
@@ -430,27 +509,9 @@ func CreateQuiz(c *gin.Context, db *gorm.DB) {
 // @Failure 500 {object} types.InternalServerErrorResponseStruct
 // @Router /quizzes/{quizId} [get]
 func GetQuizByID(c *gin.Context, db *gorm.DB) {
-	tokenStr := middlewares.BearerFromHeader(c)
-
-	var userUuid string
-	if tokenStr != "" {
-		claims, err := auth.ParseAccess(tokenStr)
-		if err != nil {
-			log.Printf("Error parsing access token: %v", err)
-
-			message := "Access token is malformed."
-			if err.Error() == "token expired" {
-				message = "Token has expired."
-			}
-			c.AbortWithStatusJSON(http.StatusForbidden, types.ForbiddenErrorResponseStruct{
-				StatusCode: http.StatusForbidden,
-				Success:    false,
-				Message:    message,
-			})
-			return
-		}
-
-		userUuid = claims.Subject
+	userUuid, ok := optionalUserID(c, db)
+	if !ok {
+		return
 	}
 
 	quizUuid, err := uuid.Parse(c.Param("quizId"))
@@ -466,11 +527,8 @@ func GetQuizByID(c *gin.Context, db *gorm.DB) {
 	}
 
 	quizQueryChain := gorm.G[schemas.Quiz](db).Where("id = ?", quizUuid).
-		Select("id, name, category_id, created_by, curator_pick, image_url, created_at, updated_at").
-		Preload("UserLikes", func(db gorm.PreloadBuilder) error {
-			db.Select("id")
-			return nil
-		}).
+		Where(utils.VisibleQuiz(userUuid, c.GetString("role"))).
+		Select("id, name, category_id, created_by, curator_pick, image_url, question_time_limit, likes, games_played, created_at, updated_at").
 		Preload("Category", func(db gorm.PreloadBuilder) error {
 			db.Select("id, name")
 			return nil
@@ -478,19 +536,7 @@ func GetQuizByID(c *gin.Context, db *gorm.DB) {
 		Preload("User", func(db gorm.PreloadBuilder) error {
 			db.Select("id, username, name")
 			return nil
-		}).
-		Preload("Games", nil)
-
-	if userUuid != "" {
-		quizQueryChain = quizQueryChain.Preload("Questions", func(db gorm.PreloadBuilder) error {
-			db.Select("id, content, quiz_id")
-			return nil
-		}).
-			Preload("Questions.Choices", func(db gorm.PreloadBuilder) error {
-				db.Select("id, content, is_correct, question_id")
-				return nil
-			})
-	}
+		})
 
 	quiz, err := quizQueryChain.First(c)
 	if err != nil {
@@ -513,11 +559,40 @@ func GetQuizByID(c *gin.Context, db *gorm.DB) {
 		return
 	}
 
-	quiz.GamesPlayed = len(quiz.Games)
-	quiz.Likes = len(quiz.UserLikes)
+	if userUuid != "" {
+		// Only the author and the admins see the answers, the other players
+		// would otherwise know them before playing
+		canSeeAnswers := quiz.CreatedBy == userUuid || c.GetString("role") == schemas.RoleAdmin
 
-	quiz.Games = nil
-	quiz.UserLikes = nil
+		questionColumns, choiceColumns := "id, content, type, quiz_id", "id, content, question_id"
+		if canSeeAnswers {
+			questionColumns, choiceColumns = "id, content, type, quiz_id, numeric_answer, numeric_tolerance, explanation", "id, content, is_correct, position, question_id"
+		}
+
+		quiz.Questions, err = gorm.G[schemas.Question](db).Where("quiz_id = ?", quiz.ID).
+			Select(questionColumns).
+			Preload("Choices", func(db gorm.PreloadBuilder) error {
+				db.Select(choiceColumns)
+				return nil
+			}).
+			Find(c)
+		if err != nil {
+			log.Printf("Error fetching quiz questions: %v", err)
+
+			c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+				StatusCode: http.StatusInternalServerError,
+				Success:    false,
+				Message:    "An error occurred while fetching the quiz.",
+			})
+			return
+		}
+
+		if !canSeeAnswers {
+			for i, question := range quiz.Questions {
+				quiz.Questions[i] = utils.PlayerQuestion(question)
+			}
+		}
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"statusCode": http.StatusOK,
@@ -529,7 +604,7 @@ func GetQuizByID(c *gin.Context, db *gorm.DB) {
 // UpdateQuiz godoc
 // @Summary Update a quiz by ID
 // @Schemes
-// @Description Update a quiz by its ID
+// @Description Update a quiz by its ID. Sending question_time_limit as 0 removes the time limit. A name flagged by moderation keeps the quiz out of the public listings until reviewed.
 // @Tags quizzes
 // @Accept json
 // @Produce json
@@ -541,7 +616,7 @@ func GetQuizByID(c *gin.Context, db *gorm.DB) {
 // @Failure 404 {object} types.NotFoundErrorResponseStruct
 // @Failure 500 {object} types.InternalServerErrorResponseStruct
 // @Router /quizzes/{quizId} [patch]
-func UpdateQuiz(c *gin.Context, db *gorm.DB) {
+func UpdateQuiz(c *gin.Context, db *gorm.DB, moderator moderation.Moderator) {
 	quizId := c.Param("quizId")
 
 	quizUuid, err := uuid.Parse(quizId)
@@ -612,6 +687,12 @@ func UpdateQuiz(c *gin.Context, db *gorm.DB) {
 
 	if reqBody.Name != "" {
 		quiz.Name = reqBody.Name
+
+		verdict := utils.ModerateContent(c.Request.Context(), moderator, quiz.Name)
+		if verdict.Flagged && quiz.ModerationStatus != schemas.ModerationHidden {
+			quiz.ModerationStatus = schemas.ModerationFlagged
+			quiz.ModerationReason = verdict.Reason()
+		}
 	}
 
 	if reqBody.CategoryID != "" {
@@ -643,6 +724,21 @@ func UpdateQuiz(c *gin.Context, db *gorm.DB) {
 		quiz.ImageUrl = reqBody.ImageUrl
 	}
 
+	if reqBody.QuestionTimeLimit != nil {
+		if *reqBody.QuestionTimeLimit == 0 {
+			quiz.QuestionTimeLimit = nil
+		} else if *reqBody.QuestionTimeLimit < 5 {
+			c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+				StatusCode: http.StatusBadRequest,
+				Success:    false,
+				Message:    "Question time limit must be between 5 and 300 seconds, or 0 to remove it.",
+			})
+			return
+		} else {
+			quiz.QuestionTimeLimit = reqBody.QuestionTimeLimit
+		}
+	}
+
 	if err := db.Save(&quiz).Error; err != nil {
 		log.Printf("Error updating quiz: %v", err)
 
@@ -804,7 +900,9 @@ func LikeQuiz(c *gin.Context, db *gorm.DB) {
 		return
 	}
 
-	quiz, err := gorm.G[schemas.Quiz](db).Where("id = ?", quizUuid).First(c)
+	quiz, err := gorm.G[schemas.Quiz](db).Where("id = ?", quizUuid).
+		Where(utils.VisibleQuiz(user.ID, c.GetString("role"))).
+		First(c)
 	if err != nil {
 		log.Printf("Error fetching quiz by ID: %v", err)
 
@@ -825,7 +923,9 @@ func LikeQuiz(c *gin.Context, db *gorm.DB) {
 		return
 	}
 
-	err = db.Model(&quiz).Association("UserLikes").Append(&user)
+	err = db.Transaction(func(tx *gorm.DB) error {
+		return utils.AddQuizLike(tx, quiz.ID, user.ID)
+	})
 	if err != nil {
 		log.Printf("Error liking quiz: %v", err)
 
@@ -918,7 +1018,9 @@ func DislikeQuiz(c *gin.Context, db *gorm.DB) {
 		return
 	}
 
-	err = db.Model(&quiz).Association("UserLikes").Delete(&user)
+	err = db.Transaction(func(tx *gorm.DB) error {
+		return utils.RemoveQuizLike(tx, quiz.ID, user.ID)
+	})
 	if err != nil {
 		log.Printf("Error unliking quiz: %v", err)
 
@@ -936,3 +1038,96 @@ func DislikeQuiz(c *gin.Context, db *gorm.DB) {
 		"message":    "Quiz disliked successfully.",
 	})
 }
+
+// SetCuratorPick godoc
+// @Summary Set whether a quiz is a curator pick
+// @Schemes
+// @Description Feature a quiz as a curator pick or remove it from the picks. Only approved quizzes can be picked. Curators and admins only.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param quizId path string true "Quiz ID"
+// @Param data body types.SetCuratorPickRequestBody true "Set Curator Pick Request Body"
+// @Success 200 {object} types.SuccessResponseStruct
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 404 {object} types.NotFoundErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /admin/quizzes/{quizId}/curator-pick [patch]
+func SetCuratorPick(c *gin.Context, db *gorm.DB) {
+	quizUuid, err := uuid.Parse(c.Param("quizId"))
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Invalid quiz ID format.",
+		})
+		return
+	}
+
+	var reqBody types.SetCuratorPickRequestBody
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		log.Printf("Error parsing request body: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "An error occurred while parsing the request body.",
+		})
+		return
+	}
+
+	quiz, err := gorm.G[schemas.Quiz](db).Where("id = ?", quizUuid).
+		Select("id, moderation_status").
+		First(c.Request.Context())
+	if err != nil {
+		log.Printf("Error fetching quiz by ID: %v", err)
+
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, types.NotFoundErrorResponseStruct{
+				StatusCode: http.StatusNotFound,
+				Success:    false,
+				Message:    "Quiz not found.",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while fetching the quiz.",
+		})
+		return
+	}
+
+	if *reqBody.CuratorPick && quiz.ModerationStatus != schemas.ModerationApproved {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Only approved quizzes can be curator picks.",
+		})
+		return
+	}
+
+	_, err = gorm.G[schemas.Quiz](db).
+		Where("id = ?", quiz.ID).
+		Update(c.Request.Context(), "curator_pick", *reqBody.CuratorPick)
+	if err != nil {
+		log.Printf("Error updating curator pick: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while updating the curator pick.",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.SuccessResponseStruct{
+		StatusCode: http.StatusOK,
+		Success:    true,
+		Message:    "Curator pick updated successfully.",
+	})
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"intelliquiz/src/database/schemas"
+	"intelliquiz/src/feed"
+	"intelliquiz/src/types"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GetRecommendations godoc
+// @Summary Get recommended quizzes
+// @Schemes
+// @Description Recommend quizzes to play next, similar to the ones the authenticated user liked or finished, as played and liked by the same users, and from the categories the user plays the most. Users who did not play or like any quiz yet get the most liked quizzes. Quizzes the user created, finished or liked are left out.
+// @Tags quizzes
+// @Produce json
+// @Param limit query int false "Number of quizzes (min: 5, max: 50)" default(10)
+// @Success 200 {object} types.RecommendedQuizzesSuccessResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /me/recommendations [get]
+func GetRecommendations(c *gin.Context, db *gorm.DB, recommender *feed.Recommender) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	limit = max(5, min(50, limit))
+
+	userUuid, err := uuid.Parse(c.MustGet("userID").(string))
+	if err != nil {
+		c.JSON(http.StatusForbidden, types.ForbiddenErrorResponseStruct{
+			StatusCode: http.StatusForbidden,
+			Success:    false,
+			Message:    "Invalid user ID format on claims.",
+		})
+		return
+	}
+
+	quizzes, err := recommender.ForUser(c.Request.Context(), userUuid.String(), limit)
+	if err != nil {
+		log.Printf("Error recommending quizzes: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while recommending quizzes.",
+		})
+		return
+	}
+	if quizzes == nil {
+		quizzes = []schemas.Quiz{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"statusCode": http.StatusOK,
+		"success":    true,
+		"data":       gin.H{"quizzes": quizzes},
+	})
+}
+
+// GetSimilarQuizzes godoc
+// @Summary Get quizzes similar to a quiz
+// @Schemes
+// @Description Retrieve the quizzes most similar to a quiz, as played and liked by the same users, favoring the ones of its category. Quizzes of its category fill in when few users played it.
+// @Tags quizzes
+// @Produce json
+// @Param quizId path string true "Quiz ID"
+// @Param limit query int false "Number of quizzes (min: 5, max: 50)" default(10)
+// @Success 200 {object} types.RecommendedQuizzesSuccessResponseStruct
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 404 {object} types.NotFoundErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /quizzes/{quizId}/similar [get]
+func GetSimilarQuizzes(c *gin.Context, db *gorm.DB, recommender *feed.Recommender) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	limit = max(5, min(50, limit))
+
+	quizUuid, err := uuid.Parse(c.Param("quizId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Invalid quiz ID format.",
+		})
+		return
+	}
+
+	quiz, err := gorm.G[schemas.Quiz](db).
+		Select("id, category_id").
+		Where("id = ? AND moderation_status = ?", quizUuid, schemas.ModerationApproved).
+		First(c)
+	if err != nil {
+		log.Printf("Error fetching quiz by ID: %v", err)
+
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, types.NotFoundErrorResponseStruct{
+				StatusCode: http.StatusNotFound,
+				Success:    false,
+				Message:    "Quiz not found.",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while fetching the quiz.",
+		})
+		return
+	}
+
+	quizzes, err := recommender.Similar(c.Request.Context(), quiz, limit)
+	if err != nil {
+		log.Printf("Error fetching similar quizzes: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while fetching similar quizzes.",
+		})
+		return
+	}
+	if quizzes == nil {
+		quizzes = []schemas.Quiz{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"statusCode": http.StatusOK,
+		"success":    true,
+		"data":       gin.H{"quizzes": quizzes},
+	})
+}
@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"intelliquiz/src/database/schemas"
+	"intelliquiz/src/types"
+	"intelliquiz/src/utils"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReportQuiz godoc
+// @Summary Report a quiz
+// @Schemes
+// @Description Report a quiz with offensive, spam or infringing content, or a wrong answer key, for the admins to review. The reporter is notified of the outcome.
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param quizId path string true "Quiz ID"
+// @Param data body types.ReportRequestBody true "Report Request Body"
+// @Success 201 {object} types.CreateReportSuccessResponseStruct
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 404 {object} types.NotFoundErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /quizzes/{quizId}/report [post]
+func ReportQuiz(c *gin.Context, db *gorm.DB) {
+	quizUuid, err := uuid.Parse(c.Param("quizId"))
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Invalid quiz ID format.",
+		})
+		return
+	}
+
+	quiz, err := gorm.G[schemas.Quiz](db).Where("id = ?", quizUuid).
+		Where(utils.VisibleQuiz(c.GetString("userID"), c.GetString("role"))).
+		Select("id, created_by").
+		First(c.Request.Context())
+	if err != nil {
+		log.Printf("Error fetching quiz by ID: %v", err)
+
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, types.NotFoundErrorResponseStruct{
+				StatusCode: http.StatusNotFound,
+				Success:    false,
+				Message:    "Quiz not found.",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while fetching the quiz.",
+		})
+		return
+	}
+
+	createReport(c, db, schemas.Report{QuizID: quiz.ID}, quiz.CreatedBy, "quiz")
+}
+
+// ReportQuestion godoc
+// @Summary Report a question
+// @Schemes
+// @Description Report a question with a wrong answer key, or offensive, spam or infringing content, for the admins to review. The reporter is notified of the outcome.
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param questionId path string true "Question ID"
+// @Param data body types.ReportRequestBody true "Report Request Body"
+// @Success 201 {object} types.CreateReportSuccessResponseStruct
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 404 {object} types.NotFoundErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /questions/{questionId}/report [post]
+func ReportQuestion(c *gin.Context, db *gorm.DB) {
+	questionUuid, err := uuid.Parse(c.Param("questionId"))
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Invalid question ID format.",
+		})
+		return
+	}
+
+	question, err := gorm.G[schemas.Question](db).Where("id = ?", questionUuid).
+		Select("id, quiz_id").
+		Preload("Quiz", func(db gorm.PreloadBuilder) error {
+			db.Select("id, created_by").
+				Where(utils.VisibleQuiz(c.GetString("userID"), c.GetString("role")))
+			return nil
+		}).
+		First(c.Request.Context())
+	if err != nil || question.Quiz == nil {
+		log.Printf("Error fetching question by ID: %v", err)
+
+		if err == gorm.ErrRecordNotFound || question.Quiz == nil {
+			c.JSON(http.StatusNotFound, types.NotFoundErrorResponseStruct{
+				StatusCode: http.StatusNotFound,
+				Success:    false,
+				Message:    "Question not found.",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while fetching the question.",
+		})
+		return
+	}
+
+	createReport(c, db, schemas.Report{QuizID: question.QuizID, QuestionID: &question.ID}, question.Quiz.CreatedBy, "question")
+}
+
+// createReport files the report of the authenticated user against content
+// owned by ownerID. Users cannot report their own content, nor report the same
+// content again while their previous report is open.
+func createReport(c *gin.Context, db *gorm.DB, report schemas.Report, ownerID string, target string) {
+	var reqBody types.ReportRequestBody
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		log.Printf("Error parsing request body: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "An error occurred while parsing the request body.",
+		})
+		return
+	}
+
+	userUuid, err := uuid.Parse(c.MustGet("userID").(string))
+	if err != nil {
+		log.Printf("Error parsing User UUID from context: %v", err)
+
+		c.JSON(http.StatusForbidden, types.ForbiddenErrorResponseStruct{
+			StatusCode: http.StatusForbidden,
+			Success:    false,
+			Message:    "Invalid user ID format on claims.",
+		})
+		return
+	}
+
+	if ownerID == userUuid.String() {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "You cannot report your own " + target + ".",
+		})
+		return
+	}
+
+	openReports := gorm.G[schemas.Report](db).
+		Where("reporter_id = ? AND quiz_id = ? AND status = ?", userUuid.String(), report.QuizID, schemas.ReportStatusOpen)
+	if report.QuestionID != nil {
+		openReports = openReports.Where("question_id = ?", *report.QuestionID)
+	} else {
+		openReports = openReports.Where("question_id IS NULL")
+	}
+	openReportsCount, err := openReports.Count(c.Request.Context(), "id")
+	if err != nil {
+		log.Printf("Error counting open reports: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while verifying previous reports.",
+		})
+		return
+	}
+	if openReportsCount > 0 {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "You have already reported this " + target + ".",
+		})
+		return
+	}
+
+	report.ReporterID = userUuid.String()
+	report.Reason = reqBody.Reason
+	report.Comment = strings.TrimSpace(reqBody.Comment)
+	report.Status = schemas.ReportStatusOpen
+
+	if err := gorm.G[schemas.Report](db).Create(c.Request.Context(), &report); err != nil {
+		log.Printf("Error creating report: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while creating the report.",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"statusCode": http.StatusCreated,
+		"success":    true,
+		"data":       report,
+	})
+}
@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"errors"
+	"intelliquiz/src/auth"
+	"intelliquiz/src/database/schemas"
+	"intelliquiz/src/middlewares"
+	"intelliquiz/src/rooms"
+	"intelliquiz/src/types"
+	"intelliquiz/src/utils"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"gorm.io/gorm"
+)
+
+// Tokens are sent explicitly by the client instead of relying on cookies, so
+// cross-origin upgrades are accepted and authorization happens on the token.
+var roomUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin: func(r *http.Request) bool {
+		return true
+	},
+}
+
+// CreateRoom godoc
+// @Summary Create a multiplayer room
+// @Schemes
+// @Description Open a live room for a quiz. Players join the room through its WebSocket using the returned code, and the host starts it by sending {"type": "start"}. If the host leaves the lobby, the player who joined next becomes the host. Without question_seconds, questions last as long as the time limit of the quiz, or 20 seconds when it has none.
+// @Tags rooms
+// @Accept json
+// @Produce json
+// @Param data body types.CreateRoomRequestBody true "Create Room Request Body"
+// @Success 201 {object} types.CreateRoomResponseStruct
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 404 {object} types.NotFoundErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /rooms [post]
+func CreateRoom(c *gin.Context, db *gorm.DB, hub *rooms.Hub) {
+	userUuid, err := uuid.Parse(c.MustGet("userID").(string))
+	if err != nil {
+		log.Printf("Error parsing User UUID from context: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.ForbiddenErrorResponseStruct{
+			StatusCode: http.StatusForbidden,
+			Success:    false,
+			Message:    "Invalid user ID format on claims.",
+		})
+		return
+	}
+
+	var reqBody types.CreateRoomRequestBody
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		log.Printf("Error parsing request body: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "An error occurred while parsing the request body.",
+		})
+		return
+	}
+
+	quizUuid, err := uuid.Parse(reqBody.QuizID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Invalid quiz ID format.",
+		})
+		return
+	}
+
+	quiz, err := gorm.G[schemas.Quiz](db).Where("id = ?", quizUuid).
+		Where(utils.VisibleQuiz(userUuid.String(), c.GetString("role"))).
+		Preload("Questions", func(db gorm.PreloadBuilder) error {
+			db.Select("id, quiz_id, content, type, numeric_answer, numeric_tolerance")
+			return nil
+		}).
+		Preload("Questions.Choices", func(db gorm.PreloadBuilder) error {
+			db.Select("id, question_id, content, is_correct, position")
+			return nil
+		}).
+		First(c)
+	if err != nil {
+		log.Printf("Error retrieving quiz from database: %v", err)
+
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, types.NotFoundErrorResponseStruct{
+				StatusCode: http.StatusNotFound,
+				Success:    false,
+				Message:    "Quiz not found.",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "Internal server error while retrieving quiz.",
+		})
+		return
+	}
+
+	if len(quiz.Questions) == 0 {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Quiz has no questions.",
+		})
+		return
+	}
+
+	if reqBody.QuestionSeconds == 0 {
+		reqBody.QuestionSeconds = 20
+		if quiz.QuestionTimeLimit != nil {
+			reqBody.QuestionSeconds = int(*quiz.QuestionTimeLimit)
+		}
+	}
+
+	room, err := hub.Create(userUuid.String(), quiz, time.Duration(reqBody.QuestionSeconds)*time.Second)
+	if err != nil {
+		log.Printf("Error creating room: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "Internal server error while creating room.",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, types.CreateRoomResponseStruct{
+		StatusCode: http.StatusCreated,
+		Success:    true,
+		Data: types.CreateRoomDataStruct{
+			Code:            room.Code,
+			QuizID:          room.QuizID,
+			HostID:          room.HostID(),
+			QuestionSeconds: reqBody.QuestionSeconds,
+			TotalQuestions:  room.TotalQuestions(),
+		},
+	})
+}
+
+// JoinRoom godoc
+// @Summary Join a multiplayer room
+// @Schemes
+// @Description Upgrade to a WebSocket connected to the room. Browsers can't set headers on WebSocket requests, so the access token may be sent in the token query parameter. Server messages are types.RoomMessage envelopes (lobby, question, answer_result, leaderboard, error); clients send types.RoomIncomingMessage (start, answer).
+// @Tags rooms
+// @Param code path string true "Room code"
+// @Param token query string false "Access token, when the Authorization header can't be set"
+// @Success 101
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 404 {object} types.NotFoundErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /rooms/{code}/ws [get]
+func JoinRoom(c *gin.Context, db *gorm.DB, hub *rooms.Hub) {
+	tokenStr := middlewares.BearerFromHeader(c)
+	if tokenStr == "" {
+		tokenStr = c.Query("token")
+	}
+
+	claims, err := auth.ParseAccess(tokenStr)
+	if err != nil {
+		c.JSON(http.StatusForbidden, types.ForbiddenErrorResponseStruct{
+			StatusCode: http.StatusForbidden,
+			Success:    false,
+			Message:    "Forbidden",
+		})
+		return
+	}
+
+	active, err := auth.IsSessionActive(c.Request.Context(), db, claims.SessionID)
+	if err != nil {
+		log.Printf("Error verifying session: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while verifying the session.",
+		})
+		return
+	}
+	if !active {
+		c.JSON(http.StatusForbidden, types.ForbiddenErrorResponseStruct{
+			StatusCode: http.StatusForbidden,
+			Success:    false,
+			Message:    "Session has been revoked.",
+		})
+		return
+	}
+
+	room, err := hub.Get(c.Param("code"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, types.NotFoundErrorResponseStruct{
+			StatusCode: http.StatusNotFound,
+			Success:    false,
+			Message:    "Room not found.",
+		})
+		return
+	}
+
+	if err := room.CanJoin(claims.Subject); err != nil {
+		if errors.Is(err, rooms.ErrRoomNotFound) {
+			c.JSON(http.StatusNotFound, types.NotFoundErrorResponseStruct{
+				StatusCode: http.StatusNotFound,
+				Success:    false,
+				Message:    "Room not found.",
+			})
+			return
+		}
+
+		c.JSON(http.StatusForbidden, types.ForbiddenErrorResponseStruct{
+			StatusCode: http.StatusForbidden,
+			Success:    false,
+			Message:    "Room has already started.",
+		})
+		return
+	}
+
+	user, err := gorm.G[schemas.User](db).
+		Where("id = ?", claims.Subject).
+		Select("id, username").
+		First(c)
+	if err != nil {
+		log.Printf("Error fetching user by ID: %v", err)
+
+		c.JSON(http.StatusForbidden, types.ForbiddenErrorResponseStruct{
+			StatusCode: http.StatusForbidden,
+			Success:    false,
+			Message:    "Authenticated user not found.",
+		})
+		return
+	}
+
+	conn, err := roomUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Printf("Error upgrading room connection: %v", err)
+		return
+	}
+
+	room.Serve(user.ID, user.Username, conn)
+}
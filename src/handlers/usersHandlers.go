@@ -1,10 +1,14 @@
 package handlers
 
 import (
+	"intelliquiz/src/auth"
 	"intelliquiz/src/database/schemas"
 	"intelliquiz/src/types"
+	"intelliquiz/src/utils"
 	"log"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	uuidG "github.com/google/uuid"
@@ -14,16 +18,45 @@ import (
 // GetUsers godoc
 // @Summary Get all users
 // @Schemes
-// @Description Retrieve a list of all users
+// @Description Retrieve a page of users, sorted by username
 // @Tags users
 // @Produce json
+// @Param limit query int false "Limit of users per page (min: 5, max: 50)" default(10)
+// @Param cursor query string false "Cursor of the page, taken from next_cursor or prev_cursor"
+// @Param page query int false "Page number (0-indexed), used without a cursor" default(0)
 // @Success 200 {object} types.GetUsersSuccessResponseStruct
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
 // @Failure 403 {object} types.ForbiddenErrorResponseStruct
 // @Failure 500 {object} types.InternalServerErrorResponseStruct
 // @Router /users [get]
 func GetUsers(c *gin.Context, db *gorm.DB) {
+	pagination, paginate, ok := parsePagination(c, "username", []utils.SortKey{
+		{Column: "users.username"},
+		{Column: "users.id", Parse: utils.UUIDCursorValue},
+	})
+	if !ok {
+		return
+	}
+
+	var usersCount int64
+	var err error
+	if !pagination.UsesCursor() {
+		usersCount, err = gorm.G[schemas.User](db).Count(c, "id")
+		if err != nil {
+			log.Printf("Error counting users: %v", err)
+
+			c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+				StatusCode: http.StatusInternalServerError,
+				Success:    false,
+				Message:    "An error occurred while fetching users",
+			})
+			return
+		}
+	}
+
 	users, err := gorm.G[schemas.User](db).
 		Select("id, username, name").
+		Scopes(paginate).
 		Find(c)
 	if err != nil {
 		log.Printf("Error fetching users: %v", err)
@@ -36,10 +69,26 @@ func GetUsers(c *gin.Context, db *gorm.DB) {
 		return
 	}
 
+	users, cursors := utils.PageItems(pagination, "username", users, func(user schemas.User) []any {
+		return []any{user.Username, user.ID}
+	})
+	if len(users) == 0 {
+		users = []schemas.User{}
+	}
+
+	data := gin.H{
+		"users":       users,
+		"next_cursor": cursors.Next,
+		"prev_cursor": cursors.Prev,
+	}
+	if !pagination.UsesCursor() {
+		data["maxPage"] = pagination.MaxPage(usersCount)
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"statusCode": http.StatusOK,
 		"success":    true,
-		"data":       users,
+		"data":       data,
 	})
 }
 
@@ -129,7 +178,7 @@ func GetOwnUser(c *gin.Context, db *gorm.DB) {
 
 	user, err := gorm.G[schemas.User](db).
 		Where("id = ?", userUuid.String()).
-		Select("id, username, name, email").
+		Select("id, username, name, email, role").
 		First(c)
 
 	if err != nil {
@@ -283,7 +332,9 @@ func UpdateUser(c *gin.Context, db *gorm.DB) {
 		user.Name = reqBody.Name
 	}
 
-	if err := db.Save(&user).Error; err != nil {
+	// The role and suspension are managed by the admins and may change
+	// while the user is being updated
+	if err := db.Omit("role", "suspended_at", "suspension_reason").Save(&user).Error; err != nil {
 		log.Printf("Error updating user: %v", err)
 
 		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
@@ -300,3 +351,256 @@ func UpdateUser(c *gin.Context, db *gorm.DB) {
 		Message:    "User updated successfully.",
 	})
 }
+
+// UpdateUserRole godoc
+// @Summary Change the role of a user
+// @Schemes
+// @Description Change the role of a user. The user sessions are revoked when the role changes, so the new role applies right away. Admins cannot change their own role. Admin only.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param data body types.UpdateUserRoleRequestBody true "Update User Role Request Body"
+// @Success 200 {object} types.SuccessResponseStruct
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 404 {object} types.NotFoundErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /admin/users/{userId}/role [patch]
+func UpdateUserRole(c *gin.Context, db *gorm.DB) {
+	var reqBody types.UpdateUserRoleRequestBody
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		log.Printf("Error parsing request body: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "An error occurred while parsing the request body.",
+		})
+		return
+	}
+
+	user, ok := findManagedUser(c, db)
+	if !ok {
+		return
+	}
+
+	if user.Role != reqBody.Role {
+		err := db.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+			_, err := gorm.G[schemas.User](tx).
+				Where("id = ?", user.ID).
+				Update(c.Request.Context(), "role", reqBody.Role)
+			if err != nil {
+				return err
+			}
+
+			// The role is carried by the access tokens already issued
+			return auth.RevokeUserSessions(c.Request.Context(), tx, user.ID)
+		})
+		if err != nil {
+			log.Printf("Error updating user role: %v", err)
+
+			c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+				StatusCode: http.StatusInternalServerError,
+				Success:    false,
+				Message:    "An error occurred while updating the user role.",
+			})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, types.SuccessResponseStruct{
+		StatusCode: http.StatusOK,
+		Success:    true,
+		Message:    "User role updated successfully.",
+	})
+}
+
+// SuspendUser godoc
+// @Summary Suspend a user
+// @Schemes
+// @Description Suspend a user, revoking their sessions and keeping them from logging in until unsuspended. Admins cannot be suspended. Admin only.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param data body types.SuspendUserRequestBody false "Suspend User Request Body"
+// @Success 200 {object} types.SuccessResponseStruct
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 404 {object} types.NotFoundErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /admin/users/{userId}/suspend [post]
+func SuspendUser(c *gin.Context, db *gorm.DB) {
+	var reqBody types.SuspendUserRequestBody
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&reqBody); err != nil {
+			log.Printf("Error parsing request body: %v", err)
+
+			c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+				StatusCode: http.StatusBadRequest,
+				Success:    false,
+				Message:    "An error occurred while parsing the request body.",
+			})
+			return
+		}
+	}
+
+	user, ok := findManagedUser(c, db)
+	if !ok {
+		return
+	}
+
+	if user.Role == schemas.RoleAdmin {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Admins cannot be suspended.",
+		})
+		return
+	}
+	if user.SuspendedAt != nil {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "User is already suspended.",
+		})
+		return
+	}
+
+	err := db.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&schemas.User{}).
+			Where("id = ?", user.ID).
+			Updates(map[string]any{
+				"suspended_at":      time.Now(),
+				"suspension_reason": strings.TrimSpace(reqBody.Reason),
+			}).
+			Error
+		if err != nil {
+			return err
+		}
+
+		return auth.RevokeUserSessions(c.Request.Context(), tx, user.ID)
+	})
+	if err != nil {
+		log.Printf("Error suspending user: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while suspending the user.",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.SuccessResponseStruct{
+		StatusCode: http.StatusOK,
+		Success:    true,
+		Message:    "User suspended successfully.",
+	})
+}
+
+// UnsuspendUser godoc
+// @Summary Lift the suspension of a user
+// @Schemes
+// @Description Lift the suspension of a user, letting them log in again. Admin only.
+// @Tags admin
+// @Produce json
+// @Param userId path string true "User ID"
+// @Success 200 {object} types.SuccessResponseStruct
+// @Failure 400 {object} types.BadRequestErrorResponseStruct
+// @Failure 403 {object} types.ForbiddenErrorResponseStruct
+// @Failure 404 {object} types.NotFoundErrorResponseStruct
+// @Failure 500 {object} types.InternalServerErrorResponseStruct
+// @Router /admin/users/{userId}/unsuspend [post]
+func UnsuspendUser(c *gin.Context, db *gorm.DB) {
+	user, ok := findManagedUser(c, db)
+	if !ok {
+		return
+	}
+
+	if user.SuspendedAt == nil {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "User is not suspended.",
+		})
+		return
+	}
+
+	err := db.WithContext(c.Request.Context()).Model(&schemas.User{}).
+		Where("id = ?", user.ID).
+		Updates(map[string]any{
+			"suspended_at":      nil,
+			"suspension_reason": "",
+		}).
+		Error
+	if err != nil {
+		log.Printf("Error unsuspending user: %v", err)
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while unsuspending the user.",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.SuccessResponseStruct{
+		StatusCode: http.StatusOK,
+		Success:    true,
+		Message:    "User unsuspended successfully.",
+	})
+}
+
+// findManagedUser fetches the user of the userId path parameter for an admin
+// to manage, writing the error response when it fails. Admins cannot manage
+// themselves, so they do not lock themselves out by mistake.
+func findManagedUser(c *gin.Context, db *gorm.DB) (schemas.User, bool) {
+	userUuid, err := uuidG.Parse(c.Param("userId"))
+	if err != nil {
+		log.Printf("Error parsing UUID: %v", err)
+
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "Invalid user ID format.",
+		})
+		return schemas.User{}, false
+	}
+
+	if userUuid.String() == c.GetString("userID") {
+		c.JSON(http.StatusBadRequest, types.BadRequestErrorResponseStruct{
+			StatusCode: http.StatusBadRequest,
+			Success:    false,
+			Message:    "You cannot manage your own account.",
+		})
+		return schemas.User{}, false
+	}
+
+	user, err := gorm.G[schemas.User](db).
+		Where("id = ?", userUuid.String()).
+		Select("id, role, suspended_at").
+		First(c.Request.Context())
+	if err != nil {
+		log.Printf("Error fetching user by ID: %v", err)
+
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, types.NotFoundErrorResponseStruct{
+				StatusCode: http.StatusNotFound,
+				Success:    false,
+				Message:    "User not found.",
+			})
+			return schemas.User{}, false
+		}
+
+		c.JSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+			StatusCode: http.StatusInternalServerError,
+			Success:    false,
+			Message:    "An error occurred while fetching the user.",
+		})
+		return schemas.User{}, false
+	}
+
+	return user, true
+}
@@ -1,19 +1,30 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"intelliquiz/src/ai"
 	"intelliquiz/src/database/schemas"
 	"intelliquiz/src/database/seeders"
 	"intelliquiz/src/docs"
+	"intelliquiz/src/feed"
 	"intelliquiz/src/handlers"
+	"intelliquiz/src/jobs"
 	"intelliquiz/src/middlewares"
+	"intelliquiz/src/moderation"
+	"intelliquiz/src/rooms"
+	"intelliquiz/src/utils"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
-	openai "github.com/sashabaranov/go-openai"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
@@ -29,10 +40,127 @@ func dotEnvLoader() {
 	}
 }
 
-func setupRouter(db *gorm.DB, openAIClient *openai.Client) *gin.Engine {
+// aiBudgetFromEnv reads the AI token quotas and prices. Unset quotas mean no
+// limit.
+func aiBudgetFromEnv() (ai.Budget, error) {
+	var budget ai.Budget
+	quotas := map[string]*int64{
+		"AI_DAILY_TOKEN_QUOTA":          &budget.DailyTokens,
+		"AI_MONTHLY_TOKEN_QUOTA":        &budget.MonthlyTokens,
+		"AI_GLOBAL_MONTHLY_TOKEN_QUOTA": &budget.GlobalMonthlyTokens,
+	}
+	for name, quota := range quotas {
+		if value := os.Getenv(name); value != "" {
+			parsed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return ai.Budget{}, fmt.Errorf("%s: %w", name, err)
+			}
+			*quota = parsed
+		}
+	}
+
+	prices := map[string]*float64{
+		"AI_PROMPT_TOKEN_PRICE":     &budget.PromptTokenPrice,
+		"AI_COMPLETION_TOKEN_PRICE": &budget.CompletionTokenPrice,
+	}
+	for name, price := range prices {
+		if value := os.Getenv(name); value != "" {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return ai.Budget{}, fmt.Errorf("%s: %w", name, err)
+			}
+			*price = parsed
+		}
+	}
+
+	return budget, nil
+}
+
+// aiCacheConfigFromEnv reads the cache of AI autocompletions. AI_CACHE is
+// memory, redis or none, defaulting to memory.
+func aiCacheConfigFromEnv() (ai.CacheConfig, error) {
+	config := ai.CacheConfig{
+		Backend:  os.Getenv("AI_CACHE"),
+		RedisURL: os.Getenv("REDIS_URL"),
+	}
+
+	if value := os.Getenv("AI_CACHE_SIZE"); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil {
+			return ai.CacheConfig{}, fmt.Errorf("AI_CACHE_SIZE: %w", err)
+		}
+		config.Size = size
+	}
+
+	if value := os.Getenv("AI_CACHE_TTL"); value != "" {
+		ttl, err := time.ParseDuration(value)
+		if err != nil {
+			return ai.CacheConfig{}, fmt.Errorf("AI_CACHE_TTL: %w", err)
+		}
+		config.TTL = ttl
+	}
+
+	return config, nil
+}
+
+// aiResilienceFromEnv reads how AI calls are retried and timed out. Unset
+// values use the defaults.
+func aiResilienceFromEnv() (ai.ResilienceConfig, error) {
+	var config ai.ResilienceConfig
+	counts := map[string]*int{
+		"AI_MAX_ATTEMPTS":      &config.MaxAttempts,
+		"AI_BREAKER_THRESHOLD": &config.BreakerThreshold,
+	}
+	for name, count := range counts {
+		if value := os.Getenv(name); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				return ai.ResilienceConfig{}, fmt.Errorf("%s: %w", name, err)
+			}
+			*count = parsed
+		}
+	}
+
+	durations := map[string]*time.Duration{
+		"AI_CALL_TIMEOUT":     &config.CallTimeout,
+		"AI_STREAM_TIMEOUT":   &config.StreamTimeout,
+		"AI_BREAKER_COOLDOWN": &config.BreakerCooldown,
+	}
+	for name, duration := range durations {
+		if value := os.Getenv(name); value != "" {
+			parsed, err := time.ParseDuration(value)
+			if err != nil {
+				return ai.ResilienceConfig{}, fmt.Errorf("%s: %w", name, err)
+			}
+			*duration = parsed
+		}
+	}
+
+	return config, nil
+}
+
+// durationFromEnv reads a duration such as 30m or 1h, falling back to the
+// default when unset.
+func durationFromEnv(name string, fallback time.Duration) (time.Duration, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return duration, nil
+}
+
+func setupRouter(db *gorm.DB, aiProvider ai.AIProvider, aiBudget ai.Budget, moderator moderation.Moderator, roomHub *rooms.Hub, homeFeed *feed.Feed, recommender *feed.Recommender) *gin.Engine {
 	// Disable Console Color
 	// gin.DisableConsoleColor()
-	r := gin.Default()
+	// The default logger would print the access tokens sent in query strings
+	r := gin.New()
+	r.Use(middlewares.LoggerMiddleware(), gin.Recovery())
 
 	isProduction := os.Getenv("GIN_MODE") == "production"
 	if isProduction {
@@ -68,12 +196,17 @@ func setupRouter(db *gorm.DB, openAIClient *openai.Client) *gin.Engine {
 	rateLimited.POST("/refresh", func(c *gin.Context) { handlers.Refresh(c, db) })
 
 	// Home Page Routes
-	rateLimited.GET("/homepage", func(c *gin.Context) { handlers.HomePage(c, db) })
+	rateLimited.GET("/homepage", func(c *gin.Context) { handlers.HomePage(c, db, homeFeed) })
 
-	jwtAuthorized := rateLimited.Group("", middlewares.JWTTokenMiddleware())
+	jwtAuthorized := rateLimited.Group("", middlewares.JWTTokenMiddleware(db))
+
+	// Session Routes
+	jwtAuthorized.POST("/logout", func(c *gin.Context) { handlers.Logout(c, db) })
+	jwtAuthorized.POST("/logout-all", func(c *gin.Context) { handlers.LogoutAll(c, db) })
 
 	// User Routes
 	jwtAuthorized.GET("/me", func(c *gin.Context) { handlers.GetOwnUser(c, db) })
+	jwtAuthorized.GET("/me/ai-usage", func(c *gin.Context) { handlers.GetOwnAIUsage(c, db, aiBudget) })
 	jwtAuthorized.GET("/users", func(c *gin.Context) { handlers.GetUsers(c, db) })
 	jwtAuthorized.GET("/users/:userId", func(c *gin.Context) { handlers.GetUserByID(c, db) })
 	jwtAuthorized.PATCH("/users/:userId", func(c *gin.Context) { handlers.UpdateUser(c, db) })
@@ -86,39 +219,85 @@ func setupRouter(db *gorm.DB, openAIClient *openai.Client) *gin.Engine {
 	// Public Quiz Routes
 	rateLimited.GET("/quizzes", func(c *gin.Context) { handlers.GetQuizzes(c, db) })
 	rateLimited.GET("/quizzes/:quizId", func(c *gin.Context) { handlers.GetQuizByID(c, db) })
+	rateLimited.GET("/quizzes/:quizId/similar", func(c *gin.Context) { handlers.GetSimilarQuizzes(c, db, recommender) })
 
 	// Protected Quiz Routes
 	jwtAuthorized.GET("/me/quizzes", func(c *gin.Context) { handlers.GetOwnQuizzes(c, db) })
-	jwtAuthorized.POST("/quizzes", func(c *gin.Context) { handlers.CreateQuiz(c, db) })
-	jwtAuthorized.PATCH("/quizzes/:quizId", func(c *gin.Context) { handlers.UpdateQuiz(c, db) })
+	jwtAuthorized.GET("/me/recommendations", func(c *gin.Context) { handlers.GetRecommendations(c, db, recommender) })
+	jwtAuthorized.POST("/quizzes", func(c *gin.Context) { handlers.CreateQuiz(c, db, moderator) })
+	jwtAuthorized.PATCH("/quizzes/:quizId", func(c *gin.Context) { handlers.UpdateQuiz(c, db, moderator) })
 	jwtAuthorized.DELETE("/quizzes/:quizId", func(c *gin.Context) { handlers.DeleteQuiz(c, db) })
 	jwtAuthorized.POST("/quizzes/:quizId/like", func(c *gin.Context) { handlers.LikeQuiz(c, db) })
 	jwtAuthorized.POST("/quizzes/:quizId/dislike", func(c *gin.Context) { handlers.DislikeQuiz(c, db) })
+	jwtAuthorized.POST("/quizzes/import", func(c *gin.Context) { handlers.ImportQuiz(c, db, moderator) })
+	jwtAuthorized.GET("/quizzes/:quizId/export", func(c *gin.Context) { handlers.ExportQuiz(c, db) })
+	jwtAuthorized.POST("/quizzes/:quizId/report", func(c *gin.Context) { handlers.ReportQuiz(c, db) })
 
 	// Question Routes
-	jwtAuthorized.POST("/questions", func(c *gin.Context) { handlers.CreateQuestion(c, db) })
-	jwtAuthorized.PATCH("/questions/:questionId", func(c *gin.Context) { handlers.UpdateQuestion(c, db) })
+	jwtAuthorized.POST("/questions", func(c *gin.Context) { handlers.CreateQuestion(c, db, moderator) })
+	jwtAuthorized.PATCH("/questions/:questionId", func(c *gin.Context) { handlers.UpdateQuestion(c, db, moderator) })
 	jwtAuthorized.DELETE("/questions/:questionId", func(c *gin.Context) { handlers.DeleteQuestion(c, db) })
+	jwtAuthorized.POST("/questions/:questionId/report", func(c *gin.Context) { handlers.ReportQuestion(c, db) })
 
 	// Choice Routes
 	jwtAuthorized.GET("/questions/:questionId/choices", func(c *gin.Context) { handlers.GetChoices(c, db) })
-	jwtAuthorized.POST("/questions/:questionId/choices", func(c *gin.Context) { handlers.CreateChoice(c, db) })
+	jwtAuthorized.POST("/questions/:questionId/choices", func(c *gin.Context) { handlers.CreateChoice(c, db, moderator) })
 	jwtAuthorized.GET("/choices/:choiceId", func(c *gin.Context) { handlers.GetChoiceByID(c, db) })
-	jwtAuthorized.PATCH("/choices/:choiceId", func(c *gin.Context) { handlers.UpdateChoice(c, db) })
+	jwtAuthorized.PATCH("/choices/:choiceId", func(c *gin.Context) { handlers.UpdateChoice(c, db, moderator) })
 	jwtAuthorized.DELETE("/choices/:choiceId", func(c *gin.Context) { handlers.DeleteChoice(c, db) })
 
 	// Game Routes
 	jwtAuthorized.POST("/quizzes/:quizId/play", func(c *gin.Context) { handlers.StartGame(c, db) })
-	jwtAuthorized.POST("/games/:gameId/answer/:choiceId", func(c *gin.Context) { handlers.AnswerQuestion(c, db) })
+	jwtAuthorized.POST("/games/:gameId/answer", func(c *gin.Context) { handlers.AnswerQuestion(c, db) })
+	jwtAuthorized.POST("/games/:gameId/answer/:choiceId", func(c *gin.Context) { handlers.AnswerQuestionWithChoice(c, db) })
 	jwtAuthorized.GET("/games/:gameId/result", func(c *gin.Context) { handlers.GameResultById(c, db) })
 	jwtAuthorized.GET("/me/games", func(c *gin.Context) { handlers.GamesResults(c, db) })
 
+	// Multiplayer Room Routes
+	jwtAuthorized.POST("/rooms", func(c *gin.Context) { handlers.CreateRoom(c, db, roomHub) })
+	rateLimited.GET("/rooms/:code/ws", func(c *gin.Context) { handlers.JoinRoom(c, db, roomHub) })
+
+	// Quiz Draft Routes
+	jwtAuthorized.GET("/drafts", func(c *gin.Context) { handlers.GetDrafts(c, db) })
+	jwtAuthorized.GET("/drafts/:draftId", func(c *gin.Context) { handlers.GetDraftByID(c, db) })
+	jwtAuthorized.PUT("/drafts/:draftId", func(c *gin.Context) { handlers.UpdateDraft(c, db) })
+	jwtAuthorized.DELETE("/drafts/:draftId", func(c *gin.Context) { handlers.DeleteDraft(c, db) })
+	jwtAuthorized.POST("/drafts/:draftId/publish", func(c *gin.Context) { handlers.PublishDraft(c, db, moderator) })
+
+	// Notification Routes
+	jwtAuthorized.GET("/me/notifications", func(c *gin.Context) { handlers.GetOwnNotifications(c, db) })
+	jwtAuthorized.POST("/me/notifications/read", func(c *gin.Context) { handlers.ReadAllNotifications(c, db) })
+	jwtAuthorized.POST("/me/notifications/:notificationId/read", func(c *gin.Context) { handlers.ReadNotification(c, db) })
+
+	// Curator Routes
+	curatorRoutes := jwtAuthorized.Group("/admin", middlewares.RequireRole(schemas.RoleCurator, schemas.RoleAdmin))
+	curatorRoutes.PATCH("/quizzes/:quizId/curator-pick", func(c *gin.Context) { handlers.SetCuratorPick(c, db) })
+	curatorRoutes.POST("/categories", func(c *gin.Context) { handlers.CreateCategory(c, db) })
+	curatorRoutes.PATCH("/categories/:categoryId", func(c *gin.Context) { handlers.UpdateCategory(c, db) })
+	curatorRoutes.DELETE("/categories/:categoryId", func(c *gin.Context) { handlers.DeleteCategory(c, db) })
+
+	// Admin Routes
+	adminRoutes := jwtAuthorized.Group("/admin", middlewares.RequireRole(schemas.RoleAdmin))
+	adminRoutes.GET("/reports", func(c *gin.Context) { handlers.GetReports(c, db) })
+	adminRoutes.POST("/reports/:reportId/resolve", func(c *gin.Context) { handlers.ResolveReport(c, db) })
+	adminRoutes.GET("/quizzes/flagged", func(c *gin.Context) { handlers.GetFlaggedQuizzes(c, db) })
+	adminRoutes.PATCH("/quizzes/:quizId/moderation", func(c *gin.Context) { handlers.ReviewQuizModeration(c, db) })
+	adminRoutes.PATCH("/users/:userId/role", func(c *gin.Context) { handlers.UpdateUserRole(c, db) })
+	adminRoutes.POST("/users/:userId/suspend", func(c *gin.Context) { handlers.SuspendUser(c, db) })
+	adminRoutes.POST("/users/:userId/unsuspend", func(c *gin.Context) { handlers.UnsuspendUser(c, db) })
+
 	// Integration AI Routes
-	jwtAuthorized.POST("/ai/generate-quiz", func(c *gin.Context) { handlers.GenerateQuizAI(c, db, openAIClient) })
-	jwtAuthorized.POST("/ai/generate-question", func(c *gin.Context) { handlers.GenerateQuestionAI(c, db, openAIClient) })
-	jwtAuthorized.POST("/ai/autocomplete-quiz", func(c *gin.Context) { handlers.AutocompleteQuiz(c, db, openAIClient) })
-	jwtAuthorized.POST("/ai/autocomplete-question", func(c *gin.Context) { handlers.AutocompleteQuestion(c, db, openAIClient) })
-	jwtAuthorized.POST("/ai/autocomplete-choice", func(c *gin.Context) { handlers.AutocompleteChoice(c, db, openAIClient) })
+	aiRoutes := jwtAuthorized.Group("/ai", middlewares.AIUsageMiddleware(db, aiBudget))
+	aiRoutes.POST("/generate-quiz", func(c *gin.Context) { handlers.GenerateQuizAI(c, db, aiProvider) })
+	aiRoutes.POST("/generate-quiz-from-text", func(c *gin.Context) { handlers.GenerateQuizFromTextAI(c, db, aiProvider) })
+	aiRoutes.POST("/generate-quiz/stream", func(c *gin.Context) { handlers.StreamGenerateQuizAI(c, db, aiProvider) })
+	aiRoutes.POST("/generate-quiz-from-text/stream", func(c *gin.Context) { handlers.StreamGenerateQuizFromTextAI(c, db, aiProvider) })
+	aiRoutes.POST("/generate-question", func(c *gin.Context) { handlers.GenerateQuestionAI(c, db, aiProvider) })
+	aiRoutes.POST("/autocomplete-quiz", func(c *gin.Context) { handlers.AutocompleteQuiz(c, db, aiProvider) })
+	aiRoutes.POST("/autocomplete-question", func(c *gin.Context) { handlers.AutocompleteQuestion(c, db, aiProvider) })
+	aiRoutes.POST("/autocomplete-choice", func(c *gin.Context) { handlers.AutocompleteChoice(c, db, aiProvider) })
+	aiRoutes.POST("/review-quiz/:quizId", func(c *gin.Context) { handlers.ReviewQuizAI(c, db, aiProvider) })
+	aiRoutes.POST("/generate-explanation", func(c *gin.Context) { handlers.GenerateExplanationAI(c, db, aiProvider) })
 
 	if os.Getenv("GIN_MODE") != "production" {
 		docs.SwaggerInfo.BasePath = "/"
@@ -160,17 +339,134 @@ func main() {
 		seeders.Run(db)
 	} else if migrate {
 		schemas.Run(db, &freshMigrate)
+
+		if err := seeders.CategorySlugsSeeding(db); err != nil {
+			log.Fatal("Failed to set the category slugs: " + err.Error())
+			return
+		}
 	}
 
-	var openAIClient *openai.Client
-	openAIKey := os.Getenv("OPENAI_API_KEY")
-	if openAIKey == "" {
-		log.Println("Warning: OPENAI_API_KEY is not set. AI features will not work.")
-	} else {
-		openAIClient = openai.NewClient(openAIKey)
+	// ADMIN_USER_IDS is a comma separated list of user IDs promoted to admins
+	// on startup, to set up the first admins
+	adminIDs := strings.FieldsFunc(os.Getenv("ADMIN_USER_IDS"), func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
+	if err := seeders.AdminsSeeding(db, adminIDs); err != nil {
+		log.Fatal("Failed to promote the admins: " + err.Error())
+		return
+	}
+
+	// MODERATION is keywords, openai or none, defaulting to keywords. The
+	// openai moderator also checks the keyword list
+	moderator, err := moderation.NewModerator(moderation.Config{
+		Moderator:    os.Getenv("MODERATION"),
+		KeywordsPath: os.Getenv("MODERATION_KEYWORDS_FILE"),
+		APIKey:       os.Getenv("OPENAI_API_KEY"),
+		Model:        os.Getenv("MODERATION_MODEL"),
+	})
+	if err != nil {
+		log.Fatal("Failed to configure moderation: " + err.Error())
+		return
 	}
 
-	r := setupRouter(db, openAIClient)
+	aiResilience, err := aiResilienceFromEnv()
+	if err != nil {
+		log.Fatal("Invalid AI resilience configuration: " + err.Error())
+		return
+	}
+
+	// AI_PROVIDER is openai, openai-compatible or mock, defaulting to openai
+	// when OPENAI_API_KEY is set
+	aiProvider, err := ai.NewProvider(ai.Config{
+		Provider:     os.Getenv("AI_PROVIDER"),
+		Model:        os.Getenv("AI_MODEL"),
+		BaseURL:      os.Getenv("AI_BASE_URL"),
+		APIKey:       os.Getenv("OPENAI_API_KEY"),
+		FixturesPath: os.Getenv("AI_MOCK_FIXTURES"),
+		Resilience:   aiResilience,
+	})
+	if errors.Is(err, ai.ErrProviderNotConfigured) {
+		log.Println("Warning: neither AI_PROVIDER nor OPENAI_API_KEY is set. AI features will not work.")
+	} else if err != nil {
+		log.Fatal("Failed to configure AI provider: " + err.Error())
+		return
+	}
+
+	if aiProvider != nil {
+		cacheConfig, err := aiCacheConfigFromEnv()
+		if err != nil {
+			log.Fatal("Invalid AI cache configuration: " + err.Error())
+			return
+		}
+
+		aiCache, err := ai.NewCache(cacheConfig)
+		if err != nil {
+			log.Fatal("Failed to configure AI cache: " + err.Error())
+			return
+		}
+		if aiCache != nil {
+			aiProvider = ai.NewCachedProvider(aiProvider, aiCache, cacheConfig.TTL)
+		}
+		if moderator != nil {
+			aiProvider = moderation.NewModeratedProvider(aiProvider, moderator)
+		}
+	}
+
+	aiBudget, err := aiBudgetFromEnv()
+	if err != nil {
+		log.Fatal("Invalid AI budget configuration: " + err.Error())
+		return
+	}
+
+	// QUIZ_STATS_RECONCILE_INTERVAL is how often the counters of the quizzes
+	// are recounted and their recent plays moved forward, defaulting to 1h.
+	// The trending sort relies on it to forget old plays, so it can't be 0
+	reconcileInterval, err := durationFromEnv("QUIZ_STATS_RECONCILE_INTERVAL", time.Hour)
+	if err != nil {
+		log.Fatal("Invalid quiz statistics configuration: " + err.Error())
+		return
+	}
+	if reconcileInterval <= 0 {
+		log.Fatal("Invalid quiz statistics configuration: QUIZ_STATS_RECONCILE_INTERVAL must be positive, the trending sort relies on it")
+		return
+	}
+	jobs.Every(context.Background(), "quiz stats reconciliation", reconcileInterval, func(ctx context.Context) error {
+		repaired, err := utils.ReconcileQuizStats(ctx, db)
+		if repaired > 0 {
+			log.Printf("Repaired the statistics of %d quizzes", repaired)
+		}
+		return err
+	})
+
+	// HOME_FEED_REFRESH_INTERVAL is how often the home page sections are
+	// recomputed, defaulting to 5m. 0 computes them once on the first request
+	homeFeedInterval, err := durationFromEnv("HOME_FEED_REFRESH_INTERVAL", 5*time.Minute)
+	if err != nil {
+		log.Fatal("Invalid home feed configuration: " + err.Error())
+		return
+	}
+	homeFeed := feed.NewFeed(db)
+	jobs.Every(context.Background(), "home feed refresh", homeFeedInterval, homeFeed.Refresh)
+
+	// RECOMMENDATIONS_REFRESH_INTERVAL is how often the similarity of the
+	// quizzes liked or played since the last refresh is recomputed, defaulting
+	// to 15m. RECOMMENDATIONS_FULL_REFRESH_INTERVAL is how often every quiz is
+	// recomputed, starting on startup, defaulting to 24h. 0 disables either
+	recommendationsInterval, err := durationFromEnv("RECOMMENDATIONS_REFRESH_INTERVAL", 15*time.Minute)
+	if err != nil {
+		log.Fatal("Invalid recommendations configuration: " + err.Error())
+		return
+	}
+	recommendationsFullInterval, err := durationFromEnv("RECOMMENDATIONS_FULL_REFRESH_INTERVAL", 24*time.Hour)
+	if err != nil {
+		log.Fatal("Invalid recommendations configuration: " + err.Error())
+		return
+	}
+	recommender := feed.NewRecommender(db)
+	jobs.Every(context.Background(), "recommendations full refresh", recommendationsFullInterval, recommender.RefreshAll)
+	jobs.Every(context.Background(), "recommendations refresh", recommendationsInterval, recommender.Refresh)
+
+	roomHub := rooms.NewHub(db)
+
+	r := setupRouter(db, aiProvider, aiBudget, moderator, roomHub, homeFeed, recommender)
 
 	r.Run(":" + os.Getenv("PORT"))
 }
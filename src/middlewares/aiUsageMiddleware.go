@@ -0,0 +1,102 @@
+package middlewares
+
+import (
+	"context"
+	"intelliquiz/src/ai"
+	"intelliquiz/src/database/schemas"
+	"intelliquiz/src/types"
+	"intelliquiz/src/utils"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// AIUsageMiddleware rejects requests once the user or global token quotas of
+// the budget are exhausted, and records the tokens spent by the request after
+// it is handled. Quotas are checked before the request, so the last request of
+// a period may go over them.
+func AIUsageMiddleware(db *gorm.DB, budget ai.Budget) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.MustGet("userID").(string)
+		now := time.Now()
+
+		quotas := []struct {
+			userID  string
+			since   time.Time
+			resetAt time.Time
+			limit   int64
+			message string
+		}{
+			{userID, utils.StartOfDay(now), utils.StartOfDay(now).AddDate(0, 0, 1), budget.DailyTokens, "Daily AI token quota exhausted. Try again tomorrow."},
+			{userID, utils.StartOfMonth(now), utils.StartOfMonth(now).AddDate(0, 1, 0), budget.MonthlyTokens, "Monthly AI token quota exhausted. Try again next month."},
+			{"", utils.StartOfMonth(now), utils.StartOfMonth(now).AddDate(0, 1, 0), budget.GlobalMonthlyTokens, "The AI features have reached their usage limit for this month."},
+		}
+		for _, quota := range quotas {
+			if quota.limit <= 0 {
+				continue
+			}
+
+			totals, err := utils.SumAIUsage(c.Request.Context(), db, quota.userID, quota.since)
+			if err != nil {
+				log.Printf("Error checking AI usage: %v", err)
+
+				c.AbortWithStatusJSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+					StatusCode: http.StatusInternalServerError,
+					Success:    false,
+					Message:    "An error occurred while checking the AI usage.",
+				})
+				return
+			}
+			if totals.TotalTokens < quota.limit {
+				continue
+			}
+
+			// The global cap is not something the user can wait out on their
+			// own, so it is reported apart from the personal quotas
+			if quota.userID == "" {
+				c.AbortWithStatusJSON(http.StatusPaymentRequired, types.PaymentRequiredErrorResponseStruct{
+					StatusCode: http.StatusPaymentRequired,
+					Success:    false,
+					Message:    quota.message,
+				})
+				return
+			}
+
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(quota.resetAt.Sub(now).Seconds()))))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, types.TooManyRequestsErrorResponseStruct{
+				StatusCode: http.StatusTooManyRequests,
+				Success:    false,
+				Message:    quota.message,
+			})
+			return
+		}
+
+		ctx, tracker := ai.TrackUsage(c.Request.Context())
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		usage, calls := tracker.Usage()
+		if calls == 0 {
+			return
+		}
+
+		record := schemas.AIUsage{
+			UserID:           userID,
+			Endpoint:         c.FullPath(),
+			PromptTokens:     usage.PromptTokens,
+			CompletionTokens: usage.CompletionTokens,
+			TotalTokens:      usage.TotalTokens(),
+			Cost:             budget.Cost(usage),
+		}
+		// The usage must be recorded even when the client went away mid-request
+		if err := gorm.G[schemas.AIUsage](db).Create(context.WithoutCancel(ctx), &record); err != nil {
+			log.Printf("Error recording AI usage: %v", err)
+		}
+	}
+}
@@ -2,11 +2,15 @@ package middlewares
 
 import (
 	"intelliquiz/src/auth"
+	"intelliquiz/src/database/schemas"
 	"intelliquiz/src/types"
+	"log"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 func BearerFromHeader(c *gin.Context) string {
@@ -18,7 +22,7 @@ func BearerFromHeader(c *gin.Context) string {
 	return ""
 }
 
-func JWTTokenMiddleware() gin.HandlerFunc {
+func JWTTokenMiddleware(db *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		tokenStr := BearerFromHeader(c)
 		if tokenStr == "" {
@@ -40,7 +44,51 @@ func JWTTokenMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		active, err := auth.IsSessionActive(c.Request.Context(), db, claims.SessionID)
+		if err != nil {
+			log.Printf("Error verifying session: %v", err)
+
+			c.AbortWithStatusJSON(http.StatusInternalServerError, types.InternalServerErrorResponseStruct{
+				StatusCode: http.StatusInternalServerError,
+				Success:    false,
+				Message:    "An error occurred while verifying the session.",
+			})
+			return
+		}
+		if !active {
+			c.AbortWithStatusJSON(http.StatusForbidden, types.ForbiddenErrorResponseStruct{
+				StatusCode: http.StatusForbidden,
+				Success:    false,
+				Message:    "Session has been revoked.",
+			})
+			return
+		}
+
 		c.Set("userID", claims.Subject)
+		c.Set("sessionID", claims.SessionID)
+		c.Set("role", claims.Role)
+		c.Next()
+	}
+}
+
+// RequireRole only lets the users with one of the roles through, read from
+// the access token claims. It must run after JWTTokenMiddleware.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString("role")
+		if role == "" {
+			role = schemas.RoleUser
+		}
+
+		if !slices.Contains(roles, role) {
+			c.AbortWithStatusJSON(http.StatusForbidden, types.ForbiddenErrorResponseStruct{
+				StatusCode: http.StatusForbidden,
+				Success:    false,
+				Message:    "You do not have permission to access this resource.",
+			})
+			return
+		}
+
 		c.Next()
 	}
 }
@@ -0,0 +1,84 @@
+package moderation
+
+import (
+	"bufio"
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+//go:embed keywords.txt
+var defaultKeywords string
+
+// KeywordModerator flags texts containing any word or phrase of a list. Texts
+// and keywords are compared ignoring case, accents, punctuation and common
+// letter substitutions, and only whole words match, so "Scunthorpe" is not
+// flagged for what it contains.
+type KeywordModerator struct {
+	keywords []string
+}
+
+// NewKeywordModerator loads the keywords from the file at path, one per line
+// with # starting comments, or uses the built-in list when path is empty.
+func NewKeywordModerator(path string) (*KeywordModerator, error) {
+	list := defaultKeywords
+	if path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading the keyword list: %w", err)
+		}
+		list = string(content)
+	}
+
+	moderator := &KeywordModerator{}
+	scanner := bufio.NewScanner(strings.NewReader(list))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if keyword := normalizeKeywordText(line); keyword != "" {
+			moderator.keywords = append(moderator.keywords, keyword)
+		}
+	}
+
+	return moderator, nil
+}
+
+func (m *KeywordModerator) Moderate(ctx context.Context, texts ...string) (Verdict, error) {
+	verdict := Verdict{}
+	for _, text := range texts {
+		normalized := " " + normalizeKeywordText(text) + " "
+		for _, keyword := range m.keywords {
+			if strings.Contains(normalized, " "+keyword+" ") {
+				verdict.Flagged = true
+				verdict.Reasons = append(verdict.Reasons, fmt.Sprintf("keyword %q", keyword))
+			}
+		}
+	}
+
+	return verdict, nil
+}
+
+var leetReplacer = strings.NewReplacer("0", "o", "1", "i", "3", "e", "4", "a", "5", "s", "7", "t", "@", "a", "$", "s")
+
+// normalizeKeywordText lowercases the text, removes its accents, undoes common
+// letter substitutions and turns anything but letters into single spaces.
+// Substitutions are only undone in words with letters, so numbers like "455"
+// are dropped instead of read as words.
+func normalizeKeywordText(s string) string {
+	s, _, _ = transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), s)
+
+	words := strings.Fields(strings.ToLower(s))
+	for i, word := range words {
+		if strings.IndexFunc(word, unicode.IsLetter) >= 0 {
+			words[i] = leetReplacer.Replace(word)
+		}
+	}
+
+	return strings.Join(strings.FieldsFunc(strings.Join(words, " "), func(r rune) bool { return !unicode.IsLetter(r) }), " ")
+}
@@ -0,0 +1,93 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeKeywordText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"case and accents", "Ação É Árdua", "acao e ardua"},
+		{"punctuation", "foda-se!!  porra...", "foda se porra"},
+		{"substitutions", "$h1t f@g 4ss", "shit fag ass"},
+		{"numbers", "455 questões em 1945", "questoes em"},
+		{"decimals", "pi vale 3,14", "pi vale"},
+		{"empty", " \t\n", ""},
+	}
+
+	for _, test := range tests {
+		if got := normalizeKeywordText(test.text); got != test.want {
+			t.Errorf("%s: normalizeKeywordText(%q) = %q, want %q", test.name, test.text, got, test.want)
+		}
+	}
+}
+
+func TestKeywordModeratorModerate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keywords.txt")
+	list := "# Test list\nass\nKill Yourself # phrase\nfilho da mãe\nporra\n"
+	if err := os.WriteFile(path, []byte(list), 0o600); err != nil {
+		t.Fatalf("writing the keyword list: %v", err)
+	}
+	moderator, err := NewKeywordModerator(path)
+	if err != nil {
+		t.Fatalf("NewKeywordModerator: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		texts []string
+		want  []string
+	}{
+		{"word", []string{"Que porra é essa?"}, []string{"porra"}},
+		{"accents and case", []string{"FILHO DA MAE"}, []string{"filho da mae"}},
+		{"substitutions", []string{"k1ll y0urs3lf"}, []string{"kill yourself"}},
+		{"substituted word", []string{"Que 4ss!"}, []string{"ass"}},
+		{"phrase across punctuation", []string{"Filho-da-mãe!"}, []string{"filho da mae"}},
+		{"every text", []string{"Quiz limpo", "porra", "kill yourself"}, []string{"porra", "kill yourself"}},
+		{"inside a word", []string{"Scunthorpe fica na Inglaterra", "Quem foi Passos?", "Class"}, nil},
+		{"phrase split up", []string{"O filho da vizinha", "a mãe dele"}, nil},
+		{"numbers", []string{"Quanto é 400 + 55?", "455", "R$ 4,55"}, nil},
+		{"symbols alone", []string{"@$$"}, nil},
+		{"clean", []string{"Qual é a capital da França?", "Paris"}, nil},
+	}
+
+	for _, test := range tests {
+		verdict, err := moderator.Moderate(context.Background(), test.texts...)
+		if err != nil {
+			t.Fatalf("%s: Moderate: %v", test.name, err)
+		}
+
+		var want []string
+		for _, keyword := range test.want {
+			want = append(want, fmt.Sprintf("keyword %q", keyword))
+		}
+		if verdict.Flagged != (len(want) > 0) || !slices.Equal(verdict.Reasons, want) {
+			t.Errorf("%s: Moderate(%q) = %+v, want flagged for %q", test.name, test.texts, verdict, test.want)
+		}
+	}
+}
+
+func TestNewKeywordModeratorDefaultList(t *testing.T) {
+	moderator, err := NewKeywordModerator("")
+	if err != nil {
+		t.Fatalf("NewKeywordModerator: %v", err)
+	}
+
+	for _, keyword := range moderator.keywords {
+		if keyword != normalizeKeywordText(keyword) || strings.Contains(keyword, "#") {
+			t.Errorf("keyword %q of the built-in list is not normalized", keyword)
+		}
+	}
+	if verdict, _ := moderator.Moderate(context.Background(), "Que p0rr4!"); !verdict.Flagged {
+		t.Error("the built-in list did not flag a listed word")
+	}
+}
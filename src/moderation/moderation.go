@@ -0,0 +1,85 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+)
+
+const (
+	ModeratorKeywords = "keywords"
+	ModeratorOpenAI   = "openai"
+	ModeratorNone     = "none"
+)
+
+// Verdict is the outcome of moderating some content, with the reasons it was
+// flagged for.
+type Verdict struct {
+	Flagged bool
+	Reasons []string
+}
+
+// Reason joins the reasons of the verdict for display.
+func (v Verdict) Reason() string {
+	return strings.Join(v.Reasons, ", ")
+}
+
+// Moderator checks texts for offensive or unsafe content. The texts are
+// moderated together, so a verdict covers all of them.
+type Moderator interface {
+	Moderate(ctx context.Context, texts ...string) (Verdict, error)
+}
+
+type Config struct {
+	Moderator    string
+	KeywordsPath string
+	APIKey       string
+	Model        string
+}
+
+// NewModerator builds the moderator selected by the configuration, a keyword
+// list by default. The openai moderator also checks the keyword list, as the
+// moderation API does not know about locally banned words. It returns nil when
+// moderation is disabled.
+func NewModerator(config Config) (Moderator, error) {
+	switch config.Moderator {
+	case "", ModeratorKeywords:
+		return NewKeywordModerator(config.KeywordsPath)
+	case ModeratorOpenAI:
+		if config.APIKey == "" {
+			return nil, errors.New("the openai moderator requires an API key")
+		}
+		keywords, err := NewKeywordModerator(config.KeywordsPath)
+		if err != nil {
+			return nil, err
+		}
+		return Chain{keywords, NewOpenAIModerator(config.APIKey, config.Model)}, nil
+	case ModeratorNone:
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf("unknown moderator %q", config.Moderator)
+}
+
+// Chain asks every moderator in turn and merges their verdicts. A failing
+// moderator is logged and skipped, so an outage of a moderation API does not
+// block authoring.
+type Chain []Moderator
+
+func (c Chain) Moderate(ctx context.Context, texts ...string) (Verdict, error) {
+	verdict := Verdict{}
+	for _, moderator := range c {
+		result, err := moderator.Moderate(ctx, texts...)
+		if err != nil {
+			log.Printf("Error moderating content: %v", err)
+			continue
+		}
+
+		verdict.Flagged = verdict.Flagged || result.Flagged
+		verdict.Reasons = append(verdict.Reasons, result.Reasons...)
+	}
+
+	return verdict, nil
+}
@@ -0,0 +1,86 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+const openAIModerationTimeout = 10 * time.Second
+
+// OpenAIModerator checks texts with the OpenAI moderation API.
+type OpenAIModerator struct {
+	client *openai.Client
+	model  string
+}
+
+// NewOpenAIModerator creates a moderator using the given moderation model,
+// omni-moderation-latest by default.
+func NewOpenAIModerator(apiKey, model string) *OpenAIModerator {
+	if model == "" {
+		model = openai.ModerationOmniLatest
+	}
+
+	return &OpenAIModerator{
+		client: openai.NewClient(apiKey),
+		model:  model,
+	}
+}
+
+func (m *OpenAIModerator) Moderate(ctx context.Context, texts ...string) (Verdict, error) {
+	input := strings.Join(slices.DeleteFunc(slices.Clone(texts), func(text string) bool {
+		return strings.TrimSpace(text) == ""
+	}), "\n\n")
+	if input == "" {
+		return Verdict{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, openAIModerationTimeout)
+	defer cancel()
+
+	response, err := m.client.Moderations(ctx, openai.ModerationRequest{
+		Input: input,
+		Model: m.model,
+	})
+	if err != nil {
+		return Verdict{}, err
+	}
+
+	verdict := Verdict{}
+	for _, result := range response.Results {
+		if !result.Flagged {
+			continue
+		}
+		verdict.Flagged = true
+		verdict.Reasons = append(verdict.Reasons, flaggedCategories(result.Categories)...)
+	}
+
+	return verdict, nil
+}
+
+// flaggedCategories lists the names of the categories set in the result, such
+// as "hate" or "violence/graphic".
+func flaggedCategories(categories openai.ResultCategories) []string {
+	encoded, err := json.Marshal(categories)
+	if err != nil {
+		return nil
+	}
+	var flags map[string]bool
+	if err := json.Unmarshal(encoded, &flags); err != nil {
+		return nil
+	}
+
+	names := []string{}
+	for name, flagged := range flags {
+		if flagged {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	return names
+}
@@ -0,0 +1,206 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"intelliquiz/src/ai"
+	"log"
+)
+
+// ErrFlagged is returned by ModeratedProvider when the generated content does
+// not pass moderation.
+var ErrFlagged = errors.New("AI generated content was flagged by moderation")
+
+// ModeratedProvider wraps a provider so its answers are moderated before they
+// are returned. Flagged questions are dropped from generated quizzes, and
+// ErrFlagged is returned when the title or every question is flagged, or when
+// any other answer is. Moderation failures are logged and let the answer
+// through.
+type ModeratedProvider struct {
+	ai.AIProvider
+	moderator Moderator
+}
+
+func NewModeratedProvider(provider ai.AIProvider, moderator Moderator) *ModeratedProvider {
+	return &ModeratedProvider{
+		AIProvider: provider,
+		moderator:  moderator,
+	}
+}
+
+func (p *ModeratedProvider) GenerateQuiz(ctx context.Context, req ai.QuizRequest) (*ai.GeneratedQuiz, error) {
+	quiz, err := p.AIProvider.GenerateQuiz(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	quiz.Questions, err = moderateQuiz(ctx, p, quiz.QuizTitle, quiz.Questions, generatedQuestionTexts)
+	if err != nil {
+		return nil, err
+	}
+
+	return quiz, nil
+}
+
+func (p *ModeratedProvider) GenerateQuizFromSource(ctx context.Context, req ai.SourceQuizRequest) (*ai.GeneratedSourceQuiz, error) {
+	quiz, err := p.AIProvider.GenerateQuizFromSource(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	quiz.Questions, err = moderateQuiz(ctx, p, quiz.QuizTitle, quiz.Questions, generatedSourceQuestionTexts)
+	if err != nil {
+		return nil, err
+	}
+
+	return quiz, nil
+}
+
+func (p *ModeratedProvider) GenerateQuestion(ctx context.Context, req ai.QuestionRequest) (*ai.GeneratedQuestion, error) {
+	question, err := p.AIProvider.GenerateQuestion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if p.flagged(ctx, generatedQuestionTexts(*question)...) {
+		return nil, ErrFlagged
+	}
+
+	return question, nil
+}
+
+func (p *ModeratedProvider) AutocompleteQuiz(ctx context.Context, req ai.QuizAutocompleteRequest) (string, error) {
+	text, err := p.AIProvider.AutocompleteQuiz(ctx, req)
+	return p.moderateText(ctx, text, err)
+}
+
+func (p *ModeratedProvider) AutocompleteQuestion(ctx context.Context, req ai.QuestionAutocompleteRequest) (string, error) {
+	text, err := p.AIProvider.AutocompleteQuestion(ctx, req)
+	return p.moderateText(ctx, text, err)
+}
+
+func (p *ModeratedProvider) AutocompleteChoice(ctx context.Context, req ai.ChoiceAutocompleteRequest) (string, error) {
+	text, err := p.AIProvider.AutocompleteChoice(ctx, req)
+	return p.moderateText(ctx, text, err)
+}
+
+func (p *ModeratedProvider) GenerateExplanation(ctx context.Context, req ai.ExplanationRequest) (string, error) {
+	text, err := p.AIProvider.GenerateExplanation(ctx, req)
+	return p.moderateText(ctx, text, err)
+}
+
+func (p *ModeratedProvider) ReviewQuiz(ctx context.Context, req ai.QuizReviewRequest) (*ai.QuizReview, error) {
+	review, err := p.AIProvider.ReviewQuiz(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	texts := []string{review.Summary}
+	for _, question := range review.Questions {
+		for _, issue := range question.Issues {
+			texts = append(texts, issue.Description, issue.SuggestedContent)
+		}
+	}
+	if p.flagged(ctx, texts...) {
+		return nil, ErrFlagged
+	}
+
+	return review, nil
+}
+
+func (p *ModeratedProvider) StreamQuiz(ctx context.Context, req ai.QuizRequest, stream ai.QuizStream[ai.GeneratedQuestion]) error {
+	return p.AIProvider.StreamQuiz(ctx, req, moderatedStream(ctx, p, stream, generatedQuestionTexts))
+}
+
+func (p *ModeratedProvider) StreamQuizFromSource(ctx context.Context, req ai.SourceQuizRequest, stream ai.QuizStream[ai.GeneratedSourceQuestion]) error {
+	return p.AIProvider.StreamQuizFromSource(ctx, req, moderatedStream(ctx, p, stream, generatedSourceQuestionTexts))
+}
+
+// moderateText moderates the text answer of a call, passing its error through.
+func (p *ModeratedProvider) moderateText(ctx context.Context, text string, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	if p.flagged(ctx, text) {
+		return "", ErrFlagged
+	}
+
+	return text, nil
+}
+
+func (p *ModeratedProvider) flagged(ctx context.Context, texts ...string) bool {
+	verdict, err := p.moderator.Moderate(ctx, texts...)
+	if err != nil {
+		log.Printf("Error moderating AI generated content: %v", err)
+		return false
+	}
+	if verdict.Flagged {
+		log.Printf("AI generated content flagged by moderation: %s", verdict.Reason())
+	}
+
+	return verdict.Flagged
+}
+
+// moderateQuiz moderates the whole quiz at once, and only when it is flagged
+// each question on its own to drop the flagged ones.
+func moderateQuiz[Q any](ctx context.Context, p *ModeratedProvider, title string, questions []Q, questionTexts func(Q) []string) ([]Q, error) {
+	texts := []string{title}
+	for _, question := range questions {
+		texts = append(texts, questionTexts(question)...)
+	}
+	if !p.flagged(ctx, texts...) {
+		return questions, nil
+	}
+
+	if p.flagged(ctx, title) {
+		return nil, ErrFlagged
+	}
+	kept := []Q{}
+	for _, question := range questions {
+		if !p.flagged(ctx, questionTexts(question)...) {
+			kept = append(kept, question)
+		}
+	}
+	if len(kept) == 0 {
+		return nil, ErrFlagged
+	}
+
+	return kept, nil
+}
+
+// moderatedStream wraps the callbacks of the stream so flagged questions are
+// skipped, and a flagged title stops the generation.
+func moderatedStream[Q any](ctx context.Context, p *ModeratedProvider, stream ai.QuizStream[Q], questionTexts func(Q) []string) ai.QuizStream[Q] {
+	return ai.QuizStream[Q]{
+		OnTitle: func(title string) error {
+			if p.flagged(ctx, title) {
+				return ErrFlagged
+			}
+			if stream.OnTitle == nil {
+				return nil
+			}
+			return stream.OnTitle(title)
+		},
+		OnQuestion: func(question Q) error {
+			if p.flagged(ctx, questionTexts(question)...) || stream.OnQuestion == nil {
+				return nil
+			}
+			return stream.OnQuestion(question)
+		},
+	}
+}
+
+func generatedQuestionTexts(question ai.GeneratedQuestion) []string {
+	texts := []string{question.QuestionContent}
+	for _, choice := range question.Choices {
+		texts = append(texts, choice.Content)
+	}
+
+	return texts
+}
+
+func generatedSourceQuestionTexts(question ai.GeneratedSourceQuestion) []string {
+	return generatedQuestionTexts(ai.GeneratedQuestion{
+		QuestionContent: question.QuestionContent,
+		Choices:         question.Choices,
+	})
+}
@@ -0,0 +1,115 @@
+package rooms
+
+import (
+	"encoding/json"
+	"intelliquiz/src/types"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	writeWait      = 10 * time.Second
+	pongWait       = 60 * time.Second
+	pingPeriod     = (pongWait * 9) / 10
+	maxMessageSize = 512
+	sendBufferSize = 32
+)
+
+// client is a single WebSocket connection of a player.
+type client struct {
+	conn   *websocket.Conn
+	send   chan types.RoomMessage
+	mu     sync.Mutex
+	closed bool
+}
+
+func newClient(conn *websocket.Conn) *client {
+	return &client{
+		conn: conn,
+		send: make(chan types.RoomMessage, sendBufferSize),
+	}
+}
+
+// trySend queues a message without blocking. A client that can't keep up is
+// disconnected instead of stalling the whole room.
+func (c *client) trySend(msg types.RoomMessage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return
+	}
+
+	select {
+	case c.send <- msg:
+	default:
+		c.closed = true
+		close(c.send)
+	}
+}
+
+func (c *client) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.closed {
+		c.closed = true
+		close(c.send)
+	}
+}
+
+func (c *client) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case msg, ok := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if !ok {
+				c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+				return
+			}
+
+			if err := c.conn.WriteJSON(msg); err != nil {
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readPump blocks until the connection is closed, forwarding every message to
+// the handler.
+func (c *client) readPump(handle func(types.RoomIncomingMessage)) {
+	c.conn.SetReadLimit(maxMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		c.conn.SetReadDeadline(time.Now().Add(pongWait))
+		return nil
+	})
+
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var msg types.RoomIncomingMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			c.trySend(types.RoomMessage{Type: "error", Data: types.RoomErrorDTO{Message: "Malformed message."}})
+			continue
+		}
+
+		handle(msg)
+	}
+}
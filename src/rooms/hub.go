@@ -0,0 +1,94 @@
+package rooms
+
+import (
+	"crypto/rand"
+	"errors"
+	"intelliquiz/src/database/schemas"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	codeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	codeLength   = 6
+)
+
+var (
+	ErrRoomNotFound       = errors.New("room not found")
+	ErrRoomAlreadyStarted = errors.New("room already started")
+	ErrNotHost            = errors.New("only the host can start the room")
+)
+
+// Hub keeps every live room in memory, indexed by its join code.
+type Hub struct {
+	store gameStore
+	mu    sync.Mutex
+	rooms map[string]*Room
+}
+
+func NewHub(db *gorm.DB) *Hub {
+	return &Hub{
+		store: dbGameStore{db: db},
+		rooms: make(map[string]*Room),
+	}
+}
+
+// Create opens a lobby for the quiz. The quiz must be loaded with its
+// questions and their choices, including is_correct.
+func (h *Hub) Create(hostID string, quiz schemas.Quiz, questionDuration time.Duration) (*Room, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var code string
+	for {
+		generated, err := generateCode()
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := h.rooms[generated]; !exists {
+			code = generated
+			break
+		}
+	}
+
+	room := newRoom(h, code, hostID, quiz, questionDuration)
+	h.rooms[code] = room
+
+	return room, nil
+}
+
+func (h *Hub) Get(code string) (*Room, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	room, exists := h.rooms[strings.ToUpper(code)]
+	if !exists {
+		return nil, ErrRoomNotFound
+	}
+
+	return room, nil
+}
+
+func (h *Hub) remove(code string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.rooms, code)
+}
+
+func generateCode() (string, error) {
+	var sb strings.Builder
+	for range codeLength {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(codeAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		sb.WriteByte(codeAlphabet[n.Int64()])
+	}
+
+	return sb.String(), nil
+}
@@ -0,0 +1,596 @@
+package rooms
+
+import (
+	"intelliquiz/src/database/schemas"
+	"intelliquiz/src/types"
+	"intelliquiz/src/utils"
+	"log"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	lobbyTimeout     = 30 * time.Minute
+	leaderboardPause = 5 * time.Second
+)
+
+type roomState int
+
+const (
+	stateLobby roomState = iota
+	stateStarting
+	statePlaying
+	stateFinished
+)
+
+type player struct {
+	userID          string
+	username        string
+	client          *client
+	gameID          string
+	gameQuestionIDs []string
+	answered        bool
+	correctAnswers  uint
+	score           uint
+	secondsTaken    uint
+}
+
+// Room is a live multiplayer session of a quiz. Every player gets their own
+// schemas.Game, so results still show up in /me/games once the room finishes.
+type Room struct {
+	Code             string
+	QuizID           string
+	QuestionDuration time.Duration
+
+	hub       *Hub
+	questions []schemas.Question
+
+	mu            sync.Mutex
+	hostID        string
+	state         roomState
+	players       map[string]*player
+	order         []string
+	current       int
+	questionStart time.Time
+	allAnswered   chan struct{}
+	// savingAnswers tracks the answers to the current question still being
+	// written, which are saved without holding mu
+	savingAnswers sync.WaitGroup
+}
+
+func newRoom(hub *Hub, code, hostID string, quiz schemas.Quiz, questionDuration time.Duration) *Room {
+	questions := quiz.Questions
+	for i := range questions {
+		j := rand.Intn(i + 1)
+		questions[i], questions[j] = questions[j], questions[i]
+	}
+
+	room := &Room{
+		Code:             code,
+		hostID:           hostID,
+		QuizID:           quiz.ID,
+		QuestionDuration: questionDuration,
+		hub:              hub,
+		questions:        questions,
+		players:          make(map[string]*player),
+	}
+
+	time.AfterFunc(lobbyTimeout, func() {
+		room.mu.Lock()
+		defer room.mu.Unlock()
+
+		if room.state == stateLobby {
+			room.closeLocked()
+		}
+	})
+
+	return room
+}
+
+func (r *Room) TotalQuestions() int {
+	return len(r.questions)
+}
+
+// HostID returns the player allowed to start the room, which changes when the
+// host leaves the lobby.
+func (r *Room) HostID() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.hostID
+}
+
+// CanJoin reports whether the user may connect to the room. Once the room has
+// started only players that were in the lobby may reconnect.
+func (r *Room) CanJoin(userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.state == stateFinished {
+		return ErrRoomNotFound
+	}
+	if _, isPlayer := r.players[userID]; r.state != stateLobby && !isPlayer {
+		return ErrRoomAlreadyStarted
+	}
+
+	return nil
+}
+
+// Serve attaches the connection to the room and blocks until it is closed.
+func (r *Room) Serve(userID, username string, conn *websocket.Conn) {
+	cl := newClient(conn)
+	go cl.writePump()
+
+	if err := r.attach(userID, username, cl); err != nil {
+		cl.trySend(types.RoomMessage{Type: "error", Data: types.RoomErrorDTO{Message: err.Error()}})
+		cl.close()
+		return
+	}
+
+	cl.readPump(func(msg types.RoomIncomingMessage) {
+		r.handleMessage(userID, cl, msg)
+	})
+
+	r.detach(userID, cl)
+}
+
+func (r *Room) attach(userID, username string, cl *client) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, isPlayer := r.players[userID]
+	switch {
+	case r.state == stateFinished:
+		return ErrRoomNotFound
+	case r.state != stateLobby && !isPlayer:
+		return ErrRoomAlreadyStarted
+	case isPlayer:
+		// A reconnecting player takes over from the previous connection
+		if p.client != nil {
+			p.client.close()
+		}
+		p.client = cl
+	default:
+		p = &player{userID: userID, username: username, client: cl}
+		r.players[userID] = p
+		r.order = append(r.order, userID)
+	}
+
+	r.broadcastLocked(types.RoomMessage{Type: "lobby", Data: r.lobbyLocked()})
+
+	if r.state == statePlaying {
+		cl.trySend(types.RoomMessage{Type: "question", Data: r.questionLocked()})
+	}
+
+	return nil
+}
+
+func (r *Room) detach(userID string, cl *client) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, isPlayer := r.players[userID]
+	if !isPlayer || p.client != cl {
+		return
+	}
+
+	cl.close()
+	p.client = nil
+
+	if r.state == stateLobby && !r.leaveLobbyLocked(userID) {
+		return
+	}
+
+	r.broadcastLocked(types.RoomMessage{Type: "lobby", Data: r.lobbyLocked()})
+	r.signalIfAllAnsweredLocked()
+}
+
+// leaveLobbyLocked removes a player from the lobby, closing the room once it
+// is empty. It reports whether the room is still open.
+func (r *Room) leaveLobbyLocked(userID string) bool {
+	delete(r.players, userID)
+	for i, id := range r.order {
+		if id == userID {
+			r.order = append(r.order[:i], r.order[i+1:]...)
+			break
+		}
+	}
+
+	if len(r.players) == 0 {
+		r.closeLocked()
+		return false
+	}
+	// The player who joined next takes over so the room can still start
+	if userID == r.hostID {
+		r.hostID = r.order[0]
+	}
+
+	return true
+}
+
+func (r *Room) handleMessage(userID string, cl *client, msg types.RoomIncomingMessage) {
+	switch msg.Type {
+	case "start":
+		if err := r.start(userID); err != nil {
+			cl.trySend(types.RoomMessage{Type: "error", Data: types.RoomErrorDTO{Message: err.Error()}})
+		}
+	case "answer":
+		r.answer(userID, cl, utils.NewAnswer(msg.ChoiceID, msg.ChoiceIDs, msg.Text, msg.Number))
+	default:
+		cl.trySend(types.RoomMessage{Type: "error", Data: types.RoomErrorDTO{Message: "Unknown message type."}})
+	}
+}
+
+func (r *Room) start(userID string) error {
+	r.mu.Lock()
+	if userID != r.hostID {
+		r.mu.Unlock()
+		return ErrNotHost
+	}
+	if r.state != stateLobby {
+		r.mu.Unlock()
+		return ErrRoomAlreadyStarted
+	}
+
+	// The room stops taking players while their games are created
+	r.state = stateStarting
+	players := make([]*player, 0, len(r.order))
+	for _, id := range r.order {
+		players = append(players, r.players[id])
+	}
+	r.mu.Unlock()
+
+	games, err := r.createGames(players)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err != nil {
+		log.Printf("Error creating room games in database: %v", err)
+
+		// Players that left in the meantime are gone from the lobby now
+		r.state = stateLobby
+		for _, p := range players {
+			if p.client == nil && !r.leaveLobbyLocked(p.userID) {
+				return err
+			}
+		}
+		r.broadcastLocked(types.RoomMessage{Type: "lobby", Data: r.lobbyLocked()})
+
+		return err
+	}
+
+	for i, p := range players {
+		p.gameID = games[i].ID
+		p.gameQuestionIDs = make([]string, len(games[i].GameQuestions))
+		for j, gameQuestion := range games[i].GameQuestions {
+			p.gameQuestionIDs[j] = gameQuestion.ID
+		}
+	}
+
+	r.state = statePlaying
+	go r.run()
+
+	return nil
+}
+
+// createGames creates the game of every player, in the order of the players.
+func (r *Room) createGames(players []*player) ([]schemas.Game, error) {
+	userIDs := make([]string, len(players))
+	for i, p := range players {
+		userIDs[i] = p.userID
+	}
+
+	return r.hub.store.createGames(r.QuizID, userIDs, r.questions, time.Now())
+}
+
+// run drives the room through every question, waiting on each one until every
+// connected player answered or the countdown expired.
+func (r *Room) run() {
+	for i := range r.questions {
+		r.mu.Lock()
+		r.current = i
+		r.questionStart = time.Now()
+		r.allAnswered = make(chan struct{})
+		for _, p := range r.players {
+			p.answered = false
+		}
+		allAnswered := r.allAnswered
+		r.broadcastLocked(types.RoomMessage{Type: "question", Data: r.questionLocked()})
+		r.signalIfAllAnsweredLocked()
+		r.mu.Unlock()
+
+		timer := time.NewTimer(r.QuestionDuration)
+		select {
+		case <-allAnswered:
+			timer.Stop()
+		case <-timer.C:
+		}
+
+		// Once the question is closed no answer starts saving, so the ones
+		// being saved can be waited for before the leaderboard
+		r.mu.Lock()
+		r.allAnswered = nil
+		timedOut := r.timeoutUnansweredLocked()
+		deadline := r.questionStart.Add(r.QuestionDuration)
+		r.mu.Unlock()
+		r.savingAnswers.Wait()
+
+		r.saveTimeouts(timedOut, deadline)
+		isLast := i == len(r.questions)-1
+		if isLast {
+			r.finishGames()
+		}
+
+		r.mu.Lock()
+		r.broadcastLocked(types.RoomMessage{Type: "leaderboard", Data: r.leaderboardLocked(isLast)})
+		if isLast {
+			r.closeLocked()
+		}
+		r.mu.Unlock()
+
+		if !isLast {
+			time.Sleep(leaderboardPause)
+		}
+	}
+}
+
+func (r *Room) answer(userID string, cl *client, answer utils.Answer) {
+	r.mu.Lock()
+
+	p := r.players[userID]
+	if r.state != statePlaying || p == nil || r.allAnswered == nil {
+		r.mu.Unlock()
+		cl.trySend(types.RoomMessage{Type: "error", Data: types.RoomErrorDTO{Message: "No question is open for answers."}})
+		return
+	}
+	if p.answered {
+		r.mu.Unlock()
+		cl.trySend(types.RoomMessage{Type: "error", Data: types.RoomErrorDTO{Message: "You already answered this question."}})
+		return
+	}
+
+	position := r.current
+	question := r.questions[position]
+	isCorrect, err := utils.EvaluateAnswer(question, answer)
+	if err != nil {
+		r.mu.Unlock()
+
+		message := "The answer does not match the question type."
+		if err == utils.ErrChoiceNotInQuestion {
+			message = "Choice does not belong to the current question."
+		}
+
+		cl.trySend(types.RoomMessage{Type: "error", Data: types.RoomErrorDTO{Message: message}})
+		return
+	}
+
+	answeredAt := time.Now()
+	elapsed := answeredAt.Sub(r.questionStart)
+	points := utils.QuestionPoints(isCorrect, elapsed, r.QuestionDuration)
+
+	// The answer is taken before it is saved, so it can't be sent twice and
+	// the countdown doesn't wait on the database
+	p.answered = true
+	gameQuestionID := p.gameQuestionIDs[position]
+	r.savingAnswers.Add(1)
+	defer r.savingAnswers.Done()
+	r.mu.Unlock()
+
+	err = r.hub.store.saveAnswer(p.gameID, gameQuestionID, savedAnswer{
+		ChoiceID:   utils.AnswerChoiceID(question.Type, answer),
+		Answer:     utils.FormatAnswer(question.Type, answer),
+		IsCorrect:  isCorrect,
+		Points:     points,
+		AnsweredAt: answeredAt,
+	})
+
+	r.mu.Lock()
+	if err != nil {
+		log.Printf("Error updating room game question in database: %v", err)
+
+		// The player may answer again while the question is open, otherwise
+		// the question is saved as timed out like the ones left unanswered
+		isOpen := r.allAnswered != nil && r.current == position
+		if isOpen {
+			p.answered = false
+		} else {
+			p.secondsTaken += uint(r.QuestionDuration.Seconds())
+		}
+		deadline := r.questionStart.Add(r.QuestionDuration)
+		r.mu.Unlock()
+
+		if !isOpen {
+			r.saveTimeouts([]string{gameQuestionID}, deadline)
+		}
+		cl.trySend(types.RoomMessage{Type: "error", Data: types.RoomErrorDTO{Message: "Internal server error while answering question."}})
+		return
+	}
+	defer r.mu.Unlock()
+
+	p.secondsTaken += uint(elapsed.Seconds())
+	p.score += points
+	if isCorrect {
+		p.correctAnswers++
+	}
+
+	cl.trySend(types.RoomMessage{Type: "answer_result", Data: types.RoomAnswerResultDTO{
+		Position:  position,
+		IsCorrect: isCorrect,
+		Points:    points,
+	}})
+
+	r.signalIfAllAnsweredLocked()
+}
+
+func (r *Room) signalIfAllAnsweredLocked() {
+	if r.allAnswered == nil {
+		return
+	}
+
+	for _, p := range r.players {
+		if p.client != nil && !p.answered {
+			return
+		}
+	}
+
+	close(r.allAnswered)
+	r.allAnswered = nil
+}
+
+// timeoutUnansweredLocked closes the current question for players that didn't
+// answer in time, returning their game questions to be saved as timed out.
+func (r *Room) timeoutUnansweredLocked() []string {
+	var gameQuestionIDs []string
+	for _, p := range r.players {
+		if p.answered {
+			continue
+		}
+
+		gameQuestionIDs = append(gameQuestionIDs, p.gameQuestionIDs[r.current])
+		p.answered = true
+		p.secondsTaken += uint(r.QuestionDuration.Seconds())
+	}
+
+	return gameQuestionIDs
+}
+
+// saveTimeouts stamps the deadline of the question on the timed out game
+// questions, so per-question timings stay valid.
+func (r *Room) saveTimeouts(gameQuestionIDs []string, deadline time.Time) {
+	if len(gameQuestionIDs) == 0 {
+		return
+	}
+
+	if err := r.hub.store.saveTimeouts(gameQuestionIDs, deadline); err != nil {
+		log.Printf("Error timing out room game questions in database: %v", err)
+	}
+}
+
+func (r *Room) finishGames() {
+	r.mu.Lock()
+	gameIDs := make([]string, 0, len(r.players))
+	for _, p := range r.players {
+		gameIDs = append(gameIDs, p.gameID)
+	}
+	r.mu.Unlock()
+
+	finishedAt := time.Now()
+	for _, gameID := range gameIDs {
+		if err := r.hub.store.finishGame(r.QuizID, gameID, finishedAt); err != nil {
+			log.Printf("Error finishing room game in database: %v", err)
+		}
+	}
+}
+
+func (r *Room) closeLocked() {
+	r.state = stateFinished
+	for _, p := range r.players {
+		if p.client != nil {
+			p.client.close()
+			p.client = nil
+		}
+	}
+	r.hub.remove(r.Code)
+}
+
+func (r *Room) broadcastLocked(msg types.RoomMessage) {
+	for _, p := range r.players {
+		if p.client != nil {
+			p.client.trySend(msg)
+		}
+	}
+}
+
+func (r *Room) lobbyLocked() types.RoomLobbyDTO {
+	players := make([]types.RoomPlayerDTO, 0, len(r.order))
+	for _, userID := range r.order {
+		p := r.players[userID]
+		players = append(players, types.RoomPlayerDTO{
+			UserID:    p.userID,
+			Username:  p.username,
+			Connected: p.client != nil,
+		})
+	}
+
+	return types.RoomLobbyDTO{
+		Code:    r.Code,
+		HostID:  r.hostID,
+		Players: players,
+	}
+}
+
+func (r *Room) questionLocked() types.RoomQuestionDTO {
+	question := utils.PlayerQuestion(r.questions[r.current])
+
+	choices := make([]types.GameQuestionChoiceDTO, len(question.Choices))
+	for i, choice := range question.Choices {
+		choices[i] = types.GameQuestionChoiceDTO{
+			ID:         choice.ID,
+			QuestionID: choice.QuestionID,
+			Content:    choice.Content,
+		}
+	}
+
+	return types.RoomQuestionDTO{
+		Position:       r.current,
+		TotalQuestions: len(r.questions),
+		Seconds:        int(r.QuestionDuration.Seconds()),
+		Deadline:       r.questionStart.Add(r.QuestionDuration),
+		Question: types.GameQuestionDTO{
+			ID:      question.ID,
+			QuizID:  question.QuizID,
+			Content: question.Content,
+			Type:    question.Type,
+			Choices: choices,
+		},
+	}
+}
+
+func (r *Room) leaderboardLocked(isFinished bool) types.RoomLeaderboardDTO {
+	entries := make([]types.RoomLeaderboardEntryDTO, 0, len(r.players))
+	for _, p := range r.players {
+		entries = append(entries, types.RoomLeaderboardEntryDTO{
+			UserID:            p.userID,
+			Username:          p.username,
+			CorrectAnswers:    p.correctAnswers,
+			Score:             p.score,
+			TotalSecondsTaken: p.secondsTaken,
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Score != entries[j].Score {
+			return entries[i].Score > entries[j].Score
+		}
+		if entries[i].CorrectAnswers != entries[j].CorrectAnswers {
+			return entries[i].CorrectAnswers > entries[j].CorrectAnswers
+		}
+		return entries[i].TotalSecondsTaken < entries[j].TotalSecondsTaken
+	})
+	for i := range entries {
+		entries[i].Rank = i + 1
+	}
+
+	// Only single answer questions have a correct choice worth revealing
+	var correctChoiceID string
+	question := r.questions[r.current]
+	if question.Type == schemas.QuestionTypeSingleChoice || question.Type == schemas.QuestionTypeTrueFalse {
+		for _, choice := range question.Choices {
+			if choice.IsCorrect != nil && *choice.IsCorrect {
+				correctChoiceID = choice.ID
+			}
+		}
+	}
+
+	return types.RoomLeaderboardDTO{
+		Position:        r.current,
+		IsFinished:      isFinished,
+		CorrectChoiceID: correctChoiceID,
+		Entries:         entries,
+	}
+}
@@ -0,0 +1,384 @@
+package rooms
+
+import (
+	"errors"
+	"intelliquiz/src/database/schemas"
+	"intelliquiz/src/types"
+	"intelliquiz/src/utils"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeStore keeps the games in memory. Answers fail with the errors in
+// answerErrs in turn, and when block is set they wait on it before returning.
+type fakeStore struct {
+	mu         sync.Mutex
+	createErr  error
+	answerErrs []error
+	block      chan struct{}
+	saving     chan struct{}
+	answers    map[string]savedAnswer
+	timeouts   map[string]time.Time
+	finished   []string
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{
+		saving:   make(chan struct{}, 8),
+		answers:  make(map[string]savedAnswer),
+		timeouts: make(map[string]time.Time),
+	}
+}
+
+func (s *fakeStore) createGames(quizID string, userIDs []string, questions []schemas.Question, startedAt time.Time) ([]schemas.Game, error) {
+	if s.createErr != nil {
+		return nil, s.createErr
+	}
+
+	games := make([]schemas.Game, len(userIDs))
+	for i, userID := range userIDs {
+		games[i] = schemas.Game{ID: "game-" + userID, QuizID: quizID, UserID: userID}
+		for j, question := range questions {
+			games[i].GameQuestions = append(games[i].GameQuestions, schemas.GameQuestion{
+				ID:         games[i].ID + "-" + strconv.Itoa(j),
+				GameID:     games[i].ID,
+				QuestionID: question.ID,
+				Position:   uint8(j),
+			})
+		}
+	}
+
+	return games, nil
+}
+
+func (s *fakeStore) saveAnswer(gameID, gameQuestionID string, answer savedAnswer) error {
+	s.saving <- struct{}{}
+	if s.block != nil {
+		<-s.block
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.answerErrs) > 0 {
+		err := s.answerErrs[0]
+		s.answerErrs = s.answerErrs[1:]
+		if err != nil {
+			return err
+		}
+	}
+	s.answers[gameQuestionID] = answer
+
+	return nil
+}
+
+func (s *fakeStore) saveTimeouts(gameQuestionIDs []string, deadline time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range gameQuestionIDs {
+		s.timeouts[id] = deadline
+	}
+
+	return nil
+}
+
+func (s *fakeStore) finishGame(quizID, gameID string, finishedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.finished = append(s.finished, gameID)
+
+	return nil
+}
+
+var errStoreDown = errors.New("store down")
+
+// testRoom opens a lobby hosted by "host" for a quiz of a single true or false
+// question, whose correct choice is "right".
+func testRoom(t *testing.T, store *fakeStore, questionDuration time.Duration) (*Hub, *Room) {
+	t.Helper()
+
+	correct, incorrect := true, false
+	quiz := schemas.Quiz{
+		ID: "quiz",
+		Questions: []schemas.Question{{
+			ID:     "question",
+			QuizID: "quiz",
+			Type:   schemas.QuestionTypeTrueFalse,
+			Choices: []schemas.Choice{
+				{ID: "right", QuestionID: "question", Content: "Verdadeiro", IsCorrect: &correct},
+				{ID: "wrong", QuestionID: "question", Content: "Falso", IsCorrect: &incorrect},
+			},
+		}},
+	}
+
+	hub := &Hub{store: store, rooms: make(map[string]*Room)}
+	room, err := hub.Create("host", quiz, questionDuration)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	return hub, room
+}
+
+// testJoin connects the user to the room with a client whose messages are
+// read straight from its queue.
+func testJoin(t *testing.T, room *Room, userID string) *client {
+	t.Helper()
+
+	cl := newClient(nil)
+	if err := room.attach(userID, userID, cl); err != nil {
+		t.Fatalf("attach %s: %v", userID, err)
+	}
+
+	return cl
+}
+
+// receive skips the messages of the client until one of the type arrives.
+func receive(t *testing.T, cl *client, messageType string) types.RoomMessage {
+	t.Helper()
+
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case msg, ok := <-cl.send:
+			if !ok {
+				t.Fatalf("the client was closed while waiting for a %q message", messageType)
+			}
+			if msg.Type == messageType {
+				return msg
+			}
+		case <-timeout:
+			t.Fatalf("no %q message was received", messageType)
+		}
+	}
+}
+
+func answerWith(choiceID string) utils.Answer {
+	return utils.NewAnswer(choiceID, nil, "", nil)
+}
+
+func TestRoomLobbyHandsHostOver(t *testing.T) {
+	hub, room := testRoom(t, newFakeStore(), time.Second)
+	host := testJoin(t, room, "host")
+	guest := testJoin(t, room, "guest")
+
+	room.detach("host", host)
+	if got := room.HostID(); got != "guest" {
+		t.Fatalf("got host %q after the host left, want %q", got, "guest")
+	}
+	lobby := receive(t, guest, "lobby").Data.(types.RoomLobbyDTO)
+	for len(lobby.Players) != 1 {
+		lobby = receive(t, guest, "lobby").Data.(types.RoomLobbyDTO)
+	}
+	if lobby.HostID != "guest" {
+		t.Errorf("got host %q in the lobby, want %q", lobby.HostID, "guest")
+	}
+	if err := room.start("host"); !errors.Is(err, ErrNotHost) {
+		t.Errorf("start by the former host: got error %v, want %v", err, ErrNotHost)
+	}
+
+	room.detach("guest", guest)
+	if _, err := hub.Get(room.Code); !errors.Is(err, ErrRoomNotFound) {
+		t.Errorf("got error %v once the lobby emptied, want %v", err, ErrRoomNotFound)
+	}
+	if err := room.CanJoin("guest"); !errors.Is(err, ErrRoomNotFound) {
+		t.Errorf("CanJoin on a closed room: got error %v, want %v", err, ErrRoomNotFound)
+	}
+}
+
+func TestRoomStart(t *testing.T) {
+	_, room := testRoom(t, newFakeStore(), time.Minute)
+	host := testJoin(t, room, "host")
+	testJoin(t, room, "guest")
+
+	if err := room.start("guest"); !errors.Is(err, ErrNotHost) {
+		t.Fatalf("start by a guest: got error %v, want %v", err, ErrNotHost)
+	}
+	if err := room.start("host"); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if err := room.start("host"); !errors.Is(err, ErrRoomAlreadyStarted) {
+		t.Errorf("starting twice: got error %v, want %v", err, ErrRoomAlreadyStarted)
+	}
+
+	question := receive(t, host, "question").Data.(types.RoomQuestionDTO)
+	if question.TotalQuestions != 1 || question.Question.ID != "question" {
+		t.Errorf("got question %+v", question)
+	}
+	for _, choice := range question.Question.Choices {
+		if choice.ID == "" {
+			t.Errorf("got a choice without ID in %+v", question.Question.Choices)
+		}
+	}
+
+	if err := room.CanJoin("stranger"); !errors.Is(err, ErrRoomAlreadyStarted) {
+		t.Errorf("CanJoin by a new player: got error %v, want %v", err, ErrRoomAlreadyStarted)
+	}
+	if err := room.CanJoin("guest"); err != nil {
+		t.Errorf("CanJoin by a player of the room: %v", err)
+	}
+}
+
+func TestRoomStartFailureReopensLobby(t *testing.T) {
+	store := newFakeStore()
+	store.createErr = errStoreDown
+	_, room := testRoom(t, store, time.Minute)
+	testJoin(t, room, "host")
+
+	if err := room.start("host"); !errors.Is(err, errStoreDown) {
+		t.Fatalf("got error %v, want %v", err, errStoreDown)
+	}
+	if err := room.CanJoin("stranger"); err != nil {
+		t.Errorf("CanJoin after the start failed: %v", err)
+	}
+
+	store.createErr = nil
+	if err := room.start("host"); err != nil {
+		t.Errorf("start after a failed one: %v", err)
+	}
+}
+
+func TestRoomFinishesOnceEveryoneAnswered(t *testing.T) {
+	store := newFakeStore()
+	hub, room := testRoom(t, store, time.Minute)
+	host := testJoin(t, room, "host")
+	guest := testJoin(t, room, "guest")
+
+	if err := room.start("host"); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	receive(t, host, "question")
+	receive(t, guest, "question")
+
+	room.answer("host", host, answerWith("right"))
+	if result := receive(t, host, "answer_result").Data.(types.RoomAnswerResultDTO); !result.IsCorrect || result.Points == 0 {
+		t.Errorf("got result %+v for the correct choice", result)
+	}
+	room.answer("host", host, answerWith("wrong"))
+	if msg := receive(t, host, "error").Data.(types.RoomErrorDTO); msg.Message != "You already answered this question." {
+		t.Errorf("got error %q answering twice", msg.Message)
+	}
+	room.answer("guest", guest, answerWith("wrong"))
+
+	// The countdown is a minute long, so the leaderboard comes from everyone
+	// having answered
+	leaderboard := receive(t, guest, "leaderboard").Data.(types.RoomLeaderboardDTO)
+	if !leaderboard.IsFinished || leaderboard.CorrectChoiceID != "right" {
+		t.Errorf("got leaderboard %+v, want the last one revealing %q", leaderboard, "right")
+	}
+	if len(leaderboard.Entries) != 2 || leaderboard.Entries[0].UserID != "host" || leaderboard.Entries[1].Score != 0 {
+		t.Errorf("got entries %+v, want the host first and the guest without points", leaderboard.Entries)
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if len(store.answers) != 2 || len(store.timeouts) != 0 {
+		t.Errorf("saved %d answers and %d timeouts, want 2 answers", len(store.answers), len(store.timeouts))
+	}
+	if len(store.finished) != 2 {
+		t.Errorf("finished games %v, want both", store.finished)
+	}
+	if _, err := hub.Get(room.Code); !errors.Is(err, ErrRoomNotFound) {
+		t.Errorf("got error %v once the room finished, want %v", err, ErrRoomNotFound)
+	}
+}
+
+func TestRoomTimesOutUnansweredQuestions(t *testing.T) {
+	store := newFakeStore()
+	_, room := testRoom(t, store, 200*time.Millisecond)
+	host := testJoin(t, room, "host")
+	testJoin(t, room, "guest")
+
+	if err := room.start("host"); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	question := receive(t, host, "question").Data.(types.RoomQuestionDTO)
+	room.answer("host", host, answerWith("right"))
+
+	receive(t, host, "leaderboard")
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	deadline, timedOut := store.timeouts["game-guest-0"]
+	if !timedOut || !deadline.Equal(question.Deadline) {
+		t.Errorf("got timeouts %v, want the question of the guest timed out at %v", store.timeouts, question.Deadline)
+	}
+	if _, answered := store.answers["game-host-0"]; !answered {
+		t.Errorf("the answer of the host was not saved")
+	}
+}
+
+func TestRoomAnswerFailingWhileOpenCanBeRetried(t *testing.T) {
+	store := newFakeStore()
+	store.answerErrs = []error{errStoreDown}
+	_, room := testRoom(t, store, time.Minute)
+	host := testJoin(t, room, "host")
+
+	if err := room.start("host"); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	receive(t, host, "question")
+
+	room.answer("host", host, answerWith("right"))
+	receive(t, host, "error")
+
+	room.answer("host", host, answerWith("right"))
+	if result := receive(t, host, "answer_result").Data.(types.RoomAnswerResultDTO); !result.IsCorrect {
+		t.Errorf("got result %+v retrying the correct choice", result)
+	}
+	receive(t, host, "leaderboard")
+}
+
+func TestRoomAnswerFailingAfterCountdownTimesOut(t *testing.T) {
+	store := newFakeStore()
+	store.answerErrs = []error{errStoreDown}
+	store.block = make(chan struct{})
+	_, room := testRoom(t, store, 50*time.Millisecond)
+	host := testJoin(t, room, "host")
+	testJoin(t, room, "guest")
+
+	if err := room.start("host"); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	question := receive(t, host, "question").Data.(types.RoomQuestionDTO)
+
+	answered := make(chan struct{})
+	go func() {
+		room.answer("host", host, answerWith("right"))
+		close(answered)
+	}()
+	<-store.saving
+
+	// The countdown expires while the answer is being saved, closing the
+	// question before the save fails
+	for closed := false; !closed; {
+		time.Sleep(10 * time.Millisecond)
+		room.mu.Lock()
+		closed = room.allAnswered == nil
+		room.mu.Unlock()
+	}
+	close(store.block)
+	<-answered
+
+	leaderboard := receive(t, host, "leaderboard").Data.(types.RoomLeaderboardDTO)
+	for _, entry := range leaderboard.Entries {
+		if entry.Score != 0 {
+			t.Errorf("got entry %+v, want no points for a lost answer", entry)
+		}
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	for _, id := range []string{"game-host-0", "game-guest-0"} {
+		if deadline, timedOut := store.timeouts[id]; !timedOut || !deadline.Equal(question.Deadline) {
+			t.Errorf("game question %s was not timed out at %v: got timeouts %v", id, question.Deadline, store.timeouts)
+		}
+	}
+	if len(store.answers) != 0 {
+		t.Errorf("saved answers %v, want none", store.answers)
+	}
+}
@@ -0,0 +1,111 @@
+package rooms
+
+import (
+	"context"
+	"intelliquiz/src/database/schemas"
+	"intelliquiz/src/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// savedAnswer is the answer of a player to a question of the room.
+type savedAnswer struct {
+	ChoiceID   *string
+	Answer     *string
+	IsCorrect  bool
+	Points     uint
+	AnsweredAt time.Time
+}
+
+// gameStore saves the games played in the rooms.
+type gameStore interface {
+	// createGames creates a game of the questions for every user, in the
+	// order of the users.
+	createGames(quizID string, userIDs []string, questions []schemas.Question, startedAt time.Time) ([]schemas.Game, error)
+	saveAnswer(gameID, gameQuestionID string, answer savedAnswer) error
+	// saveTimeouts stamps the deadline of the question on the timed out game
+	// questions.
+	saveTimeouts(gameQuestionIDs []string, deadline time.Time) error
+	finishGame(quizID, gameID string, finishedAt time.Time) error
+}
+
+type dbGameStore struct {
+	db *gorm.DB
+}
+
+func (s dbGameStore) createGames(quizID string, userIDs []string, questions []schemas.Question, startedAt time.Time) ([]schemas.Game, error) {
+	ctx := context.Background()
+	games := make([]schemas.Game, len(userIDs))
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		for i, userID := range userIDs {
+			games[i] = schemas.Game{
+				QuizID:    quizID,
+				UserID:    userID,
+				CreatedAt: &startedAt,
+			}
+			if err := gorm.G[schemas.Game](tx).Create(ctx, &games[i]); err != nil {
+				return err
+			}
+
+			gameQuestions := make([]schemas.GameQuestion, len(questions))
+			for j, question := range questions {
+				gameQuestions[j] = schemas.GameQuestion{
+					GameID:     games[i].ID,
+					QuestionID: question.ID,
+					Position:   uint8(j),
+				}
+			}
+			if err := gorm.G[[]schemas.GameQuestion](tx).Create(ctx, &gameQuestions); err != nil {
+				return err
+			}
+			games[i].GameQuestions = gameQuestions
+		}
+
+		return utils.CountQuizGames(tx, quizID, len(userIDs))
+	})
+
+	return games, err
+}
+
+func (s dbGameStore) saveAnswer(gameID, gameQuestionID string, answer savedAnswer) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&schemas.GameQuestion{}).
+			Where("id = ?", gameQuestionID).
+			Updates(map[string]any{
+				"choice_id":   answer.ChoiceID,
+				"answer":      answer.Answer,
+				"is_correct":  answer.IsCorrect,
+				"points":      answer.Points,
+				"answered_at": answer.AnsweredAt,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&schemas.Game{}).
+			Where("id = ?", gameID).
+			Update("score", gorm.Expr("score + ?", answer.Points)).Error
+	})
+}
+
+func (s dbGameStore) saveTimeouts(gameQuestionIDs []string, deadline time.Time) error {
+	return s.db.Model(&schemas.GameQuestion{}).
+		Where("id IN ?", gameQuestionIDs).
+		Updates(map[string]any{
+			"is_correct":  false,
+			"timed_out":   true,
+			"answered_at": deadline,
+		}).Error
+}
+
+func (s dbGameStore) finishGame(quizID, gameID string, finishedAt time.Time) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return utils.FinishGame(context.Background(), tx, schemas.Game{
+			ID:         gameID,
+			QuizID:     quizID,
+			FinishedAt: &finishedAt,
+		})
+	})
+}
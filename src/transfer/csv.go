@@ -0,0 +1,213 @@
+package transfer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"intelliquiz/src/database/schemas"
+	"intelliquiz/src/types"
+	"intelliquiz/src/utils"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// CSV files have one question per row. The correct column holds the 1-based
+// indexes of the correct choices separated by ";", the numeric answer for
+// numeric questions, or true/false for true/false questions without choices.
+// Ordering questions list their choices in the correct order and free-text
+// questions list their accepted answers as choices. The explanation column is
+// optional. There are as many choice columns as a question may have choices or
+// accepted answers, and fields past the last column are read as more choices.
+var csvHeader = func() []string {
+	header := []string{"question", "type", "correct", "tolerance", "explanation"}
+	for i := 1; i <= max(utils.MaxQuestionChoices, utils.MaxAcceptedAnswers); i++ {
+		header = append(header, "choice_"+strconv.Itoa(i))
+	}
+	return header
+}()
+
+func parseCSV(r io.Reader) (ParsedQuiz, []types.ImportQuizRowErrorDTO, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return ParsedQuiz{}, nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	var choiceColumns []int
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[name] = i
+		if strings.HasPrefix(name, "choice_") {
+			choiceColumns = append(choiceColumns, i)
+		}
+	}
+	if _, exists := columns["question"]; !exists {
+		return ParsedQuiz{}, nil, errors.New("the header must have a question column")
+	}
+
+	var quiz ParsedQuiz
+	var rowErrors []types.ImportQuizRowErrorDTO
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rowErrors = append(rowErrors, rowError(parseErr.Line, "Malformed CSV row."))
+				continue
+			}
+			return ParsedQuiz{}, nil, err
+		}
+
+		row, _ := reader.FieldPos(0)
+		field := func(name string) string {
+			if i, exists := columns[name]; exists && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		var choices []string
+		for _, i := range choiceColumns {
+			if i < len(record) && strings.TrimSpace(record[i]) != "" {
+				choices = append(choices, strings.TrimSpace(record[i]))
+			}
+		}
+		if len(choiceColumns) > 0 {
+			for _, value := range record[min(len(header), len(record)):] {
+				if strings.TrimSpace(value) != "" {
+					choices = append(choices, strings.TrimSpace(value))
+				}
+			}
+		}
+
+		if field("question") == "" && len(choices) == 0 {
+			continue
+		}
+
+		question, err := csvQuestion(field("question"), field("type"), field("correct"), field("tolerance"), choices)
+		if err != nil {
+			rowErrors = append(rowErrors, rowError(row, err.Error()))
+			continue
+		}
+		question.Explanation = field("explanation")
+
+		quiz.Questions = append(quiz.Questions, ParsedQuestion{Row: row, Question: question})
+	}
+
+	return quiz, rowErrors, nil
+}
+
+func csvQuestion(content, questionType, correct, tolerance string, choices []string) (types.CreateQuizQuestionsStruct, error) {
+	question := types.CreateQuizQuestionsStruct{
+		Content: content,
+		Type:    strings.ToLower(questionType),
+	}
+	if question.Type == "" {
+		question.Type = schemas.QuestionTypeSingleChoice
+	}
+
+	switch question.Type {
+	case schemas.QuestionTypeFreeText:
+		question.AcceptedAnswers = choices
+		return question, nil
+	case schemas.QuestionTypeNumeric:
+		answer, err := strconv.ParseFloat(correct, 64)
+		if err != nil {
+			return question, errors.New("The correct column must hold the numeric answer.")
+		}
+		question.NumericAnswer = &answer
+
+		if tolerance != "" {
+			value, err := strconv.ParseFloat(tolerance, 64)
+			if err != nil {
+				return question, errors.New("The tolerance column must be a number.")
+			}
+			question.NumericTolerance = &value
+		}
+		return question, nil
+	case schemas.QuestionTypeTrueFalse:
+		if len(choices) == 0 {
+			isTrue, err := strconv.ParseBool(correct)
+			if err != nil {
+				return question, errors.New("The correct column must be true or false.")
+			}
+			question.Choices = trueFalseChoices(isTrue)
+			return question, nil
+		}
+	}
+
+	for _, choice := range choices {
+		question.Choices = append(question.Choices, types.CreateQuizQuestionChoiceStruct{Content: choice})
+	}
+	if question.Type == schemas.QuestionTypeOrdering {
+		return question, nil
+	}
+
+	for _, index := range strings.Split(correct, ";") {
+		index = strings.TrimSpace(index)
+		if index == "" {
+			continue
+		}
+
+		n, err := strconv.Atoi(index)
+		if err != nil || n < 1 || n > len(question.Choices) {
+			return question, fmt.Errorf("Invalid correct choice index: %s.", index)
+		}
+		question.Choices[n-1].IsCorrect = true
+	}
+
+	return question, nil
+}
+
+func exportCSV(questions []types.CreateQuizQuestionsStruct, w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, question := range questions {
+		record := make([]string, 5, len(csvHeader))
+		record[0] = question.Content
+		record[1] = question.Type
+		record[4] = question.Explanation
+
+		switch question.Type {
+		case schemas.QuestionTypeFreeText:
+			record = append(record, question.AcceptedAnswers...)
+		case schemas.QuestionTypeNumeric:
+			if question.NumericAnswer != nil {
+				record[2] = strconv.FormatFloat(*question.NumericAnswer, 'f', -1, 64)
+			}
+			if question.NumericTolerance != nil {
+				record[3] = strconv.FormatFloat(*question.NumericTolerance, 'f', -1, 64)
+			}
+		default:
+			var correct []string
+			for i, choice := range question.Choices {
+				record = append(record, choice.Content)
+				if choice.IsCorrect && question.Type != schemas.QuestionTypeOrdering {
+					correct = append(correct, strconv.Itoa(i+1))
+				}
+			}
+			record[2] = strings.Join(correct, ";")
+		}
+
+		for len(record) < len(csvHeader) {
+			record = append(record, "")
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,411 @@
+package transfer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"intelliquiz/src/database/schemas"
+	"intelliquiz/src/types"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// GIFT is Moodle's plain text format. Questions are separated by blank lines
+// and their answers are written between braces:
+//
+//	::Title:: Question {=right ~wrong}        single choice
+//	Question {~%50%right ~%50%right ~wrong}   multiple select
+//	Question {T}                              true/false
+//	Question {=accepted =also accepted}       free-text
+//	Question {#3.14:0.01}                     numeric
+//	Question {=right ~wrong ####Explanation}  general feedback as explanation
+//
+// Ordering questions have no GIFT equivalent and are left out of exports.
+
+const giftSpecialChars = `\~=#{}:`
+
+var giftFormatPrefixes = []string{"[html]", "[plain]", "[markdown]", "[moodle]"}
+
+type giftAnswer struct {
+	prefix byte
+	weight *float64
+	text   string
+}
+
+func parseGIFT(r io.Reader) (ParsedQuiz, []types.ImportQuizRowErrorDTO, error) {
+	var blocks []string
+	var current strings.Builder
+	flush := func() {
+		if block := strings.TrimSpace(current.String()); block != "" {
+			blocks = append(blocks, block)
+		}
+		current.Reset()
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			flush()
+		case strings.HasPrefix(trimmed, "//"), strings.HasPrefix(trimmed, "$CATEGORY:"):
+			continue
+		default:
+			current.WriteString(line)
+			current.WriteByte('\n')
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return ParsedQuiz{}, nil, err
+	}
+	flush()
+
+	var quiz ParsedQuiz
+	var rowErrors []types.ImportQuizRowErrorDTO
+	for i, block := range blocks {
+		question, err := giftQuestion(block)
+		if err != nil {
+			rowErrors = append(rowErrors, rowError(i+1, err.Error()))
+			continue
+		}
+
+		quiz.Questions = append(quiz.Questions, ParsedQuestion{Row: i + 1, Question: question})
+	}
+
+	return quiz, rowErrors, nil
+}
+
+func giftQuestion(block string) (types.CreateQuizQuestionsStruct, error) {
+	var question types.CreateQuizQuestionsStruct
+
+	if strings.HasPrefix(block, "::") {
+		end := indexUnescaped(block[2:], ':')
+		if end < 0 || !strings.HasPrefix(block[2+end:], "::") {
+			return question, errors.New("Unclosed question title.")
+		}
+		block = strings.TrimSpace(block[2+end+2:])
+	}
+	for _, prefix := range giftFormatPrefixes {
+		block = strings.TrimPrefix(block, prefix)
+	}
+
+	open := indexUnescaped(block, '{')
+	if open < 0 {
+		return question, errors.New("Missing answer block between braces.")
+	}
+	closing := indexUnescaped(block[open:], '}')
+	if closing < 0 {
+		return question, errors.New("Unclosed answer block.")
+	}
+	closing += open
+
+	// Missing word questions keep a blank where the answers were
+	content := strings.TrimSpace(block[:open])
+	if after := strings.TrimSpace(block[closing+1:]); after != "" {
+		content += " _____ " + after
+	}
+	question.Content = unescapeGIFT(content)
+
+	body, explanation := cutGIFTGeneralFeedback(block[open+1 : closing])
+	body = strings.TrimSpace(body)
+	question.Explanation = explanation
+	if strings.HasPrefix(body, "#") {
+		return giftNumericQuestion(question, body[1:])
+	}
+
+	switch strings.ToUpper(strings.TrimSpace(stripGIFTFeedback(body))) {
+	case "":
+		return question, errors.New("Essay questions are not supported.")
+	case "T", "TRUE", "F", "FALSE":
+		isTrue := strings.HasPrefix(strings.ToUpper(body), "T")
+		question.Type = schemas.QuestionTypeTrueFalse
+		question.Choices = trueFalseChoices(isTrue)
+		return question, nil
+	}
+
+	if strings.Contains(body, "->") {
+		return question, errors.New("Matching questions are not supported.")
+	}
+
+	answers, err := giftAnswers(body)
+	if err != nil {
+		return question, err
+	}
+
+	hasWrong, hasWeighted := false, false
+	for _, answer := range answers {
+		if answer.prefix == '~' && (answer.weight == nil || *answer.weight <= 0) {
+			hasWrong = true
+		}
+		if answer.prefix == '~' && answer.weight != nil && *answer.weight > 0 {
+			hasWeighted = true
+		}
+	}
+
+	switch {
+	case !hasWrong && !hasWeighted:
+		question.Type = schemas.QuestionTypeFreeText
+		for _, answer := range answers {
+			question.AcceptedAnswers = append(question.AcceptedAnswers, answer.text)
+		}
+	case hasWeighted:
+		question.Type = schemas.QuestionTypeMultipleSelect
+	default:
+		question.Type = schemas.QuestionTypeSingleChoice
+	}
+
+	if question.Type != schemas.QuestionTypeFreeText {
+		for _, answer := range answers {
+			question.Choices = append(question.Choices, types.CreateQuizQuestionChoiceStruct{
+				Content:   answer.text,
+				IsCorrect: answer.prefix == '=' || (answer.weight != nil && *answer.weight > 0),
+			})
+		}
+	}
+
+	return question, nil
+}
+
+// giftNumericQuestion reads "value:tolerance", "min..max" or a list of "="
+// answers, of which the first one is used.
+func giftNumericQuestion(question types.CreateQuizQuestionsStruct, body string) (types.CreateQuizQuestionsStruct, error) {
+	question.Type = schemas.QuestionTypeNumeric
+
+	if strings.HasPrefix(strings.TrimSpace(body), "=") {
+		answers, err := giftAnswers(body)
+		if err != nil {
+			return question, err
+		}
+		body = answers[0].text
+	}
+	body = strings.TrimSpace(stripGIFTFeedback(body))
+
+	var answer, tolerance float64
+	var err error
+	if minValue, maxValue, isRange := strings.Cut(body, ".."); isRange {
+		var lower, upper float64
+		if lower, err = strconv.ParseFloat(strings.TrimSpace(minValue), 64); err == nil {
+			upper, err = strconv.ParseFloat(strings.TrimSpace(maxValue), 64)
+		}
+		answer = (lower + upper) / 2
+		tolerance = (upper - lower) / 2
+	} else {
+		value, margin, hasMargin := strings.Cut(body, ":")
+		answer, err = strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err == nil && hasMargin {
+			tolerance, err = strconv.ParseFloat(strings.TrimSpace(margin), 64)
+		}
+	}
+	if err != nil {
+		return question, errors.New("Invalid numeric answer.")
+	}
+
+	question.NumericAnswer = &answer
+	question.NumericTolerance = &tolerance
+	return question, nil
+}
+
+func giftAnswers(body string) ([]giftAnswer, error) {
+	var answers []giftAnswer
+	start := -1
+	for i := 0; i <= len(body); i++ {
+		if i < len(body) && body[i] == '\\' {
+			i++
+			continue
+		}
+		if i < len(body) && body[i] != '=' && body[i] != '~' {
+			continue
+		}
+
+		if start >= 0 {
+			answer, err := newGIFTAnswer(body[start], body[start+1:i])
+			if err != nil {
+				return nil, err
+			}
+			answers = append(answers, answer)
+		} else if strings.TrimSpace(body[:min(i, len(body))]) != "" {
+			return nil, errors.New("Answers must start with = or ~.")
+		}
+		start = i
+	}
+
+	if len(answers) == 0 {
+		return nil, errors.New("No answers found.")
+	}
+
+	return answers, nil
+}
+
+func newGIFTAnswer(prefix byte, raw string) (giftAnswer, error) {
+	answer := giftAnswer{prefix: prefix}
+	raw = strings.TrimSpace(stripGIFTFeedback(raw))
+
+	if strings.HasPrefix(raw, "%") {
+		end := strings.Index(raw[1:], "%")
+		if end < 0 {
+			return answer, errors.New("Unclosed answer weight.")
+		}
+		weight, err := strconv.ParseFloat(raw[1:end+1], 64)
+		if err != nil {
+			return answer, errors.New("Invalid answer weight.")
+		}
+		answer.weight = &weight
+		raw = strings.TrimSpace(raw[end+2:])
+	}
+
+	answer.text = unescapeGIFT(raw)
+	if answer.text == "" {
+		return answer, errors.New("Answers cannot be empty.")
+	}
+
+	return answer, nil
+}
+
+func exportGIFT(questions []types.CreateQuizQuestionsStruct, w io.Writer) error {
+	writer := bufio.NewWriter(w)
+
+	for i, question := range questions {
+		if question.Type == schemas.QuestionTypeOrdering {
+			fmt.Fprintf(writer, "// Question %d is an ordering question, which GIFT does not support: %s\n\n", i+1, strings.ReplaceAll(question.Content, "\n", " "))
+			continue
+		}
+
+		fmt.Fprintf(writer, "::Q%d:: %s {", i+1, escapeGIFT(question.Content))
+
+		// Numeric and true/false answers fit on the question line
+		inline := false
+		switch question.Type {
+		case schemas.QuestionTypeFreeText:
+			for _, accepted := range question.AcceptedAnswers {
+				fmt.Fprintf(writer, "\n\t=%s", escapeGIFT(accepted))
+			}
+		case schemas.QuestionTypeNumeric:
+			var answer, tolerance float64
+			if question.NumericAnswer != nil {
+				answer = *question.NumericAnswer
+			}
+			if question.NumericTolerance != nil {
+				tolerance = *question.NumericTolerance
+			}
+			fmt.Fprintf(writer, "#%s:%s", formatGIFTNumber(answer), formatGIFTNumber(tolerance))
+			inline = true
+		case schemas.QuestionTypeMultipleSelect:
+			correctChoices := 0
+			for _, choice := range question.Choices {
+				if choice.IsCorrect {
+					correctChoices++
+				}
+			}
+			for _, choice := range question.Choices {
+				weight := -100.0
+				if choice.IsCorrect {
+					weight = 100 / float64(correctChoices)
+				}
+				fmt.Fprintf(writer, "\n\t~%%%s%%%s", formatGIFTNumber(weight), escapeGIFT(choice.Content))
+			}
+		default:
+			if isPair, isTrue := isTrueFalsePair(question.Choices); isPair && question.Type == schemas.QuestionTypeTrueFalse {
+				if isTrue {
+					writer.WriteString("T")
+				} else {
+					writer.WriteString("F")
+				}
+				inline = true
+				break
+			}
+
+			for _, choice := range question.Choices {
+				prefix := "~"
+				if choice.IsCorrect {
+					prefix = "="
+				}
+				fmt.Fprintf(writer, "\n\t%s%s", prefix, escapeGIFT(choice.Content))
+			}
+		}
+
+		if question.Explanation != "" {
+			if !inline {
+				writer.WriteString("\n\t")
+			}
+			fmt.Fprintf(writer, "####%s", escapeGIFT(question.Explanation))
+		}
+
+		if inline {
+			writer.WriteString("}\n\n")
+		} else {
+			writer.WriteString("\n}\n\n")
+		}
+	}
+
+	return writer.Flush()
+}
+
+func indexUnescaped(s string, target byte) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if s[i] == target {
+			return i
+		}
+	}
+	return -1
+}
+
+// cutGIFTGeneralFeedback splits the general feedback, written after "####",
+// from the answers.
+func cutGIFTGeneralFeedback(body string) (string, string) {
+	for i := 0; i < len(body); i++ {
+		if body[i] == '\\' {
+			i++
+			continue
+		}
+		if strings.HasPrefix(body[i:], "####") {
+			return body[:i], unescapeGIFT(body[i+4:])
+		}
+	}
+	return body, ""
+}
+
+func stripGIFTFeedback(s string) string {
+	if i := indexUnescaped(s, '#'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+func escapeGIFT(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(giftSpecialChars, r) {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+func unescapeGIFT(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+			if s[i] == 'n' {
+				sb.WriteByte('\n')
+				continue
+			}
+		}
+		sb.WriteByte(s[i])
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+func formatGIFTNumber(n float64) string {
+	formatted := strconv.FormatFloat(n, 'f', 5, 64)
+	formatted = strings.TrimRight(formatted, "0")
+	return strings.TrimSuffix(formatted, ".")
+}
@@ -0,0 +1,43 @@
+package transfer
+
+import (
+	"encoding/json"
+	"intelliquiz/src/database/schemas"
+	"intelliquiz/src/types"
+	"io"
+)
+
+// The JSON format is the CreateQuiz request body, so exported quizzes can be
+// posted back as they are.
+func parseJSON(r io.Reader) (ParsedQuiz, []types.ImportQuizRowErrorDTO, error) {
+	var body types.CreateQuizRequestBody
+	if err := json.NewDecoder(r).Decode(&body); err != nil {
+		return ParsedQuiz{}, nil, err
+	}
+
+	quiz := ParsedQuiz{
+		Name:              body.Name,
+		CategoryID:        body.CategoryID,
+		ImageUrl:          body.ImageUrl,
+		QuestionTimeLimit: body.QuestionTimeLimit,
+	}
+
+	for i, question := range body.Questions {
+		quiz.Questions = append(quiz.Questions, ParsedQuestion{Row: i + 1, Question: question})
+	}
+
+	return quiz, nil, nil
+}
+
+func exportJSON(quiz schemas.Quiz, questions []types.CreateQuizQuestionsStruct, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(types.CreateQuizRequestBody{
+		Name:              quiz.Name,
+		CategoryID:        quiz.CategoryID,
+		Questions:         questions,
+		ImageUrl:          quiz.ImageUrl,
+		QuestionTimeLimit: quiz.QuestionTimeLimit,
+	})
+}
@@ -0,0 +1,280 @@
+package transfer
+
+import (
+	"encoding/xml"
+	"errors"
+	"html"
+	"intelliquiz/src/database/schemas"
+	"intelliquiz/src/types"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	moodleMultiChoice = "multichoice"
+	moodleTrueFalse   = "truefalse"
+	moodleShortAnswer = "shortanswer"
+	moodleNumerical   = "numerical"
+	moodleOrdering    = "ordering"
+	moodleCategory    = "category"
+
+	moodleNameLength = 50
+)
+
+var htmlTagRegex = regexp.MustCompile(`<[^>]*>`)
+
+type moodleQuiz struct {
+	XMLName   xml.Name         `xml:"quiz"`
+	Questions []moodleQuestion `xml:"question"`
+}
+
+type moodleText struct {
+	Format string `xml:"format,attr,omitempty"`
+	Text   string `xml:"text"`
+}
+
+type moodleAnswer struct {
+	Fraction  string `xml:"fraction,attr"`
+	Format    string `xml:"format,attr,omitempty"`
+	Text      string `xml:"text"`
+	Tolerance string `xml:"tolerance,omitempty"`
+}
+
+type moodleQuestion struct {
+	Type         string         `xml:"type,attr"`
+	Name         *moodleText    `xml:"name,omitempty"`
+	QuestionText *moodleText    `xml:"questiontext,omitempty"`
+	Feedback     *moodleText    `xml:"generalfeedback,omitempty"`
+	Single       string         `xml:"single,omitempty"`
+	Answers      []moodleAnswer `xml:"answer"`
+}
+
+func parseMoodleXML(r io.Reader) (ParsedQuiz, []types.ImportQuizRowErrorDTO, error) {
+	var document moodleQuiz
+	if err := xml.NewDecoder(r).Decode(&document); err != nil {
+		return ParsedQuiz{}, nil, err
+	}
+
+	var quiz ParsedQuiz
+	var rowErrors []types.ImportQuizRowErrorDTO
+	row := 0
+	for _, element := range document.Questions {
+		if element.Type == moodleCategory {
+			continue
+		}
+		row++
+
+		question, err := moodleQuestionInput(element)
+		if err != nil {
+			rowErrors = append(rowErrors, rowError(row, err.Error()))
+			continue
+		}
+
+		quiz.Questions = append(quiz.Questions, ParsedQuestion{Row: row, Question: question})
+	}
+
+	return quiz, rowErrors, nil
+}
+
+func moodleQuestionInput(element moodleQuestion) (types.CreateQuizQuestionsStruct, error) {
+	var question types.CreateQuizQuestionsStruct
+	if element.QuestionText != nil {
+		question.Content = moodlePlainText(*element.QuestionText)
+	}
+	if element.Feedback != nil {
+		question.Explanation = moodlePlainText(*element.Feedback)
+	}
+
+	switch element.Type {
+	case moodleMultiChoice:
+		question.Type = schemas.QuestionTypeSingleChoice
+		if strings.TrimSpace(element.Single) == "false" {
+			question.Type = schemas.QuestionTypeMultipleSelect
+		}
+
+		for _, answer := range element.Answers {
+			question.Choices = append(question.Choices, types.CreateQuizQuestionChoiceStruct{
+				Content:   moodlePlainText(moodleText{Format: answer.Format, Text: answer.Text}),
+				IsCorrect: moodleFraction(answer) > 0,
+			})
+		}
+	case moodleTrueFalse:
+		question.Type = schemas.QuestionTypeTrueFalse
+
+		isTrue, found := false, false
+		for _, answer := range element.Answers {
+			if strings.EqualFold(strings.TrimSpace(answer.Text), "true") && moodleFraction(answer) > 0 {
+				isTrue, found = true, true
+			}
+			if strings.EqualFold(strings.TrimSpace(answer.Text), "false") && moodleFraction(answer) > 0 {
+				isTrue, found = false, true
+			}
+		}
+		if !found {
+			return question, errors.New("The true/false question has no correct answer.")
+		}
+		question.Choices = trueFalseChoices(isTrue)
+	case moodleShortAnswer:
+		question.Type = schemas.QuestionTypeFreeText
+		for _, answer := range element.Answers {
+			if moodleFraction(answer) > 0 {
+				question.AcceptedAnswers = append(question.AcceptedAnswers, moodlePlainText(moodleText{Format: answer.Format, Text: answer.Text}))
+			}
+		}
+	case moodleNumerical:
+		question.Type = schemas.QuestionTypeNumeric
+		for _, answer := range element.Answers {
+			if moodleFraction(answer) < 100 {
+				continue
+			}
+
+			value, err := strconv.ParseFloat(strings.TrimSpace(answer.Text), 64)
+			if err != nil {
+				return question, errors.New("Invalid numeric answer.")
+			}
+			question.NumericAnswer = &value
+
+			if answer.Tolerance != "" {
+				tolerance, err := strconv.ParseFloat(strings.TrimSpace(answer.Tolerance), 64)
+				if err != nil {
+					return question, errors.New("Invalid numeric tolerance.")
+				}
+				question.NumericTolerance = &tolerance
+			}
+			break
+		}
+	case moodleOrdering:
+		// Answers of ordering questions are listed in the correct order
+		question.Type = schemas.QuestionTypeOrdering
+		for _, answer := range element.Answers {
+			question.Choices = append(question.Choices, types.CreateQuizQuestionChoiceStruct{
+				Content: moodlePlainText(moodleText{Format: answer.Format, Text: answer.Text}),
+			})
+		}
+	default:
+		return question, errors.New("Unsupported Moodle question type: " + element.Type + ".")
+	}
+
+	return question, nil
+}
+
+func exportMoodleXML(questions []types.CreateQuizQuestionsStruct, w io.Writer) error {
+	var document moodleQuiz
+	for _, question := range questions {
+		element := moodleQuestion{
+			Name:         &moodleText{Text: moodleName(question.Content)},
+			QuestionText: &moodleText{Format: "plain_text", Text: question.Content},
+		}
+		if question.Explanation != "" {
+			element.Feedback = &moodleText{Format: "plain_text", Text: question.Explanation}
+		}
+
+		switch question.Type {
+		case schemas.QuestionTypeMultipleSelect:
+			element.Type = moodleMultiChoice
+			element.Single = "false"
+
+			correctChoices := 0
+			for _, choice := range question.Choices {
+				if choice.IsCorrect {
+					correctChoices++
+				}
+			}
+			for _, choice := range question.Choices {
+				fraction := "-100"
+				if choice.IsCorrect {
+					fraction = formatGIFTNumber(100 / float64(correctChoices))
+				}
+				element.Answers = append(element.Answers, moodleAnswer{Fraction: fraction, Format: "plain_text", Text: choice.Content})
+			}
+		case schemas.QuestionTypeTrueFalse:
+			if isPair, isTrue := isTrueFalsePair(question.Choices); isPair {
+				element.Type = moodleTrueFalse
+				element.Answers = []moodleAnswer{
+					{Fraction: moodleBoolFraction(isTrue), Format: "moodle_auto_format", Text: "true"},
+					{Fraction: moodleBoolFraction(!isTrue), Format: "moodle_auto_format", Text: "false"},
+				}
+				break
+			}
+			fallthrough
+		case schemas.QuestionTypeSingleChoice:
+			element.Type = moodleMultiChoice
+			element.Single = "true"
+			for _, choice := range question.Choices {
+				element.Answers = append(element.Answers, moodleAnswer{Fraction: moodleBoolFraction(choice.IsCorrect), Format: "plain_text", Text: choice.Content})
+			}
+		case schemas.QuestionTypeOrdering:
+			element.Type = moodleOrdering
+			for _, choice := range question.Choices {
+				element.Answers = append(element.Answers, moodleAnswer{Fraction: "0", Format: "plain_text", Text: choice.Content})
+			}
+		case schemas.QuestionTypeFreeText:
+			element.Type = moodleShortAnswer
+			for _, accepted := range question.AcceptedAnswers {
+				element.Answers = append(element.Answers, moodleAnswer{Fraction: "100", Format: "moodle_auto_format", Text: accepted})
+			}
+		case schemas.QuestionTypeNumeric:
+			element.Type = moodleNumerical
+
+			answer := moodleAnswer{Fraction: "100", Format: "moodle_auto_format", Tolerance: "0"}
+			if question.NumericAnswer != nil {
+				answer.Text = strconv.FormatFloat(*question.NumericAnswer, 'f', -1, 64)
+			}
+			if question.NumericTolerance != nil {
+				answer.Tolerance = strconv.FormatFloat(*question.NumericTolerance, 'f', -1, 64)
+			}
+			element.Answers = []moodleAnswer{answer}
+		}
+
+		document.Questions = append(document.Questions, element)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// moodlePlainText strips the markup of HTML texts, which is Moodle's default
+// format.
+func moodlePlainText(text moodleText) string {
+	content := text.Text
+	if text.Format == "" || text.Format == "html" {
+		content = html.UnescapeString(htmlTagRegex.ReplaceAllString(content, " "))
+	}
+
+	return strings.Join(strings.Fields(content), " ")
+}
+
+func moodleFraction(answer moodleAnswer) float64 {
+	fraction, err := strconv.ParseFloat(strings.TrimSpace(answer.Fraction), 64)
+	if err != nil {
+		return 0
+	}
+	return fraction
+}
+
+func moodleBoolFraction(isCorrect bool) string {
+	if isCorrect {
+		return "100"
+	}
+	return "0"
+}
+
+func moodleName(content string) string {
+	name := []rune(strings.Join(strings.Fields(content), " "))
+	if len(name) > moodleNameLength {
+		return string(name[:moodleNameLength]) + "..."
+	}
+	return string(name)
+}
@@ -0,0 +1,197 @@
+package transfer
+
+import (
+	"errors"
+	"intelliquiz/src/database/schemas"
+	"intelliquiz/src/types"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	FormatJSON      = "json"
+	FormatCSV       = "csv"
+	FormatGIFT      = "gift"
+	FormatMoodleXML = "moodlexml"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported format")
+
+// ParsedQuestion is an imported question along with the row it came from: the
+// line for CSV files and the question number for the other formats.
+type ParsedQuestion struct {
+	Row      int
+	Question types.CreateQuizQuestionsStruct
+}
+
+// ParsedQuiz holds an imported quiz. Only JSON files carry the quiz metadata,
+// the other formats describe just the questions.
+type ParsedQuiz struct {
+	Name              string
+	CategoryID        string
+	ImageUrl          string
+	QuestionTimeLimit *uint
+	Questions         []ParsedQuestion
+}
+
+// Parse reads a quiz file. Rows that cannot be understood are reported as row
+// errors so every problem can be fixed at once; the returned error is only set
+// when the file itself is unreadable.
+func Parse(format string, r io.Reader) (ParsedQuiz, []types.ImportQuizRowErrorDTO, error) {
+	switch format {
+	case FormatJSON:
+		return parseJSON(r)
+	case FormatCSV:
+		return parseCSV(r)
+	case FormatGIFT:
+		return parseGIFT(r)
+	case FormatMoodleXML:
+		return parseMoodleXML(r)
+	}
+
+	return ParsedQuiz{}, nil, ErrUnsupportedFormat
+}
+
+// Export writes the quiz, loaded with its questions and their choices, in the
+// given format.
+func Export(format string, quiz schemas.Quiz, w io.Writer) error {
+	questions := make([]types.CreateQuizQuestionsStruct, len(quiz.Questions))
+	for i, question := range quiz.Questions {
+		questions[i] = questionInput(question)
+	}
+
+	switch format {
+	case FormatJSON:
+		return exportJSON(quiz, questions, w)
+	case FormatCSV:
+		return exportCSV(questions, w)
+	case FormatGIFT:
+		return exportGIFT(questions, w)
+	case FormatMoodleXML:
+		return exportMoodleXML(questions, w)
+	}
+
+	return ErrUnsupportedFormat
+}
+
+func IsSupportedFormat(format string) bool {
+	return slices.Contains([]string{FormatJSON, FormatCSV, FormatGIFT, FormatMoodleXML}, format)
+}
+
+// FormatFromFilename guesses the format of an uploaded file by its extension.
+func FormatFromFilename(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		return FormatJSON
+	case ".csv":
+		return FormatCSV
+	case ".gift", ".txt":
+		return FormatGIFT
+	case ".xml":
+		return FormatMoodleXML
+	}
+
+	return ""
+}
+
+func ContentType(format string) string {
+	switch format {
+	case FormatJSON:
+		return "application/json"
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatMoodleXML:
+		return "application/xml"
+	}
+
+	return "text/plain; charset=utf-8"
+}
+
+func FileExtension(format string) string {
+	switch format {
+	case FormatGIFT:
+		return "gift"
+	case FormatMoodleXML:
+		return "xml"
+	}
+
+	return format
+}
+
+// questionInput converts a stored question back into the creation payload,
+// which is what every format is written from.
+func questionInput(question schemas.Question) types.CreateQuizQuestionsStruct {
+	input := types.CreateQuizQuestionsStruct{
+		Content:          question.Content,
+		Type:             question.Type,
+		NumericAnswer:    question.NumericAnswer,
+		NumericTolerance: question.NumericTolerance,
+		Explanation:      question.Explanation,
+	}
+	if input.Type == "" {
+		input.Type = schemas.QuestionTypeSingleChoice
+	}
+
+	choices := slices.Clone(question.Choices)
+	if input.Type == schemas.QuestionTypeOrdering {
+		slices.SortStableFunc(choices, func(a, b schemas.Choice) int {
+			return int(choicePosition(a)) - int(choicePosition(b))
+		})
+	}
+
+	for _, choice := range choices {
+		if input.Type == schemas.QuestionTypeFreeText {
+			input.AcceptedAnswers = append(input.AcceptedAnswers, choice.Content)
+			continue
+		}
+
+		input.Choices = append(input.Choices, types.CreateQuizQuestionChoiceStruct{
+			Content:   choice.Content,
+			IsCorrect: choice.IsCorrect != nil && *choice.IsCorrect,
+		})
+	}
+
+	return input
+}
+
+func choicePosition(choice schemas.Choice) int {
+	if choice.Position == nil {
+		return int(^uint8(0)) + 1
+	}
+	return int(*choice.Position)
+}
+
+// trueFalseChoices builds the choices of a true/false question for the formats
+// that only state which of the two is correct.
+func trueFalseChoices(isTrue bool) []types.CreateQuizQuestionChoiceStruct {
+	return []types.CreateQuizQuestionChoiceStruct{
+		{Content: "True", IsCorrect: isTrue},
+		{Content: "False", IsCorrect: !isTrue},
+	}
+}
+
+// isTrueFalsePair reports whether the choices are the plain True/False pair,
+// returning whether True is the correct one.
+func isTrueFalsePair(choices []types.CreateQuizQuestionChoiceStruct) (isPair, isTrue bool) {
+	if len(choices) != 2 {
+		return false, false
+	}
+
+	for _, choice := range choices {
+		switch strings.ToLower(strings.TrimSpace(choice.Content)) {
+		case "true":
+			isTrue = choice.IsCorrect
+		case "false":
+		default:
+			return false, false
+		}
+	}
+
+	return !strings.EqualFold(choices[0].Content, choices[1].Content), isTrue
+}
+
+func rowError(row int, message string) types.ImportQuizRowErrorDTO {
+	return types.ImportQuizRowErrorDTO{Row: row, Message: message}
+}
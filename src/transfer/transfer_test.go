@@ -0,0 +1,217 @@
+package transfer
+
+import (
+	"bytes"
+	"intelliquiz/src/database/schemas"
+	"intelliquiz/src/types"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func testQuiz() schemas.Quiz {
+	correct := func(isCorrect bool) *bool { return &isCorrect }
+	position := func(position uint8) *uint8 { return &position }
+	number := func(n float64) *float64 { return &n }
+
+	var acceptedAnswers []schemas.Choice
+	for i := range 10 {
+		acceptedAnswers = append(acceptedAnswers, schemas.Choice{Content: "Answer " + strconv.Itoa(i+1), IsCorrect: correct(true)})
+	}
+
+	return schemas.Quiz{
+		Name:       "Round trip",
+		CategoryID: "d27b21ab-6177-4159-9e13-15dc50ffed29",
+		Questions: []schemas.Question{
+			{
+				Content:     "What is the capital of France?",
+				Type:        schemas.QuestionTypeSingleChoice,
+				Explanation: "Paris has been the capital since 987.",
+				Choices: []schemas.Choice{
+					{Content: "Paris", IsCorrect: correct(true)},
+					{Content: "Lyon", IsCorrect: correct(false)},
+					{Content: "Marseille", IsCorrect: correct(false)},
+				},
+			},
+			{
+				Content: "Which are primes?",
+				Type:    schemas.QuestionTypeMultipleSelect,
+				Choices: []schemas.Choice{
+					{Content: "2", IsCorrect: correct(true)},
+					{Content: "4", IsCorrect: correct(false)},
+					{Content: "5", IsCorrect: correct(true)},
+					{Content: "9", IsCorrect: correct(false)},
+					{Content: "11", IsCorrect: correct(true)},
+					{Content: "15", IsCorrect: correct(false)},
+				},
+			},
+			{
+				Content: "The sky is blue.",
+				Type:    schemas.QuestionTypeTrueFalse,
+				Choices: []schemas.Choice{
+					{Content: "True", IsCorrect: correct(true)},
+					{Content: "False", IsCorrect: correct(false)},
+				},
+			},
+			{
+				Content: "Order from smallest to largest.",
+				Type:    schemas.QuestionTypeOrdering,
+				Choices: []schemas.Choice{
+					{Content: "Ten", IsCorrect: correct(false), Position: position(2)},
+					{Content: "One", IsCorrect: correct(false), Position: position(0)},
+					{Content: "Five", IsCorrect: correct(false), Position: position(1)},
+				},
+			},
+			{
+				Content: "Name a number.",
+				Type:    schemas.QuestionTypeFreeText,
+				Choices: acceptedAnswers,
+			},
+			{
+				Content:          "What is pi?",
+				Type:             schemas.QuestionTypeNumeric,
+				NumericAnswer:    number(3.14),
+				NumericTolerance: number(0.01),
+			},
+		},
+	}
+}
+
+func TestExportParseRoundTrip(t *testing.T) {
+	quiz := testQuiz()
+
+	for _, format := range []string{FormatJSON, FormatCSV, FormatGIFT, FormatMoodleXML} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Export(format, quiz, &buf); err != nil {
+				t.Fatalf("Export: %v", err)
+			}
+
+			parsed, rowErrors, err := Parse(format, &buf)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if len(rowErrors) > 0 {
+				t.Fatalf("Parse row errors: %+v", rowErrors)
+			}
+
+			var want []types.CreateQuizQuestionsStruct
+			for _, question := range quiz.Questions {
+				// GIFT has no ordering questions, so they are left out of exports
+				if format == FormatGIFT && question.Type == schemas.QuestionTypeOrdering {
+					continue
+				}
+				want = append(want, questionInput(question))
+			}
+
+			if len(parsed.Questions) != len(want) {
+				t.Fatalf("got %d questions, want %d", len(parsed.Questions), len(want))
+			}
+			for i, question := range parsed.Questions {
+				if !reflect.DeepEqual(question.Question, want[i]) {
+					t.Errorf("question %d:\n got %+v\nwant %+v", i+1, question.Question, want[i])
+				}
+			}
+
+			if format == FormatJSON && (parsed.Name != quiz.Name || parsed.CategoryID != quiz.CategoryID) {
+				t.Errorf("got quiz %q in %q, want %q in %q", parsed.Name, parsed.CategoryID, quiz.Name, quiz.CategoryID)
+			}
+		})
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      string
+		want      []types.CreateQuizQuestionsStruct
+		rowErrors int
+	}{
+		{
+			name: "single choice by default",
+			file: "question,correct,choice_1,choice_2\nCapital of France?,1,Paris,Lyon\n",
+			want: []types.CreateQuizQuestionsStruct{{
+				Content: "Capital of France?",
+				Type:    schemas.QuestionTypeSingleChoice,
+				Choices: []types.CreateQuizQuestionChoiceStruct{{Content: "Paris", IsCorrect: true}, {Content: "Lyon"}},
+			}},
+		},
+		{
+			name: "fields past the header are choices",
+			file: "question,type,choice_1\nNumbers?,free_text,one,two,three\n",
+			want: []types.CreateQuizQuestionsStruct{{
+				Content:         "Numbers?",
+				Type:            schemas.QuestionTypeFreeText,
+				AcceptedAnswers: []string{"one", "two", "three"},
+			}},
+		},
+		{
+			name: "true/false without choices",
+			file: "question,type,correct\nSky is blue?,true_false,true\n",
+			want: []types.CreateQuizQuestionsStruct{{
+				Content: "Sky is blue?",
+				Type:    schemas.QuestionTypeTrueFalse,
+				Choices: trueFalseChoices(true),
+			}},
+		},
+		{
+			name:      "invalid correct index",
+			file:      "question,correct,choice_1,choice_2\nCapital?,3,Paris,Lyon\n",
+			rowErrors: 1,
+		},
+		{
+			name:      "invalid numeric answer",
+			file:      "question,type,correct\nPi?,numeric,abc\n",
+			rowErrors: 1,
+		},
+		{
+			name: "blank rows are skipped",
+			file: "question,choice_1\n,\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parsed, rowErrors, err := Parse(FormatCSV, strings.NewReader(test.file))
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if len(rowErrors) != test.rowErrors {
+				t.Fatalf("got %d row errors, want %d: %+v", len(rowErrors), test.rowErrors, rowErrors)
+			}
+
+			var got []types.CreateQuizQuestionsStruct
+			for _, question := range parsed.Questions {
+				got = append(got, question.Question)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %+v\nwant %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseCSVWithoutQuestionColumn(t *testing.T) {
+	if _, _, err := Parse(FormatCSV, strings.NewReader("type,correct\n")); err == nil {
+		t.Error("expected an error for a header without a question column")
+	}
+}
+
+func TestFormatFromFilename(t *testing.T) {
+	tests := map[string]string{
+		"quiz.json": FormatJSON,
+		"quiz.CSV":  FormatCSV,
+		"quiz.gift": FormatGIFT,
+		"quiz.txt":  FormatGIFT,
+		"quiz.xml":  FormatMoodleXML,
+		"quiz.xlsx": "",
+		"quiz":      "",
+	}
+
+	for filename, want := range tests {
+		if got := FormatFromFilename(filename); got != want {
+			t.Errorf("FormatFromFilename(%q) = %q, want %q", filename, got, want)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package types
+
+// AIUsagePeriodDTO is the usage of a quota period. Quota and Remaining are
+// omitted when the period has no quota
+type AIUsagePeriodDTO struct {
+	PromptTokens     int64   `json:"prompt_tokens" example:"12400"`
+	CompletionTokens int64   `json:"completion_tokens" example:"5600"`
+	TotalTokens      int64   `json:"total_tokens" example:"18000"`
+	Cost             float64 `json:"cost" example:"0.0052"`
+	Quota            *int64  `json:"quota,omitempty" example:"50000"`
+	Remaining        *int64  `json:"remaining,omitempty" example:"32000"`
+	ResetsAt         string  `json:"resets_at" example:"2025-10-23T00:00:00-03:00"`
+}
+
+type GetOwnAIUsageDataField struct {
+	Day   AIUsagePeriodDTO `json:"day"`
+	Month AIUsagePeriodDTO `json:"month"`
+}
+
+type GetOwnAIUsageSuccessResponseStruct struct {
+	StatusCode int                    `json:"statusCode" example:"200"`
+	Success    bool                   `json:"success" example:"true"`
+	Data       GetOwnAIUsageDataField `json:"data"`
+}
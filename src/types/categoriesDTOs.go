@@ -7,10 +7,30 @@ type GetCategoryQuizDTO struct {
 	CreatedBy  string `json:"created_by" example:"user123"`
 }
 
+type CategorySummaryDTO struct {
+	ID       string `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name     string `json:"name" example:"Physics"`
+	Slug     string `json:"slug" example:"physics"`
+	IconUrl  string `json:"icon_url,omitempty" example:"https://example.com/icons/physics.svg"`
+	ParentID string `json:"parent_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+}
+
 type CategoryResponseStruct struct {
-	ID      string               `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
-	Name    string               `json:"name" example:"Science"`
-	Quizzes []GetCategoryQuizDTO `json:"quizzes,omitempty"`
+	ID           string               `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name         string               `json:"name" example:"Science"`
+	Slug         string               `json:"slug" example:"science"`
+	Description  string               `json:"description,omitempty" example:"Quizzes about the natural world."`
+	IconUrl      string               `json:"icon_url,omitempty" example:"https://example.com/icons/science.svg"`
+	ImageUrl     string               `json:"image_url,omitempty" example:"https://example.com/images/science.jpg"`
+	ParentID     string               `json:"parent_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	QuizzesCount int                  `json:"quizzes_count" example:"42"`
+	Quizzes      []GetCategoryQuizDTO `json:"quizzes,omitempty"`
+}
+
+type CategoryWithChildrenResponseStruct struct {
+	CategoryResponseStruct
+	Parent   *CategorySummaryDTO  `json:"parent,omitempty"`
+	Children []CategorySummaryDTO `json:"children,omitempty"`
 }
 
 type GetCategoriesSuccessResponseStruct struct {
@@ -20,7 +40,35 @@ type GetCategoriesSuccessResponseStruct struct {
 }
 
 type GetCategorySuccessResponseStruct struct {
-	StatusCode int                    `json:"statusCode" example:"200"`
+	StatusCode int                                `json:"statusCode" example:"200"`
+	Success    bool                               `json:"success" example:"true"`
+	Data       CategoryWithChildrenResponseStruct `json:"data"`
+}
+
+// CategoryRequestBody creates a category. The slug is made from the name
+// when not given, and a parent makes it a subcategory.
+type CategoryRequestBody struct {
+	Name        string `json:"name" binding:"required,max=40" example:"Science"`
+	Slug        string `json:"slug" binding:"max=60" example:"science"`
+	Description string `json:"description" binding:"max=1000" example:"Quizzes about the natural world."`
+	IconUrl     string `json:"icon_url" binding:"max=2048" example:"https://example.com/icons/science.svg"`
+	ImageUrl    string `json:"image_url" binding:"max=2048" example:"https://example.com/images/science.jpg"`
+	ParentID    string `json:"parent_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+}
+
+// UpdateCategoryRequestBody only changes the fields given. An empty parent ID
+// turns a subcategory into a top-level category, and empty URLs remove them.
+type UpdateCategoryRequestBody struct {
+	Name        *string `json:"name" binding:"omitempty,max=40" example:"Science"`
+	Slug        *string `json:"slug" binding:"omitempty,max=60" example:"science"`
+	Description *string `json:"description" binding:"omitempty,max=1000" example:"Quizzes about the natural world."`
+	IconUrl     *string `json:"icon_url" binding:"omitempty,max=2048" example:"https://example.com/icons/science.svg"`
+	ImageUrl    *string `json:"image_url" binding:"omitempty,max=2048" example:"https://example.com/images/science.jpg"`
+	ParentID    *string `json:"parent_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+}
+
+type CategorySuccessResponseStruct struct {
+	StatusCode int                    `json:"statusCode" example:"201"`
 	Success    bool                   `json:"success" example:"true"`
 	Data       CategoryResponseStruct `json:"data"`
 }
@@ -33,5 +33,5 @@ type GetChoiceSuccessResponseStruct struct {
 
 type UpdateChoiceRequestBody struct {
 	Content   string `json:"content" example:"Paris"`
-	IsCorrect bool   `json:"is_correct" example:"true"`
+	IsCorrect *bool  `json:"is_correct" example:"true"`
 }
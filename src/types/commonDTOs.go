@@ -24,12 +24,24 @@ type NotFoundErrorResponseStruct struct {
 	Message    string `json:"message" example:"Not Found"`
 }
 
+type ConflictErrorResponseStruct struct {
+	StatusCode int    `json:"statusCode" example:"409"`
+	Success    bool   `json:"success" example:"false"`
+	Message    string `json:"message" example:"Conflict"`
+}
+
 type UnprocessableEntityErrorResponseStruct struct {
 	StatusCode int    `json:"statusCode" default:"422"`
 	Success    bool   `json:"success" default:"false"`
 	Message    string `json:"message" default:"Unprocessable request body"`
 }
 
+type PaymentRequiredErrorResponseStruct struct {
+	StatusCode int    `json:"statusCode" default:"402"`
+	Success    bool   `json:"success" default:"false"`
+	Message    string `json:"message" default:"Payment required"`
+}
+
 type TooManyRequestsErrorResponseStruct struct {
 	StatusCode int    `json:"statusCode" default:"429"`
 	Success    bool   `json:"success" default:"false"`
@@ -41,3 +53,15 @@ type InternalServerErrorResponseStruct struct {
 	Success    bool   `json:"success" default:"false"`
 	Message    string `json:"message" default:"Internal Server Error"`
 }
+
+type ServiceUnavailableErrorResponseStruct struct {
+	StatusCode int    `json:"statusCode" default:"503"`
+	Success    bool   `json:"success" default:"false"`
+	Message    string `json:"message" default:"Service unavailable"`
+}
+
+type GatewayTimeoutErrorResponseStruct struct {
+	StatusCode int    `json:"statusCode" default:"504"`
+	Success    bool   `json:"success" default:"false"`
+	Message    string `json:"message" default:"Gateway timeout"`
+}
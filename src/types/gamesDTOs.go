@@ -12,13 +12,15 @@ type GameQuestionDTO struct {
 	ID      string                  `json:"id" example:"550e8400-e29b-41d4-a716-446655440002"`
 	QuizID  string                  `json:"quiz_id" example:"550e8400-e29b-41d4-a716-446655440002"`
 	Content string                  `json:"content" example:"Question content"`
-	Choices []GameQuestionChoiceDTO `json:"choices"`
+	Type    string                  `json:"type" example:"single_choice"`
+	Choices []GameQuestionChoiceDTO `json:"choices,omitempty"`
 }
 
 type StartGameDataStruct struct {
-	GameID         string          `json:"game_id" example:"550e8400-e29b-41d4-a716-446655440003"`
-	Question       GameQuestionDTO `json:"question"`
-	TotalQuestions int             `json:"total_questions" example:"10"`
+	GameID            string          `json:"game_id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	Question          GameQuestionDTO `json:"question"`
+	TotalQuestions    int             `json:"total_questions" example:"10"`
+	QuestionTimeLimit *uint           `json:"question_time_limit,omitempty" example:"20"`
 }
 
 type StartGameResponseStruct struct {
@@ -27,10 +29,31 @@ type StartGameResponseStruct struct {
 	Data       StartGameDataStruct `json:"data"`
 }
 
+// AnswerQuestionRequestBody answers the current question according to its type:
+// choice_id for single choice and true/false, choice_ids for multiple select,
+// choice_ids in the chosen order for ordering, text for free-text and number
+// for numeric questions.
+type AnswerQuestionRequestBody struct {
+	ChoiceID  string   `json:"choice_id" example:"05a93ef2-23a6-4793-a6dc-0167bae5150f"`
+	ChoiceIDs []string `json:"choice_ids" example:"05a93ef2-23a6-4793-a6dc-0167bae5150f"`
+	Text      string   `json:"text" example:"Paris"`
+	Number    *float64 `json:"number" example:"3.14"`
+}
+
+// AnswerQuestionDataStruct reveals the correct answer of the answered question:
+// correct_choices holds the choices in the correct order for ordering questions
+// and the accepted answers for free-text ones, and correct_number the answer of
+// numeric questions.
 type AnswerQuestionDataStruct struct {
-	IsCorrect    bool             `json:"is_correct" example:"true"`
-	IsFinished   bool             `json:"is_finished" example:"false"`
-	NextQuestion *GameQuestionDTO `json:"next_question,omitempty"`
+	IsCorrect      bool                  `json:"is_correct" example:"true"`
+	TimedOut       bool                  `json:"timed_out" example:"false"`
+	Points         uint                  `json:"points" example:"165"`
+	Score          uint                  `json:"score" example:"330"`
+	IsFinished     bool                  `json:"is_finished" example:"false"`
+	CorrectChoices []GameResultChoiceDTO `json:"correct_choices"`
+	CorrectNumber  *float64              `json:"correct_number" example:"3.14"`
+	Explanation    string                `json:"explanation" example:"Paris has been the capital of France since 987."`
+	NextQuestion   *GameQuestionDTO      `json:"next_question,omitempty"`
 }
 
 type AnswerQuestionResponseStruct struct {
@@ -39,9 +62,27 @@ type AnswerQuestionResponseStruct struct {
 	Data       AnswerQuestionDataStruct `json:"data"`
 }
 
+// DeletedQuestionDataStruct tells how a game goes on after its current question
+// was deleted: next_question is the question to answer now, absent when no
+// question is left and the game finished.
+type DeletedQuestionDataStruct struct {
+	IsFinished   bool             `json:"is_finished" example:"false"`
+	NextQuestion *GameQuestionDTO `json:"next_question,omitempty"`
+}
+
+type DeletedQuestionResponseStruct struct {
+	StatusCode int                       `json:"status_code" example:"410"`
+	Success    bool                      `json:"success" example:"false"`
+	Message    string                    `json:"message" example:"The current question has been deleted."`
+	Data       DeletedQuestionDataStruct `json:"data"`
+}
+
 type GameResultQuestionDTO struct {
-	ID      string `json:"id" example:"c9118e52-e912-4396-9f66-f8976f84e935"`
-	Content string `json:"content" example:"Qual a capital da França?"`
+	ID            string   `json:"id" example:"c9118e52-e912-4396-9f66-f8976f84e935"`
+	Content       string   `json:"content" example:"Qual a capital da França?"`
+	Type          string   `json:"type" example:"single_choice"`
+	NumericAnswer *float64 `json:"numeric_answer,omitempty" example:"3.14"`
+	Explanation   string   `json:"explanation,omitempty" example:"Paris é a capital da França desde 987."`
 }
 
 type GameResultChoiceDTO struct {
@@ -49,19 +90,25 @@ type GameResultChoiceDTO struct {
 	Content string `json:"content" example:"Paris"`
 }
 
+// GameQuestionResultDTO holds the correct answer in correct_choices, as in the
+// answer response, and in question.numeric_answer for numeric questions.
 type GameQuestionResultDTO struct {
-	ID           string                `json:"id" example:"047bdcd1-fd82-43f2-895a-8ad7fc7206e3"`
-	GameID       string                `json:"game_id" example:"38822b7e-1a36-492e-bfc3-8c26131a278f"`
-	QuestionID   string                `json:"question_id" example:"c9118e52-e912-4396-9f66-f8976f84e935"`
-	Question     GameResultQuestionDTO `json:"question"`
-	ChoiceID     string                `json:"choice_id" example:"04da923c-314a-41c6-98fc-8a39a992d5c0"`
-	Choice       GameResultChoiceDTO   `json:"choice"`
-	Position     int                   `json:"position" example:"0"`
-	AnsweredAt   string                `json:"answered_at" example:"2025-10-25T18:45:27.849543Z"`
-	SecondsTaken int                   `json:"seconds_taken" example:"17"`
-	IsCorrect    bool                  `json:"is_correct" example:"true"`
-	CreatedAt    string                `json:"created_at" example:"2025-10-25T18:45:10.258139Z"`
-	UpdatedAt    string                `json:"updated_at" example:"2025-10-25T18:45:27.84965Z"`
+	ID             string                `json:"id" example:"047bdcd1-fd82-43f2-895a-8ad7fc7206e3"`
+	GameID         string                `json:"game_id" example:"38822b7e-1a36-492e-bfc3-8c26131a278f"`
+	QuestionID     string                `json:"question_id" example:"c9118e52-e912-4396-9f66-f8976f84e935"`
+	Question       GameResultQuestionDTO `json:"question"`
+	ChoiceID       string                `json:"choice_id" example:"04da923c-314a-41c6-98fc-8a39a992d5c0"`
+	Choice         GameResultChoiceDTO   `json:"choice"`
+	CorrectChoices []GameResultChoiceDTO `json:"correct_choices,omitempty"`
+	Answer         string                `json:"answer,omitempty" example:"Paris"`
+	Position       int                   `json:"position" example:"0"`
+	AnsweredAt     string                `json:"answered_at" example:"2025-10-25T18:45:27.849543Z"`
+	SecondsTaken   int                   `json:"seconds_taken" example:"17"`
+	IsCorrect      bool                  `json:"is_correct" example:"true"`
+	TimedOut       bool                  `json:"timed_out" example:"false"`
+	Points         uint                  `json:"points" example:"165"`
+	CreatedAt      string                `json:"created_at" example:"2025-10-25T18:45:10.258139Z"`
+	UpdatedAt      string                `json:"updated_at" example:"2025-10-25T18:45:27.84965Z"`
 }
 
 type GameResultGameDTO struct {
@@ -72,6 +119,7 @@ type GameResultGameDTO struct {
 	TotalQuestions    uint                    `json:"total_questions" example:"2"`
 	CorrectAnswers    uint                    `json:"correct_answers" example:"1"`
 	TotalSecondsTaken uint                    `json:"total_seconds_taken" example:"24"`
+	Score             uint                    `json:"score" example:"165"`
 	CreatedAt         string                  `json:"created_at" example:"2025-10-25T18:45:10.256695Z"`
 	UpdatedAt         string                  `json:"updated_at" example:"2025-10-25T18:45:45.67655Z"`
 }
@@ -93,12 +141,20 @@ type GamesResultsDataStruct struct {
 	TotalQuestions    uint       `json:"total_questions" example:"2"`
 	CorrectAnswers    uint       `json:"correct_answers" example:"1"`
 	TotalSecondsTaken uint       `json:"total_seconds_taken" example:"24"`
+	Score             uint       `json:"score" example:"165"`
 	CreatedAt         string     `json:"created_at" example:"2025-10-25T18:45:10.256695Z"`
 	UpdatedAt         string     `json:"updated_at" example:"2025-10-25T18:45:45.67655Z"`
 }
 
+type GamesResultsPageStruct struct {
+	Games      []GamesResultsDataStruct `json:"games"`
+	MaxPage    int                      `json:"maxPage,omitempty" example:"10"`
+	NextCursor *string                  `json:"next_cursor" example:"eyJzIjoibmV3ZXN0IiwidiI6W119"`
+	PrevCursor *string                  `json:"prev_cursor"`
+}
+
 type GamesResultsResponseStruct struct {
-	StatusCode int                      `json:"status_code" example:"200"`
-	Success    bool                     `json:"success" example:"true"`
-	Data       []GamesResultsDataStruct `json:"data"`
+	StatusCode int                    `json:"status_code" example:"200"`
+	Success    bool                   `json:"success" example:"true"`
+	Data       GamesResultsPageStruct `json:"data"`
 }
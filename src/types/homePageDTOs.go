@@ -2,8 +2,10 @@ package types
 
 // HomePageCategoryDTO represents the category in home page quiz response
 type HomePageCategoryDTO struct {
-	ID   string `json:"id" example:"69f93509-275f-4c04-b5da-f105f4764830"`
-	Name string `json:"name" example:"Geografia"`
+	ID      string `json:"id" example:"69f93509-275f-4c04-b5da-f105f4764830"`
+	Name    string `json:"name" example:"Geografia"`
+	Slug    string `json:"slug,omitempty" example:"geografia"`
+	IconUrl string `json:"icon_url,omitempty" example:"https://example.com/icons/geografia.png"`
 }
 
 // HomePageUserDTO represents the user in home page quiz response
@@ -12,15 +14,6 @@ type HomePageUserDTO struct {
 	Username string `json:"username" example:"newyann"`
 }
 
-// HomePageGameDTO represents a game in the quiz response
-type HomePageGameDTO struct {
-	ID                string `json:"id" example:"622ad40f-44d0-416d-beb0-d62c7c7abb2e"`
-	QuizID            string `json:"quiz_id" example:"95e85c0b-ea32-437f-91a3-8daaeb492951"`
-	TotalQuestions    int    `json:"total_questions" example:"10"`
-	CorrectAnswers    int    `json:"correct_answers" example:"7"`
-	TotalSecondsTaken int    `json:"total_seconds_taken" example:"120"`
-}
-
 // HomePageQuizDTO represents a quiz in home page response
 type HomePageQuizDTO struct {
 	ID          string              `json:"id" example:"95e85c0b-ea32-437f-91a3-8daaeb492951"`
@@ -32,7 +25,7 @@ type HomePageQuizDTO struct {
 	Likes       int                 `json:"likes" example:"1"`
 	Score       *float64            `json:"score,omitempty" example:"1.0"`
 	CuratorPick bool                `json:"curator_pick" example:"false"`
-	Games       []HomePageGameDTO   `json:"games,omitempty"`
+	ImageUrl    string              `json:"image_url,omitempty" example:"https://example.com/images/franca.png"`
 	GamesPlayed int                 `json:"games_played" example:"1"`
 	CreatedAt   string              `json:"created_at" example:"2025-11-04T21:35:49.803868Z"`
 	UpdatedAt   string              `json:"updated_at" example:"2025-11-04T21:40:51.906957Z"`
@@ -40,11 +33,39 @@ type HomePageQuizDTO struct {
 
 // HomePageDataField represents the data field in home page response
 type HomePageDataField struct {
-	CuratedQuizzes     []HomePageQuizDTO `json:"curatedQuizzes"`
-	MostLikedQuizzes   []HomePageQuizDTO `json:"mostLikedQuizzes"`
-	MostPlayedQuizzes  []HomePageQuizDTO `json:"mostPlayedQuizzes"`
-	NewlyAddedQuizzes  []HomePageQuizDTO `json:"newlyAddedQuizzes"`
-	BestQuizzesOfMonth []HomePageQuizDTO `json:"bestQuizzesOfMonth"`
+	CuratedQuizzes     []HomePageQuizDTO  `json:"curatedQuizzes"`
+	MostLikedQuizzes   []HomePageQuizDTO  `json:"mostLikedQuizzes"`
+	MostPlayedQuizzes  []HomePageQuizDTO  `json:"mostPlayedQuizzes"`
+	NewlyAddedQuizzes  []HomePageQuizDTO  `json:"newlyAddedQuizzes"`
+	BestQuizzesOfMonth []HomePageQuizDTO  `json:"bestQuizzesOfMonth"`
+	RefreshedAt        string             `json:"refreshedAt" example:"2025-11-04T21:40:00.000000Z"`
+	ForYou             *HomePageForYouDTO `json:"forYou,omitempty"`
+}
+
+// HomePageContinuePlayingDTO represents an unfinished game of the user
+type HomePageContinuePlayingDTO struct {
+	GameID    string          `json:"game_id" example:"622ad40f-44d0-416d-beb0-d62c7c7abb2e"`
+	StartedAt string          `json:"started_at" example:"2025-11-04T21:35:49.803868Z"`
+	Quiz      HomePageQuizDTO `json:"quiz"`
+}
+
+// HomePageBecauseYouLikedDTO represents quizzes like the one the user liked last
+type HomePageBecauseYouLikedDTO struct {
+	Quiz    HomePageQuizDTO   `json:"quiz"`
+	Quizzes []HomePageQuizDTO `json:"quizzes"`
+}
+
+// HomePageCategoryQuizzesDTO represents quizzes of a category the user plays the most
+type HomePageCategoryQuizzesDTO struct {
+	Category HomePageCategoryDTO `json:"category"`
+	Quizzes  []HomePageQuizDTO   `json:"quizzes"`
+}
+
+// HomePageForYouDTO represents the home page sections of the logged in user
+type HomePageForYouDTO struct {
+	ContinuePlaying []HomePageContinuePlayingDTO `json:"continuePlaying"`
+	BecauseYouLiked *HomePageBecauseYouLikedDTO  `json:"becauseYouLiked"`
+	TopCategories   []HomePageCategoryQuizzesDTO `json:"topCategories"`
 }
 
 // HomePageSuccessResponseStruct represents the successful response for home page endpoint
@@ -37,9 +37,12 @@ type AutocompleteChoiceSuccessResponseDTO struct {
 	Data       string `json:"data" example:"Paris"`
 }
 
+// GenerateQuestionRequestDTO appends the generated question to the draft when
+// DraftID is set, otherwise a new draft is created
 type GenerateQuestionRequestDTO struct {
 	QuizTitle  string `json:"quiz_title" binding:"required" example:"Geography Quiz"`
 	CategoryID string `json:"category_id" binding:"required" example:"d27b21ab-6177-4159-9e13-15dc50ffed29"`
+	DraftID    string `json:"draft_id" binding:"omitempty,uuid" example:"a1e4a6b0-8c4b-4f52-9d0f-2b7c9a8e6f10"`
 }
 
 type GeneratedChoiceDTO struct {
@@ -48,6 +51,7 @@ type GeneratedChoiceDTO struct {
 }
 
 type GeneratedQuestionDataDTO struct {
+	DraftID         string               `json:"draft_id,omitempty" example:"a1e4a6b0-8c4b-4f52-9d0f-2b7c9a8e6f10"`
 	QuestionContent string               `json:"question_content" example:"What is the capital of France?"`
 	Choices         []GeneratedChoiceDTO `json:"choices"`
 }
@@ -59,7 +63,13 @@ type GenerateQuestionSuccessResponseDTO struct {
 }
 
 type GenerateQuizRequestDTO struct {
-	CategoryID string `json:"category_id" binding:"required" example:"d27b21ab-6177-4159-9e13-15dc50ffed29"`
+	CategoryID    string `json:"category_id" binding:"required" example:"d27b21ab-6177-4159-9e13-15dc50ffed29"`
+	Topic         string `json:"topic" binding:"omitempty,max=255" example:"Capitals of Europe"`
+	QuestionCount int    `json:"question_count" binding:"omitempty,min=2,max=50" example:"10"`
+	Difficulty    string `json:"difficulty" binding:"omitempty,oneof=easy medium hard mixed" enums:"easy,medium,hard,mixed" example:"medium"`
+	Language      string `json:"language" binding:"omitempty,bcp47_language_tag" example:"pt-BR"`
+	MinChoices    int    `json:"min_choices" binding:"omitempty,min=2,max=6" example:"3"`
+	MaxChoices    int    `json:"max_choices" binding:"omitempty,min=2,max=6" example:"4"`
 }
 
 type GeneratedQuizQuestionDTO struct {
@@ -68,6 +78,7 @@ type GeneratedQuizQuestionDTO struct {
 }
 
 type GeneratedQuizDataDTO struct {
+	DraftID   string                     `json:"draft_id,omitempty" example:"a1e4a6b0-8c4b-4f52-9d0f-2b7c9a8e6f10"`
 	QuizTitle string                     `json:"quiz_title" example:"European Capitals Quiz"`
 	Questions []GeneratedQuizQuestionDTO `json:"questions"`
 }
@@ -77,3 +88,100 @@ type GenerateQuizSuccessResponseDTO struct {
 	Success    bool                 `json:"success" example:"true"`
 	Data       GeneratedQuizDataDTO `json:"data"`
 }
+
+// GenerateQuizFromTextRequestDTO is sent either as JSON with the text, or as a
+// multipart form with the text or an uploaded file
+type GenerateQuizFromTextRequestDTO struct {
+	Text          string `json:"text" form:"text" example:"A fotossíntese é o processo pelo qual as plantas convertem luz em energia química..."`
+	CategoryID    string `json:"category_id" form:"category_id" example:"d27b21ab-6177-4159-9e13-15dc50ffed29"`
+	Topic         string `json:"topic" form:"topic" binding:"omitempty,max=255" example:"Photosynthesis"`
+	QuestionCount int    `json:"question_count" form:"question_count" binding:"omitempty,min=2,max=50" example:"10"`
+	Difficulty    string `json:"difficulty" form:"difficulty" binding:"omitempty,oneof=easy medium hard mixed" enums:"easy,medium,hard,mixed" example:"medium"`
+	Language      string `json:"language" form:"language" binding:"omitempty,bcp47_language_tag" example:"pt-BR"`
+	MinChoices    int    `json:"min_choices" form:"min_choices" binding:"omitempty,min=2,max=6" example:"3"`
+	MaxChoices    int    `json:"max_choices" form:"max_choices" binding:"omitempty,min=2,max=6" example:"4"`
+}
+
+type GeneratedSourceQuestionDTO struct {
+	QuestionContent string               `json:"question_content" example:"Into what do plants convert light during photosynthesis?"`
+	Choices         []GeneratedChoiceDTO `json:"choices"`
+	SourceExcerpt   string               `json:"source_excerpt" example:"A fotossíntese é o processo pelo qual as plantas convertem luz em energia química."`
+}
+
+type GeneratedSourceQuizDataDTO struct {
+	DraftID   string                       `json:"draft_id,omitempty" example:"a1e4a6b0-8c4b-4f52-9d0f-2b7c9a8e6f10"`
+	QuizTitle string                       `json:"quiz_title" example:"Photosynthesis Basics"`
+	Questions []GeneratedSourceQuestionDTO `json:"questions"`
+}
+
+type GenerateQuizFromTextSuccessResponseDTO struct {
+	StatusCode int                        `json:"statusCode" example:"200"`
+	Success    bool                       `json:"success" example:"true"`
+	Data       GeneratedSourceQuizDataDTO `json:"data"`
+}
+
+type QuizStreamTitleEventDTO struct {
+	QuizTitle string `json:"quiz_title" example:"European Capitals Quiz"`
+}
+
+type QuizStreamQuestionEventDTO struct {
+	Index           int                  `json:"index" example:"0"`
+	QuestionContent string               `json:"question_content" example:"What is the capital of France?"`
+	Choices         []GeneratedChoiceDTO `json:"choices"`
+	SourceExcerpt   string               `json:"source_excerpt,omitempty" example:"Paris is the capital and largest city of France."`
+}
+
+type QuizStreamDoneEventDTO struct {
+	DraftID       string `json:"draft_id,omitempty" example:"a1e4a6b0-8c4b-4f52-9d0f-2b7c9a8e6f10"`
+	QuestionCount int    `json:"question_count" example:"5"`
+}
+
+type QuizStreamErrorEventDTO struct {
+	Message string `json:"message" example:"An error occurred while communicating with the AI service."`
+}
+
+// ReviewIssueDTO is a problem found in a question. ChoiceID is set when the
+// problem is in one of its choices, and SuggestedContent is the rewritten
+// question or choice content, ready to be sent to the update endpoints.
+type ReviewIssueDTO struct {
+	Kind             string `json:"kind" enums:"ambiguous_wording,factual_doubt,multiple_plausible_answers,obvious_distractor,spelling" example:"ambiguous_wording"`
+	ChoiceID         string `json:"choice_id,omitempty" example:"6a0e1f5c-7d8e-4c2b-9a3f-1b2c3d4e5f60"`
+	Description      string `json:"description" example:"The question does not say which Paris it refers to."`
+	SuggestedContent string `json:"suggested_content,omitempty" example:"What is the capital of France?"`
+}
+
+type QuestionReviewDTO struct {
+	QuestionID      string           `json:"question_id" example:"5d9c1a3e-2b4f-4e6a-8c7d-9f0e1a2b3c4d"`
+	QuestionContent string           `json:"question_content" example:"What is the capital?"`
+	Issues          []ReviewIssueDTO `json:"issues"`
+}
+
+type QuizReviewDataDTO struct {
+	QuizID     string              `json:"quiz_id" example:"3f1c2b4a-5d6e-4f70-8a9b-0c1d2e3f4a5b"`
+	Summary    string              `json:"summary" example:"The quiz is well written, but two questions are ambiguous."`
+	IssueCount int                 `json:"issue_count" example:"2"`
+	Questions  []QuestionReviewDTO `json:"questions"`
+}
+
+type ReviewQuizSuccessResponseDTO struct {
+	StatusCode int               `json:"statusCode" example:"200"`
+	Success    bool              `json:"success" example:"true"`
+	Data       QuizReviewDataDTO `json:"data"`
+}
+
+type GenerateExplanationRequestDTO struct {
+	QuestionID string `json:"question_id" binding:"required,uuid" example:"5d9c1a3e-2b4f-4e6a-8c7d-9f0e1a2b3c4d"`
+	Save       bool   `json:"save" example:"false"`
+}
+
+type GeneratedExplanationDataDTO struct {
+	QuestionID  string `json:"question_id" example:"5d9c1a3e-2b4f-4e6a-8c7d-9f0e1a2b3c4d"`
+	Explanation string `json:"explanation" example:"Paris has been the capital of France since 987, when Hugh Capet made it the seat of his kingdom."`
+	Saved       bool   `json:"saved" example:"false"`
+}
+
+type GenerateExplanationSuccessResponseDTO struct {
+	StatusCode int                         `json:"statusCode" example:"200"`
+	Success    bool                        `json:"success" example:"true"`
+	Data       GeneratedExplanationDataDTO `json:"data"`
+}
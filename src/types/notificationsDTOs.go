@@ -0,0 +1,23 @@
+package types
+
+type NotificationResponseDTO struct {
+	ID        string `json:"id" example:"9a8b7c6d-5e4f-4a3b-9c2d-1e0f9a8b7c6d"`
+	Kind      string `json:"kind" enums:"report_resolved,quiz_moderated" example:"report_resolved"`
+	Message   string `json:"message" example:"Your report on \"Oceania Capitals\" was reviewed and the reported content was removed."`
+	ReportID  string `json:"report_id,omitempty" example:"7c0e5b8e-0f43-4f0b-8d38-2a7f3b1f4e21"`
+	QuizID    string `json:"quiz_id,omitempty" example:"4fdb53f5-74d2-4d0e-8267-43f893a51aca"`
+	ReadAt    string `json:"read_at,omitempty" example:"2025-10-23T09:00:00.000000000Z"`
+	CreatedAt string `json:"created_at" example:"2025-10-23T08:12:40.112233445Z"`
+}
+
+type GetNotificationsDataField struct {
+	Notifications []NotificationResponseDTO `json:"notifications"`
+	UnreadCount   int                       `json:"unreadCount" example:"2"`
+	MaxPage       int                       `json:"maxPage" example:"10"`
+}
+
+type GetNotificationsSuccessResponseStruct struct {
+	StatusCode int                       `json:"statusCode" example:"200"`
+	Success    bool                      `json:"success" example:"true"`
+	Data       GetNotificationsDataField `json:"data"`
+}
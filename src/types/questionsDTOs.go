@@ -3,6 +3,7 @@ package types
 type QuestionResponseDTO struct {
 	ID      string `json:"id" default:"4fdb53f5-74d2-4d0e-8267-43f893a51aca"`
 	Content string `json:"content" default:"What is the capital of France?"`
+	Type    string `json:"type" default:"single_choice"`
 	QuizID  string `json:"quiz_id" default:"d27b21ab-6177-4159-9e13-15dc50ffed29"`
 }
 
@@ -14,13 +15,18 @@ type GetQuestionsSuccessResponseStruct struct {
 
 type ChoicesCreateQuestionDTO struct {
 	Content   string `json:"content" binding:"required" example:"Paris"`
-	IsCorrect *bool  `json:"is_correct" binding:"required" example:"true"`
+	IsCorrect bool   `json:"is_correct" example:"true"`
 }
 
 type CreateQuestionRequestBody struct {
-	Content string                     `json:"content" binding:"required" example:"What is the capital of France?"`
-	QuizID  string                     `json:"quiz_id" binding:"required" example:"4fdb53f5-74d2-4d0e-8267-43f893a51aca"`
-	Choices []ChoicesCreateQuestionDTO `json:"choices" binding:"required,min=2,dive,required"`
+	Content          string                     `json:"content" binding:"required" example:"What is the capital of France?"`
+	Type             string                     `json:"type" binding:"omitempty,oneof=single_choice multiple_select true_false ordering free_text numeric" example:"single_choice"`
+	QuizID           string                     `json:"quiz_id" binding:"required" example:"4fdb53f5-74d2-4d0e-8267-43f893a51aca"`
+	Choices          []ChoicesCreateQuestionDTO `json:"choices" binding:"omitempty,dive"`
+	AcceptedAnswers  []string                   `json:"accepted_answers" example:"Paris"`
+	NumericAnswer    *float64                   `json:"numeric_answer" example:"3.14"`
+	NumericTolerance *float64                   `json:"numeric_tolerance" binding:"omitempty,min=0" example:"0.01"`
+	Explanation      string                     `json:"explanation" binding:"max=1000" example:"Paris has been the capital of France since 987."`
 }
 
 type CreateQuestionSuccessResponseStruct struct {
@@ -35,6 +41,9 @@ type GetQuestionSuccessResponseStruct struct {
 	Data       QuestionResponseDTO `json:"data"`
 }
 
+// UpdateQuestionRequestBody changes the fields that are sent. Sending
+// explanation as an empty string removes it.
 type UpdateQuestionRequestBody struct {
-	Content string `json:"content" example:"What is the capital of France?"`
+	Content     string  `json:"content" example:"What is the capital of France?"`
+	Explanation *string `json:"explanation" binding:"omitempty,max=1000" example:"Paris has been the capital of France since 987."`
 }
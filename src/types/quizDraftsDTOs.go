@@ -0,0 +1,57 @@
+package types
+
+type QuizDraftChoiceDTO struct {
+	Content   string `json:"content" example:"Paris"`
+	IsCorrect bool   `json:"is_correct" example:"true"`
+}
+
+type QuizDraftQuestionDTO struct {
+	Content          string               `json:"content" example:"What is the capital of France?"`
+	Type             string               `json:"type,omitempty" binding:"omitempty,oneof=single_choice multiple_select true_false ordering free_text numeric" example:"single_choice"`
+	Choices          []QuizDraftChoiceDTO `json:"choices,omitempty"`
+	AcceptedAnswers  []string             `json:"accepted_answers,omitempty" example:"Paris"`
+	NumericAnswer    *float64             `json:"numeric_answer,omitempty" example:"3.14"`
+	NumericTolerance *float64             `json:"numeric_tolerance,omitempty" example:"0.01"`
+	Explanation      string               `json:"explanation,omitempty" binding:"max=1000" example:"Paris has been the capital of France since 987."`
+	SourceExcerpt    string               `json:"source_excerpt,omitempty" example:"Paris is the capital and largest city of France."`
+}
+
+type QuizDraftResponseDTO struct {
+	ID                string                 `json:"id" example:"a1e4a6b0-8c4b-4f52-9d0f-2b7c9a8e6f10"`
+	CreatedBy         string                 `json:"created_by" example:"0fde5216-1bab-41f6-bd90-4c3f088ee91f"`
+	Source            string                 `json:"source" enums:"generate-quiz,generate-question,generate-quiz-from-text" example:"generate-quiz"`
+	Name              string                 `json:"name" example:"European Capitals Quiz"`
+	CategoryID        string                 `json:"category_id,omitempty" example:"d27b21ab-6177-4159-9e13-15dc50ffed29"`
+	ImageUrl          string                 `json:"image_url,omitempty" example:"https://example.com/image.jpg"`
+	QuestionTimeLimit *uint                  `json:"question_time_limit,omitempty" example:"20"`
+	Questions         []QuizDraftQuestionDTO `json:"questions"`
+	CreatedAt         string                 `json:"created_at" example:"2025-10-22T19:01:58.778079424Z"`
+	UpdatedAt         string                 `json:"updated_at" example:"2025-10-22T19:01:58.778079424Z"`
+}
+
+type GetDraftsDataField struct {
+	Drafts  []QuizDraftResponseDTO `json:"drafts"`
+	MaxPage int                    `json:"maxPage" example:"10"`
+}
+
+type GetDraftsSuccessResponseStruct struct {
+	StatusCode int                `json:"statusCode" example:"200"`
+	Success    bool               `json:"success" example:"true"`
+	Data       GetDraftsDataField `json:"data"`
+}
+
+type GetDraftSuccessResponseStruct struct {
+	StatusCode int                  `json:"statusCode" example:"200"`
+	Success    bool                 `json:"success" example:"true"`
+	Data       QuizDraftResponseDTO `json:"data"`
+}
+
+// UpdateDraftRequestBody replaces the content of the draft. Drafts may be
+// incomplete, so only the format of the fields is validated until publishing
+type UpdateDraftRequestBody struct {
+	Name              string                 `json:"name" binding:"max=60" example:"European Capitals Quiz"`
+	CategoryID        string                 `json:"category_id" binding:"omitempty,uuid" example:"d27b21ab-6177-4159-9e13-15dc50ffed29"`
+	ImageUrl          string                 `json:"image_url" example:"https://example.com/image.jpg"`
+	QuestionTimeLimit *uint                  `json:"question_time_limit" binding:"omitempty,min=5,max=300" example:"20"`
+	Questions         []QuizDraftQuestionDTO `json:"questions" binding:"max=50,dive"`
+}
@@ -12,39 +12,65 @@ type UserQuizResponseDTOStruct struct {
 }
 
 type QuizResponseDTO struct {
-	ID          string                        `json:"id" example:"4fdb53f5-74d2-4d0e-8267-43f893a51aca"`
-	Name        string                        `json:"name" example:"Sample Quiz"`
-	CategoryID  string                        `json:"category_id" example:"d27b21ab-6177-4159-9e13-15dc50ffed29"`
-	Category    CategoryQuizResponseDTOStruct `json:"category"`
-	CreatedBy   string                        `json:"created_by" example:"0fde5216-1bab-41f6-bd90-4c3f088ee91f"`
-	User        UserQuizResponseDTOStruct     `json:"user"`
-	CuratorPick bool                          `json:"curator_pick" example:"false"`
-	GamesPlayed int                           `json:"games_played" example:"0"`
-	Likes       int                           `json:"likes" example:"0"`
-	ImageUrl    string                        `json:"image_url,omitempty" example:"https://example.com/image.jpg"`
-	CreatedAt   string                        `json:"created_at" example:"2025-10-22T19:01:58.778079424Z"`
-	UpdatedAt   string                        `json:"updated_at" example:"2025-10-22T19:01:58.778079424Z"`
+	ID                string                        `json:"id" example:"4fdb53f5-74d2-4d0e-8267-43f893a51aca"`
+	Name              string                        `json:"name" example:"Sample Quiz"`
+	CategoryID        string                        `json:"category_id" example:"d27b21ab-6177-4159-9e13-15dc50ffed29"`
+	Category          CategoryQuizResponseDTOStruct `json:"category"`
+	CreatedBy         string                        `json:"created_by" example:"0fde5216-1bab-41f6-bd90-4c3f088ee91f"`
+	User              UserQuizResponseDTOStruct     `json:"user"`
+	CuratorPick       bool                          `json:"curator_pick" example:"false"`
+	ModerationStatus  string                        `json:"moderation_status,omitempty" enums:"approved,flagged,hidden" example:"approved"`
+	ModerationReason  string                        `json:"moderation_reason,omitempty" example:"hate"`
+	GamesPlayed       int                           `json:"games_played" example:"0"`
+	Likes             int                           `json:"likes" example:"0"`
+	Score             float32                       `json:"score,omitempty" example:"0.42"`
+	ImageUrl          string                        `json:"image_url,omitempty" example:"https://example.com/image.jpg"`
+	QuestionTimeLimit *uint                         `json:"question_time_limit,omitempty" example:"20"`
+	CreatedAt         string                        `json:"created_at" example:"2025-10-22T19:01:58.778079424Z"`
+	UpdatedAt         string                        `json:"updated_at" example:"2025-10-22T19:01:58.778079424Z"`
 }
 
 type QuizDetailedResponseDTO struct {
-	ID          string                        `json:"id" example:"4fdb53f5-74d2-4d0e-8267-43f893a51aca"`
-	Name        string                        `json:"name" example:"Sample Quiz"`
-	CategoryID  string                        `json:"category_id" example:"d27b21ab-6177-4159-9e13-15dc50ffed29"`
-	Category    CategoryQuizResponseDTOStruct `json:"category"`
-	CreatedBy   string                        `json:"created_by" example:"0fde5216-1bab-41f6-bd90-4c3f088ee91f"`
-	User        UserQuizResponseDTOStruct     `json:"user"`
-	CuratorPick bool                          `json:"curator_pick" example:"false"`
-	GamesPlayed int                           `json:"games_played" example:"0"`
-	Likes       int                           `json:"likes" example:"0"`
-	ImageUrl    string                        `json:"image_url,omitempty" example:"https://example.com/image.jpg"`
-	CreatedAt   string                        `json:"created_at" example:"2025-10-22T19:01:58.778079424Z"`
-	UpdatedAt   string                        `json:"updated_at" example:"2025-10-22T19:01:58.778079424Z"`
-	Questions   []QuizQuestionResponseDTO     `json:"questions,omitempty"`
+	ID                string                        `json:"id" example:"4fdb53f5-74d2-4d0e-8267-43f893a51aca"`
+	Name              string                        `json:"name" example:"Sample Quiz"`
+	CategoryID        string                        `json:"category_id" example:"d27b21ab-6177-4159-9e13-15dc50ffed29"`
+	Category          CategoryQuizResponseDTOStruct `json:"category"`
+	CreatedBy         string                        `json:"created_by" example:"0fde5216-1bab-41f6-bd90-4c3f088ee91f"`
+	User              UserQuizResponseDTOStruct     `json:"user"`
+	CuratorPick       bool                          `json:"curator_pick" example:"false"`
+	GamesPlayed       int                           `json:"games_played" example:"0"`
+	Likes             int                           `json:"likes" example:"0"`
+	ImageUrl          string                        `json:"image_url,omitempty" example:"https://example.com/image.jpg"`
+	QuestionTimeLimit *uint                         `json:"question_time_limit,omitempty" example:"20"`
+	CreatedAt         string                        `json:"created_at" example:"2025-10-22T19:01:58.778079424Z"`
+	UpdatedAt         string                        `json:"updated_at" example:"2025-10-22T19:01:58.778079424Z"`
+	Questions         []QuizQuestionResponseDTO     `json:"questions,omitempty"`
+}
+
+type CategoryFacetDTO struct {
+	ID    string `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name  string `json:"name" example:"Science"`
+	Count int64  `json:"count" example:"12"`
+}
+
+type CuratorPickFacetDTO struct {
+	Value bool  `json:"value" example:"true"`
+	Count int64 `json:"count" example:"3"`
+}
+
+// QuizFacetsDTO counts the quizzes matching the search by category and by
+// whether they are curator picks.
+type QuizFacetsDTO struct {
+	Categories  []CategoryFacetDTO    `json:"categories"`
+	CuratorPick []CuratorPickFacetDTO `json:"curatorPick"`
 }
 
 type GetQuizzesDataField struct {
-	Quizzes []QuizResponseDTO `json:"quizzes"`
-	MaxPage int               `json:"maxPage" example:"10"`
+	Quizzes    []QuizResponseDTO `json:"quizzes"`
+	MaxPage    int               `json:"maxPage,omitempty" example:"10"`
+	Facets     *QuizFacetsDTO    `json:"facets,omitempty"`
+	NextCursor *string           `json:"next_cursor" example:"eyJzIjoibmV3ZXN0IiwidiI6W119"`
+	PrevCursor *string           `json:"prev_cursor"`
 }
 
 type GetQuizzesSuccessResponseStruct struct {
@@ -54,8 +80,10 @@ type GetQuizzesSuccessResponseStruct struct {
 }
 
 type GetOwnQuizzesDataField struct {
-	Quizzes []QuizResponseDTO `json:"quizzes"`
-	MaxPage int               `json:"maxPage" example:"10"`
+	Quizzes    []QuizResponseDTO `json:"quizzes"`
+	MaxPage    int               `json:"maxPage,omitempty" example:"10"`
+	NextCursor *string           `json:"next_cursor" example:"eyJzIjoibmV3ZXN0IiwidiI6W119"`
+	PrevCursor *string           `json:"prev_cursor"`
 }
 
 type GetOwnQuizzesSuccessResponseStruct struct {
@@ -64,21 +92,37 @@ type GetOwnQuizzesSuccessResponseStruct struct {
 	Data       GetOwnQuizzesDataField `json:"data"`
 }
 
+type RecommendedQuizzesDataField struct {
+	Quizzes []QuizResponseDTO `json:"quizzes"`
+}
+
+type RecommendedQuizzesSuccessResponseStruct struct {
+	StatusCode int                         `json:"statusCode" example:"200"`
+	Success    bool                        `json:"success" example:"true"`
+	Data       RecommendedQuizzesDataField `json:"data"`
+}
+
 type CreateQuizQuestionChoiceStruct struct {
 	Content   string `json:"content" example:"Paris"`
 	IsCorrect bool   `json:"is_correct" example:"true"`
 }
 
 type CreateQuizQuestionsStruct struct {
-	Content string                           `json:"content" binding:"required" example:"What is the capital of France?"`
-	Choices []CreateQuizQuestionChoiceStruct `json:"choices" binding:"required"`
+	Content          string                           `json:"content" binding:"required" example:"What is the capital of France?"`
+	Type             string                           `json:"type" binding:"omitempty,oneof=single_choice multiple_select true_false ordering free_text numeric" example:"single_choice"`
+	Choices          []CreateQuizQuestionChoiceStruct `json:"choices,omitempty"`
+	AcceptedAnswers  []string                         `json:"accepted_answers,omitempty" example:"Paris"`
+	NumericAnswer    *float64                         `json:"numeric_answer,omitempty" example:"3.14"`
+	NumericTolerance *float64                         `json:"numeric_tolerance,omitempty" binding:"omitempty,min=0" example:"0.01"`
+	Explanation      string                           `json:"explanation,omitempty" binding:"max=1000" example:"Paris has been the capital of France since 987."`
 }
 
 type CreateQuizRequestBody struct {
-	Name       string                      `json:"name" binding:"required" example:"Sample Quiz"`
-	CategoryID string                      `json:"category_id" binding:"required" example:"d27b21ab-6177-4159-9e13-15dc50ffed29"`
-	Questions  []CreateQuizQuestionsStruct `json:"questions" binding:"required"`
-	ImageUrl   string                      `json:"image_url" example:"https://example.com/image.jpg"`
+	Name              string                      `json:"name" binding:"required" example:"Sample Quiz"`
+	CategoryID        string                      `json:"category_id" binding:"required" example:"d27b21ab-6177-4159-9e13-15dc50ffed29"`
+	Questions         []CreateQuizQuestionsStruct `json:"questions" binding:"required"`
+	ImageUrl          string                      `json:"image_url" example:"https://example.com/image.jpg"`
+	QuestionTimeLimit *uint                       `json:"question_time_limit" binding:"omitempty,min=5,max=300" example:"20"`
 }
 
 type CreateQuizResponseDTO struct {
@@ -101,9 +145,10 @@ type GetQuizSuccessResponseStruct struct {
 }
 
 type UpdateQuizRequestBody struct {
-	Name       string `json:"name" example:"Sample Quiz"`
-	CategoryID string `json:"category_id" example:"d27b21ab-6177-4159-9e13-15dc50ffed29"`
-	ImageUrl   string `json:"image_url" example:"https://example.com/image.jpg"`
+	Name              string `json:"name" example:"Sample Quiz"`
+	CategoryID        string `json:"category_id" example:"d27b21ab-6177-4159-9e13-15dc50ffed29"`
+	ImageUrl          string `json:"image_url" example:"https://example.com/image.jpg"`
+	QuestionTimeLimit *uint  `json:"question_time_limit" binding:"omitempty,max=300" example:"20"`
 }
 
 type QuizChoiceResponseDTO struct {
@@ -111,13 +156,18 @@ type QuizChoiceResponseDTO struct {
 	QuestionID string `json:"question_id" example:"78712bb2-7005-4510-bff6-133359af04f9"`
 	Content    string `json:"content" example:"Paris"`
 	IsCorrect  bool   `json:"is_correct" example:"true"`
+	Position   *uint8 `json:"position,omitempty" example:"0"`
 }
 
 type QuizQuestionResponseDTO struct {
-	ID      string                  `json:"id" example:"78712bb2-7005-4510-bff6-133359af04f9"`
-	Content string                  `json:"content" example:"Qual a capital da França?"`
-	QuizID  string                  `json:"quiz_id" example:"304827d4-f291-4253-9a86-07d2305afd95"`
-	Choices []QuizChoiceResponseDTO `json:"choices"`
+	ID               string                  `json:"id" example:"78712bb2-7005-4510-bff6-133359af04f9"`
+	Content          string                  `json:"content" example:"Qual a capital da França?"`
+	Type             string                  `json:"type" example:"single_choice"`
+	QuizID           string                  `json:"quiz_id" example:"304827d4-f291-4253-9a86-07d2305afd95"`
+	Choices          []QuizChoiceResponseDTO `json:"choices"`
+	NumericAnswer    *float64                `json:"numeric_answer,omitempty" example:"3.14"`
+	NumericTolerance *float64                `json:"numeric_tolerance,omitempty" example:"0.01"`
+	Explanation      string                  `json:"explanation,omitempty" example:"Paris has been the capital of France since 987."`
 }
 
 type QuizWithQuestionsResponseDTO struct {
@@ -133,3 +183,21 @@ type CreateQuizWithQuestionsSuccessResponseStruct struct {
 	Success    bool                         `json:"success" example:"true"`
 	Data       QuizWithQuestionsResponseDTO `json:"data"`
 }
+
+// ImportQuizRowErrorDTO points to the CSV line, or the question number for the
+// other formats, that failed to import. Row 0 refers to the whole quiz.
+type ImportQuizRowErrorDTO struct {
+	Row     int    `json:"row" example:"3"`
+	Message string `json:"message" example:"Only one correct choice can be specified for the question: What is the capital of France?"`
+}
+
+type ImportQuizErrorResponseStruct struct {
+	StatusCode int                     `json:"statusCode" example:"422"`
+	Success    bool                    `json:"success" example:"false"`
+	Message    string                  `json:"message" example:"The quiz file has invalid rows."`
+	Errors     []ImportQuizRowErrorDTO `json:"errors"`
+}
+
+type SetCuratorPickRequestBody struct {
+	CuratorPick *bool `json:"curator_pick" binding:"required" example:"true"`
+}
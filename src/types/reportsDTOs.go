@@ -0,0 +1,113 @@
+package types
+
+type ReportRequestBody struct {
+	Reason  string `json:"reason" binding:"required,oneof=wrong_answer offensive spam copyright other" enums:"wrong_answer,offensive,spam,copyright,other" example:"wrong_answer"`
+	Comment string `json:"comment" binding:"max=500" example:"The correct answer is Canberra, not Sydney."`
+}
+
+type ReportResponseDTO struct {
+	ID         string `json:"id" example:"7c0e5b8e-0f43-4f0b-8d38-2a7f3b1f4e21"`
+	ReporterID string `json:"reporter_id" example:"0fde5216-1bab-41f6-bd90-4c3f088ee91f"`
+	QuizID     string `json:"quiz_id" example:"4fdb53f5-74d2-4d0e-8267-43f893a51aca"`
+	QuestionID string `json:"question_id,omitempty" example:"b3d1c6a2-5f1e-4c8e-9a7b-1e2d3c4b5a69"`
+	Reason     string `json:"reason" enums:"wrong_answer,offensive,spam,copyright,other" example:"wrong_answer"`
+	Comment    string `json:"comment,omitempty" example:"The correct answer is Canberra, not Sydney."`
+	Status     string `json:"status" enums:"open,resolved,dismissed" example:"open"`
+	CreatedAt  string `json:"created_at" example:"2025-10-22T19:01:58.778079424Z"`
+}
+
+type CreateReportSuccessResponseStruct struct {
+	StatusCode int               `json:"statusCode" example:"201"`
+	Success    bool              `json:"success" example:"true"`
+	Data       ReportResponseDTO `json:"data"`
+}
+
+type ReportReporterDTO struct {
+	ID       string `json:"id" example:"0fde5216-1bab-41f6-bd90-4c3f088ee91f"`
+	Username string `json:"username" example:"john_doe"`
+}
+
+type ReportQuizDTO struct {
+	ID               string `json:"id" example:"4fdb53f5-74d2-4d0e-8267-43f893a51aca"`
+	Name             string `json:"name" example:"Oceania Capitals"`
+	CreatedBy        string `json:"created_by" example:"2a9c4e1d-6b7f-4a3e-8c5d-9f0e1b2c3d4e"`
+	ModerationStatus string `json:"moderation_status" enums:"approved,flagged,hidden" example:"approved"`
+}
+
+type ReportQuestionDTO struct {
+	ID      string `json:"id" example:"b3d1c6a2-5f1e-4c8e-9a7b-1e2d3c4b5a69"`
+	Content string `json:"content" example:"What is the capital of Australia?"`
+}
+
+type AdminReportResponseDTO struct {
+	ID             string             `json:"id" example:"7c0e5b8e-0f43-4f0b-8d38-2a7f3b1f4e21"`
+	ReporterID     string             `json:"reporter_id" example:"0fde5216-1bab-41f6-bd90-4c3f088ee91f"`
+	Reporter       ReportReporterDTO  `json:"reporter"`
+	QuizID         string             `json:"quiz_id" example:"4fdb53f5-74d2-4d0e-8267-43f893a51aca"`
+	Quiz           *ReportQuizDTO     `json:"quiz,omitempty"`
+	QuestionID     string             `json:"question_id,omitempty" example:"b3d1c6a2-5f1e-4c8e-9a7b-1e2d3c4b5a69"`
+	Question       *ReportQuestionDTO `json:"question,omitempty"`
+	Reason         string             `json:"reason" enums:"wrong_answer,offensive,spam,copyright,other" example:"wrong_answer"`
+	Comment        string             `json:"comment,omitempty" example:"The correct answer is Canberra, not Sydney."`
+	Status         string             `json:"status" enums:"open,resolved,dismissed" example:"resolved"`
+	Action         string             `json:"action,omitempty" enums:"none,hide,delete" example:"none"`
+	ResolutionNote string             `json:"resolution_note,omitempty" example:"The answer key was fixed by the author."`
+	ResolvedBy     string             `json:"resolved_by,omitempty" example:"5e6f7a8b-9c0d-4e1f-a2b3-c4d5e6f7a8b9"`
+	ResolvedAt     string             `json:"resolved_at,omitempty" example:"2025-10-23T08:12:40.112233445Z"`
+	CreatedAt      string             `json:"created_at" example:"2025-10-22T19:01:58.778079424Z"`
+}
+
+type GetReportsDataField struct {
+	Reports []AdminReportResponseDTO `json:"reports"`
+	MaxPage int                      `json:"maxPage" example:"10"`
+}
+
+type GetReportsSuccessResponseStruct struct {
+	StatusCode int                 `json:"statusCode" example:"200"`
+	Success    bool                `json:"success" example:"true"`
+	Data       GetReportsDataField `json:"data"`
+}
+
+// ResolveReportRequestBody closes a report. Dismissed reports take no action,
+// and resolved ones may hide the quiz or delete the reported content.
+type ResolveReportRequestBody struct {
+	Status string `json:"status" binding:"required,oneof=resolved dismissed" enums:"resolved,dismissed" example:"resolved"`
+	Action string `json:"action" binding:"omitempty,oneof=none hide delete" enums:"none,hide,delete" example:"hide"`
+	Note   string `json:"note" binding:"max=500" example:"The quiz contains offensive content."`
+}
+
+type ResolveReportDataField struct {
+	ClosedReports int `json:"closed_reports" example:"3"`
+}
+
+type ResolveReportSuccessResponseStruct struct {
+	StatusCode int                    `json:"statusCode" example:"200"`
+	Success    bool                   `json:"success" example:"true"`
+	Data       ResolveReportDataField `json:"data"`
+}
+
+type FlaggedQuizResponseDTO struct {
+	ID               string                    `json:"id" example:"4fdb53f5-74d2-4d0e-8267-43f893a51aca"`
+	Name             string                    `json:"name" example:"Sample Quiz"`
+	CreatedBy        string                    `json:"created_by" example:"0fde5216-1bab-41f6-bd90-4c3f088ee91f"`
+	User             UserQuizResponseDTOStruct `json:"user"`
+	ModerationStatus string                    `json:"moderation_status" enums:"flagged" example:"flagged"`
+	ModerationReason string                    `json:"moderation_reason,omitempty" example:"hate"`
+	UpdatedAt        string                    `json:"updated_at" example:"2025-10-22T19:01:58.778079424Z"`
+}
+
+type GetFlaggedQuizzesDataField struct {
+	Quizzes []FlaggedQuizResponseDTO `json:"quizzes"`
+	MaxPage int                      `json:"maxPage" example:"10"`
+}
+
+type GetFlaggedQuizzesSuccessResponseStruct struct {
+	StatusCode int                        `json:"statusCode" example:"200"`
+	Success    bool                       `json:"success" example:"true"`
+	Data       GetFlaggedQuizzesDataField `json:"data"`
+}
+
+type ReviewQuizModerationRequestBody struct {
+	Status string `json:"status" binding:"required,oneof=approved hidden" enums:"approved,hidden" example:"approved"`
+	Note   string `json:"note" binding:"max=500" example:"The flagged word is used in a historical context."`
+}
@@ -0,0 +1,84 @@
+package types
+
+import "time"
+
+type CreateRoomRequestBody struct {
+	QuizID          string `json:"quiz_id" binding:"required" example:"4fdb53f5-74d2-4d0e-8267-43f893a51aca"`
+	QuestionSeconds int    `json:"question_seconds" binding:"omitempty,min=5,max=120" example:"20"`
+}
+
+type CreateRoomDataStruct struct {
+	Code            string `json:"code" example:"K7QX2M"`
+	QuizID          string `json:"quiz_id" example:"4fdb53f5-74d2-4d0e-8267-43f893a51aca"`
+	HostID          string `json:"host_id" example:"0fde5216-1bab-41f6-bd90-4c3f088ee91f"`
+	QuestionSeconds int    `json:"question_seconds" example:"20"`
+	TotalQuestions  int    `json:"total_questions" example:"10"`
+}
+
+type CreateRoomResponseStruct struct {
+	StatusCode int                  `json:"status_code" example:"201"`
+	Success    bool                 `json:"success" example:"true"`
+	Data       CreateRoomDataStruct `json:"data"`
+}
+
+// RoomMessage is the envelope of every message exchanged over a room WebSocket.
+type RoomMessage struct {
+	Type string `json:"type" example:"question"`
+	Data any    `json:"data,omitempty"`
+}
+
+// RoomIncomingMessage is sent by players: "start" (host only) or "answer".
+// Answers carry the same fields as AnswerQuestionRequestBody.
+type RoomIncomingMessage struct {
+	Type      string   `json:"type" example:"answer"`
+	ChoiceID  string   `json:"choice_id,omitempty" example:"05a93ef2-23a6-4793-a6dc-0167bae5150f"`
+	ChoiceIDs []string `json:"choice_ids,omitempty" example:"05a93ef2-23a6-4793-a6dc-0167bae5150f"`
+	Text      string   `json:"text,omitempty" example:"Paris"`
+	Number    *float64 `json:"number,omitempty" example:"3.14"`
+}
+
+type RoomPlayerDTO struct {
+	UserID    string `json:"user_id" example:"0fde5216-1bab-41f6-bd90-4c3f088ee91f"`
+	Username  string `json:"username" example:"johndoe"`
+	Connected bool   `json:"connected" example:"true"`
+}
+
+type RoomLobbyDTO struct {
+	Code    string          `json:"code" example:"K7QX2M"`
+	HostID  string          `json:"host_id" example:"0fde5216-1bab-41f6-bd90-4c3f088ee91f"`
+	Players []RoomPlayerDTO `json:"players"`
+}
+
+type RoomQuestionDTO struct {
+	Position       int             `json:"position" example:"0"`
+	TotalQuestions int             `json:"total_questions" example:"10"`
+	Seconds        int             `json:"seconds" example:"20"`
+	Deadline       time.Time       `json:"deadline" example:"2025-10-25T18:45:27.849543Z"`
+	Question       GameQuestionDTO `json:"question"`
+}
+
+type RoomAnswerResultDTO struct {
+	Position  int  `json:"position" example:"0"`
+	IsCorrect bool `json:"is_correct" example:"true"`
+	Points    uint `json:"points" example:"165"`
+}
+
+type RoomLeaderboardEntryDTO struct {
+	Rank              int    `json:"rank" example:"1"`
+	UserID            string `json:"user_id" example:"0fde5216-1bab-41f6-bd90-4c3f088ee91f"`
+	Username          string `json:"username" example:"johndoe"`
+	CorrectAnswers    uint   `json:"correct_answers" example:"3"`
+	Score             uint   `json:"score" example:"480"`
+	TotalSecondsTaken uint   `json:"total_seconds_taken" example:"42"`
+}
+
+type RoomLeaderboardDTO struct {
+	Position        int                       `json:"position" example:"0"`
+	IsFinished      bool                      `json:"is_finished" example:"false"`
+	CorrectChoiceID string                    `json:"correct_choice_id,omitempty" example:"05a93ef2-23a6-4793-a6dc-0167bae5150f"`
+	Entries         []RoomLeaderboardEntryDTO `json:"entries"`
+}
+
+type RoomErrorDTO struct {
+	Message string `json:"message" example:"Only the host can start the room."`
+}
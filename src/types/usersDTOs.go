@@ -11,12 +11,20 @@ type UserWithEmailResponseStruct struct {
 	Name     string `json:"name" example:"John Doe"`
 	Username string `json:"username" example:"johndoe"`
 	Email    string `json:"email" example:"johndoe@example.com"`
+	Role     string `json:"role" enums:"user,curator,admin" example:"user"`
+}
+
+type GetUsersPageStruct struct {
+	Users      []UserResponseStruct `json:"users"`
+	MaxPage    int                  `json:"maxPage,omitempty" example:"10"`
+	NextCursor *string              `json:"next_cursor" example:"eyJzIjoidXNlcm5hbWUiLCJ2IjpbXX0"`
+	PrevCursor *string              `json:"prev_cursor"`
 }
 
 type GetUsersSuccessResponseStruct struct {
-	StatusCode int                  `json:"statusCode" example:"200"`
-	Success    bool                 `json:"success" example:"true"`
-	Data       []UserResponseStruct `json:"data"`
+	StatusCode int                `json:"statusCode" example:"200"`
+	Success    bool               `json:"success" example:"true"`
+	Data       GetUsersPageStruct `json:"data"`
 }
 
 type GetUserByIDSuccessResponseStruct struct {
@@ -36,3 +44,11 @@ type UpdateUserRequestBody struct {
 	Name     string `json:"name"`
 	Email    string `json:"email"`
 }
+
+type UpdateUserRoleRequestBody struct {
+	Role string `json:"role" binding:"required,oneof=user curator admin" example:"curator"`
+}
+
+type SuspendUserRequestBody struct {
+	Reason string `json:"reason" binding:"max=1000" example:"Repeatedly publishing offensive quizzes."`
+}
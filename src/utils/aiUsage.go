@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"context"
+	"intelliquiz/src/database/schemas"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AIUsageTotals is the usage added up over a period.
+type AIUsageTotals struct {
+	PromptTokens     int64
+	CompletionTokens int64
+	TotalTokens      int64
+	Cost             float64
+}
+
+// SumAIUsage adds up the usage recorded since the given time, for a single user
+// or for everyone when userID is empty.
+func SumAIUsage(ctx context.Context, db *gorm.DB, userID string, since time.Time) (AIUsageTotals, error) {
+	query := db.WithContext(ctx).Model(&schemas.AIUsage{}).
+		Select("COALESCE(SUM(prompt_tokens), 0) AS prompt_tokens, COALESCE(SUM(completion_tokens), 0) AS completion_tokens, COALESCE(SUM(total_tokens), 0) AS total_tokens, COALESCE(SUM(cost), 0) AS cost").
+		Where("created_at >= ?", since)
+	if userID != "" {
+		query = query.Where("user_id = ?", userID)
+	}
+
+	var totals AIUsageTotals
+	err := query.Scan(&totals).Error
+
+	return totals, err
+}
+
+// StartOfDay returns the midnight that starts the day of t, when daily quotas
+// are reset.
+func StartOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// StartOfMonth returns the midnight that starts the month of t, when monthly
+// quotas are reset.
+func StartOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
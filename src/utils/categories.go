@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"context"
+	"intelliquiz/src/database/schemas"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// UniqueCategorySlug makes a slug out of the category name that no other
+// category uses, adding a number to it when taken.
+func UniqueCategorySlug(ctx context.Context, db *gorm.DB, name string, categoryID string) (string, error) {
+	base := Slugify(name)
+	if base == "" {
+		base = "category"
+	}
+
+	slug := base
+	for n := 2; ; n++ {
+		taken, err := IsCategorySlugTaken(ctx, db, slug, categoryID)
+		if err != nil || !taken {
+			return slug, err
+		}
+
+		suffix := "-" + strconv.Itoa(n)
+		slug = strings.TrimRight(base[:min(len(base), MaxSlugLength-len(suffix))], "-") + suffix
+	}
+}
+
+// IsCategorySlugTaken reports whether a category other than the given one
+// uses the slug.
+func IsCategorySlugTaken(ctx context.Context, db *gorm.DB, slug string, categoryID string) (bool, error) {
+	query := gorm.G[schemas.Category](db).Where("slug = ?", slug)
+	if categoryID != "" {
+		query = query.Where("id <> ?", categoryID)
+	}
+
+	count, err := query.Count(ctx, "id")
+	return count > 0, err
+}
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"context"
+	"intelliquiz/src/database/schemas"
+	"intelliquiz/src/moderation"
+	"log"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ModerateContent moderates user authored texts. Nothing is flagged when
+// moderation is disabled, and moderation failures are logged and let the
+// content through, so authoring does not depend on the moderator.
+func ModerateContent(ctx context.Context, moderator moderation.Moderator, texts ...string) moderation.Verdict {
+	if moderator == nil {
+		return moderation.Verdict{}
+	}
+
+	verdict, err := moderator.Moderate(ctx, texts...)
+	if err != nil {
+		log.Printf("Error moderating content: %v", err)
+		return moderation.Verdict{}
+	}
+
+	return verdict
+}
+
+// QuizTexts returns the texts of a quiz shown to players: its name and the
+// texts of its questions.
+func QuizTexts(quiz schemas.Quiz) []string {
+	texts := []string{quiz.Name}
+	for _, question := range quiz.Questions {
+		texts = append(texts, QuestionTexts(question)...)
+	}
+
+	return texts
+}
+
+// QuestionTexts returns the content, explanation and choices of a question.
+func QuestionTexts(question schemas.Question) []string {
+	texts := []string{question.Content, question.Explanation}
+	for _, choice := range question.Choices {
+		texts = append(texts, choice.Content)
+	}
+
+	return texts
+}
+
+// FlagQuiz quarantines a quiz after some of its content was flagged, keeping
+// it out of the public listings until it is reviewed. Hidden quizzes are left
+// as they are.
+func FlagQuiz(ctx context.Context, db *gorm.DB, quizID string, verdict moderation.Verdict) error {
+	return db.WithContext(ctx).Model(&schemas.Quiz{}).
+		Where("id = ? AND moderation_status <> ?", quizID, schemas.ModerationHidden).
+		Updates(map[string]any{
+			"moderation_status": schemas.ModerationFlagged,
+			"moderation_reason": verdict.Reason(),
+		}).
+		Error
+}
+
+// VisibleQuiz is the condition of the quizzes the user may open. Quizzes hidden
+// by moderation are only reachable by their owner and the admins, so they can
+// be reviewed and fixed; everyone else must not tell them from deleted ones.
+func VisibleQuiz(userID string, role string) clause.Expression {
+	if role == schemas.RoleAdmin {
+		return clause.Expr{SQL: "TRUE"}
+	}
+	if userID == "" {
+		return clause.Expr{SQL: "quizzes.moderation_status <> ?", Vars: []any{schemas.ModerationHidden}}
+	}
+
+	return clause.Expr{SQL: "(quizzes.moderation_status <> ? OR quizzes.created_by = ?)", Vars: []any{schemas.ModerationHidden, userID}}
+}
@@ -0,0 +1,282 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Pagination is the page of a list asked for in the query string. Pages are
+// given by an opaque cursor taken from the previous page, which stays stable
+// when rows are inserted and does not slow down on deep pages. Without a
+// cursor, the page number is used, as older clients do.
+type Pagination struct {
+	Limit  int
+	Page   int
+	cursor *cursor
+}
+
+// SortKey is a column or expression a list is sorted by. Its values cannot
+// be null, and the last sort key must be unique, so the order is stable.
+// Parse reads the cursor values of the key, rejecting the ones the column
+// can't be compared to, and values are compared as text when it is nil.
+type SortKey struct {
+	Column string
+	Vars   []any
+	Desc   bool
+	Parse  func(string) (any, error)
+}
+
+// TimeCursorValue parses the cursor value of a time column.
+func TimeCursorValue(value string) (any, error) {
+	return time.Parse(time.RFC3339Nano, value)
+}
+
+// IntCursorValue parses the cursor value of an integer column.
+func IntCursorValue(value string) (any, error) {
+	return strconv.ParseInt(value, 10, 64)
+}
+
+// FloatCursorValue checks the cursor value of a floating point column. The
+// value is kept as text, so the database reads it with the precision of the
+// column, such as the real of search ranks.
+func FloatCursorValue(value string) (any, error) {
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, err
+	}
+	if math.IsNaN(parsed) || math.IsInf(parsed, 0) {
+		return nil, strconv.ErrSyntax
+	}
+
+	return value, nil
+}
+
+// UUIDCursorValue parses the cursor value of a UUID column.
+func UUIDCursorValue(value string) (any, error) {
+	parsed, err := uuid.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsed.String(), nil
+}
+
+// cursor holds the sort key values of the row a page starts after, and the
+// name of the sort it was made for.
+type cursor struct {
+	Sort     string   `json:"s"`
+	Values   []string `json:"v"`
+	Backward bool     `json:"b,omitempty"`
+}
+
+// ParsePagination reads the limit, clamped between 5 and 50, and either the
+// cursor or the page number.
+func ParsePagination(c *gin.Context) (Pagination, error) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "0"))
+
+	pagination := Pagination{
+		Limit: max(5, min(50, limit)),
+		Page:  max(0, page),
+	}
+
+	if encoded := c.Query("cursor"); encoded != "" {
+		data, err := base64.RawURLEncoding.DecodeString(encoded)
+		if err != nil {
+			return pagination, ErrInvalidCursor
+		}
+
+		pagination.cursor = &cursor{}
+		if err := json.Unmarshal(data, pagination.cursor); err != nil {
+			return pagination, ErrInvalidCursor
+		}
+		pagination.Page = 0
+	}
+
+	return pagination, nil
+}
+
+// UsesCursor reports whether the page was given by a cursor. Otherwise the
+// total number of pages is worth counting for the clients paging by number.
+func (p Pagination) UsesCursor() bool {
+	return p.cursor != nil
+}
+
+// IsFirstPage reports whether the page is the first one, which is where the
+// data summarizing the whole list is worth computing.
+func (p Pagination) IsFirstPage() bool {
+	return p.cursor == nil && p.Page == 0
+}
+
+// MaxPage is the last page number for the number of rows.
+func (p Pagination) MaxPage(count int64) float64 {
+	return math.Ceil(float64(count)/float64(p.Limit)) - 1
+}
+
+// Paginate sorts the query by the keys and limits it to the page, fetching
+// one more row to tell whether there is a next page. Cursors made for another
+// sort, or holding values the keys can't parse, are rejected. The query must
+// not have an order of its own.
+func (p Pagination) Paginate(sort string, keys []SortKey) (func(*gorm.Statement), error) {
+	var values []any
+	if p.cursor != nil {
+		if p.cursor.Sort != sort || len(p.cursor.Values) != len(keys) {
+			return nil, ErrInvalidCursor
+		}
+
+		for i, key := range keys {
+			var value any = p.cursor.Values[i]
+			if key.Parse != nil {
+				parsed, err := key.Parse(p.cursor.Values[i])
+				if err != nil {
+					return nil, ErrInvalidCursor
+				}
+				value = parsed
+			}
+			values = append(values, value)
+		}
+	}
+
+	return func(stmt *gorm.Statement) {
+		backward := p.cursor != nil && p.cursor.Backward
+
+		var order []string
+		var orderVars []any
+		for _, key := range keys {
+			direction := " ASC"
+			if key.Desc != backward {
+				direction = " DESC"
+			}
+			order = append(order, key.Column+direction)
+			orderVars = append(orderVars, key.Vars...)
+		}
+		stmt.AddClause(clause.OrderBy{
+			Expression: clause.Expr{SQL: strings.Join(order, ", "), Vars: orderVars, WithoutParentheses: true},
+		})
+
+		if p.cursor != nil {
+			stmt.AddClause(clause.Where{Exprs: []clause.Expression{keysetCondition(keys, values, backward)}})
+		}
+
+		limit := p.Limit + 1
+		stmt.AddClause(clause.Limit{Limit: &limit, Offset: p.Page * p.Limit})
+	}, nil
+}
+
+// keysetCondition keeps the rows after the values in the sort order, or the
+// ones before them when going backward.
+func keysetCondition(keys []SortKey, values []any, backward bool) clause.Expression {
+	var conditions []string
+	var vars []any
+	for i, key := range keys {
+		var parts []string
+		for _, previous := range keys[:i] {
+			parts = append(parts, previous.Column+" = ?")
+		}
+
+		operator := " > ?"
+		if key.Desc != backward {
+			operator = " < ?"
+		}
+		parts = append(parts, key.Column+operator)
+		conditions = append(conditions, "("+strings.Join(parts, " AND ")+")")
+
+		for j, previous := range keys[:i] {
+			vars = append(vars, previous.Vars...)
+			vars = append(vars, values[j])
+		}
+		vars = append(vars, key.Vars...)
+		vars = append(vars, values[i])
+	}
+
+	return clause.Expr{SQL: "(" + strings.Join(conditions, " OR ") + ")", Vars: vars}
+}
+
+// Scope turns a statement scope, as Paginate returns, into a scope for the
+// non-generic API.
+func Scope(scope func(*gorm.Statement)) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		scope(db.Statement)
+		return db
+	}
+}
+
+// Cursors are the cursors of the pages around the current one, nil when there
+// is no such page.
+type Cursors struct {
+	Next *string
+	Prev *string
+}
+
+// PageItems trims the extra row fetched by Paginate and puts the items back in
+// order when going backward, making the cursors of the next and previous
+// pages out of the sort key values of the rows.
+func PageItems[T any](p Pagination, sort string, items []T, values func(T) []any) ([]T, Cursors) {
+	backward := p.cursor != nil && p.cursor.Backward
+	hasMore := len(items) > p.Limit
+	if hasMore {
+		items = items[:p.Limit]
+	}
+	if backward {
+		slices.Reverse(items)
+	}
+
+	var cursors Cursors
+	if len(items) == 0 {
+		return items, cursors
+	}
+
+	if hasMore || backward {
+		cursors.Next = encodeCursor(sort, values(items[len(items)-1]), false)
+	}
+	if (backward && hasMore) || (!backward && (p.cursor != nil || p.Page > 0)) {
+		cursors.Prev = encodeCursor(sort, values(items[0]), true)
+	}
+
+	return items, cursors
+}
+
+func encodeCursor(sort string, values []any, backward bool) *string {
+	c := cursor{Sort: sort, Backward: backward}
+	for _, value := range values {
+		c.Values = append(c.Values, cursorValue(value))
+	}
+
+	data, _ := json.Marshal(c)
+	encoded := base64.RawURLEncoding.EncodeToString(data)
+	return &encoded
+}
+
+// cursorValue writes the value as the database reads it back, keeping the
+// full precision of times and floats.
+func cursorValue(value any) string {
+	switch v := value.(type) {
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Format(time.RFC3339Nano)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+
+	return fmt.Sprint(value)
+}
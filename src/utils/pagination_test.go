@@ -0,0 +1,194 @@
+package utils
+
+import (
+	"errors"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type testItem struct {
+	ID        string
+	CreatedAt time.Time
+}
+
+func testItemValues(item testItem) []any {
+	return []any{item.CreatedAt, item.ID}
+}
+
+var testSortKeys = []SortKey{{Column: "created_at", Desc: true, Parse: TimeCursorValue}, {Column: "id", Desc: true}}
+
+func testPagination(t *testing.T, query url.Values) (Pagination, error) {
+	t.Helper()
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/?"+query.Encode(), nil)
+
+	return ParsePagination(c)
+}
+
+func testItems(n int) []testItem {
+	start := time.Date(2025, 10, 25, 18, 45, 0, 123456789, time.UTC)
+
+	var items []testItem
+	for i := range n {
+		items = append(items, testItem{ID: string(rune('a' + i)), CreatedAt: start.Add(-time.Duration(i) * time.Minute)})
+	}
+	return items
+}
+
+func TestParsePaginationLimits(t *testing.T) {
+	tests := []struct {
+		limit string
+		page  string
+		want  Pagination
+	}{
+		{"", "", Pagination{Limit: 10}},
+		{"1", "-3", Pagination{Limit: 5}},
+		{"100", "2", Pagination{Limit: 50, Page: 2}},
+		{"abc", "abc", Pagination{Limit: 5}},
+	}
+
+	for _, test := range tests {
+		query := url.Values{}
+		if test.limit != "" {
+			query.Set("limit", test.limit)
+		}
+		if test.page != "" {
+			query.Set("page", test.page)
+		}
+
+		got, err := testPagination(t, query)
+		if err != nil {
+			t.Fatalf("ParsePagination(%v): %v", query, err)
+		}
+		if got != test.want {
+			t.Errorf("ParsePagination(%v) = %+v, want %+v", query, got, test.want)
+		}
+	}
+}
+
+func TestParsePaginationInvalidCursor(t *testing.T) {
+	for _, encoded := range []string{"not base64!", "bm90IGpzb24"} {
+		_, err := testPagination(t, url.Values{"cursor": {encoded}})
+		if !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("cursor %q: got error %v, want %v", encoded, err, ErrInvalidCursor)
+		}
+	}
+}
+
+func TestPaginateInvalidCursorValues(t *testing.T) {
+	keys := []SortKey{
+		{Column: "created_at", Desc: true, Parse: TimeCursorValue},
+		{Column: "likes", Desc: true, Parse: IntCursorValue},
+		{Column: "rank", Desc: true, Parse: FloatCursorValue},
+		{Column: "id", Desc: true, Parse: UUIDCursorValue},
+	}
+	valid := []string{"2025-10-25T18:45:00.123456789Z", "12", "0.0607927", "7c0e5b8e-0f43-4f0b-8d38-2a7f3b1f4e21"}
+
+	pagination := Pagination{Limit: 5, cursor: &cursor{Sort: "newest", Values: valid}}
+	if _, err := pagination.Paginate("newest", keys); err != nil {
+		t.Fatalf("Paginate with valid values: %v", err)
+	}
+
+	invalid := []string{"yesterday", "12 likes", "NaN", "'; DROP TABLE quizzes; --"}
+	for i, value := range invalid {
+		values := slices.Clone(valid)
+		values[i] = value
+
+		pagination := Pagination{Limit: 5, cursor: &cursor{Sort: "newest", Values: values}}
+		if _, err := pagination.Paginate("newest", keys); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("value %q for %s: got error %v, want %v", value, keys[i].Column, err, ErrInvalidCursor)
+		}
+	}
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	items := testItems(6)
+
+	first, err := testPagination(t, url.Values{"limit": {"5"}})
+	if err != nil {
+		t.Fatalf("ParsePagination: %v", err)
+	}
+	page, cursors := PageItems(first, "newest", items, testItemValues)
+	if len(page) != 5 {
+		t.Fatalf("got %d items on the first page, want 5", len(page))
+	}
+	if cursors.Prev != nil {
+		t.Error("the first page has a previous cursor")
+	}
+	if cursors.Next == nil {
+		t.Fatal("the first page has no next cursor")
+	}
+
+	next, err := testPagination(t, url.Values{"limit": {"5"}, "cursor": {*cursors.Next}})
+	if err != nil {
+		t.Fatalf("ParsePagination: %v", err)
+	}
+	if !next.UsesCursor() || next.IsFirstPage() {
+		t.Error("the page of a cursor is not read as a cursor page")
+	}
+
+	last := page[len(page)-1]
+	wantValues := []string{last.CreatedAt.Format(time.RFC3339Nano), last.ID}
+	if strings.Join(next.cursor.Values, ",") != strings.Join(wantValues, ",") {
+		t.Errorf("got cursor values %v, want %v", next.cursor.Values, wantValues)
+	}
+	if next.cursor.Backward {
+		t.Error("the next cursor goes backward")
+	}
+
+	if _, err := next.Paginate("newest", testSortKeys); err != nil {
+		t.Errorf("Paginate with the cursor sort: %v", err)
+	}
+	if _, err := next.Paginate("alphabetical", testSortKeys); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Paginate with another sort: got error %v, want %v", err, ErrInvalidCursor)
+	}
+
+	page, cursors = PageItems(next, "newest", items[5:], testItemValues)
+	if len(page) != 1 || cursors.Next != nil || cursors.Prev == nil {
+		t.Errorf("last page: got %d items and cursors %+v, want 1 item and only a previous cursor", len(page), cursors)
+	}
+}
+
+func TestPageItemsBackward(t *testing.T) {
+	items := testItems(3)
+	backward := Pagination{Limit: 2, cursor: &cursor{Sort: "newest", Backward: true}}
+
+	// Going backward the rows come in reverse order, with the extra one last
+	page, cursors := PageItems(backward, "newest", []testItem{items[2], items[1], items[0]}, testItemValues)
+	if len(page) != 2 || page[0].ID != items[1].ID || page[1].ID != items[2].ID {
+		t.Fatalf("got page %+v, want items %q and %q in order", page, items[1].ID, items[2].ID)
+	}
+	if cursors.Next == nil || cursors.Prev == nil {
+		t.Errorf("got cursors %+v, want both", cursors)
+	}
+}
+
+func TestPaginateKeyset(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatalf("gorm.Open: %v", err)
+	}
+
+	pagination := Pagination{Limit: 5, cursor: &cursor{Sort: "newest", Values: []string{"2025-10-25T18:45:00Z", "a"}}}
+	paginate, err := pagination.Paginate("newest", testSortKeys)
+	if err != nil {
+		t.Fatalf("Paginate: %v", err)
+	}
+
+	stmt := db.Table("items").Scopes(Scope(paginate)).Find(&[]testItem{}).Statement
+	sql := stmt.SQL.String()
+	for _, want := range []string{"created_at < $1", "created_at = $2 AND id < $3", "ORDER BY created_at DESC, id DESC", "LIMIT $4"} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("got SQL %q, want it to contain %q", sql, want)
+		}
+	}
+}
@@ -0,0 +1,354 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"intelliquiz/src/database/schemas"
+	"intelliquiz/src/types"
+	"math"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	MinQuestionChoices   = 2
+	MaxQuestionChoices   = 6
+	MaxAcceptedAnswers   = 10
+	numericAnswerEpsilon = 1e-9
+)
+
+var (
+	ErrChoiceNotInQuestion = errors.New("choice does not belong to the current question")
+	ErrAnswerTypeMismatch  = errors.New("answer does not match the question type")
+)
+
+// Answer is a player answer to any question type. Choice based questions use
+// ChoiceIDs (in the submitted order for ordering questions), free-text
+// questions use Text and numeric questions use Number.
+type Answer struct {
+	ChoiceIDs []string
+	Text      string
+	Number    *float64
+}
+
+// BuildQuestion validates a question payload against the rules of its type and
+// converts it into a question ready to be created. The returned error message
+// is meant to be shown to the client.
+func BuildQuestion(q types.CreateQuizQuestionsStruct) (schemas.Question, error) {
+	question := schemas.Question{
+		Content:     q.Content,
+		Type:        q.Type,
+		Explanation: strings.TrimSpace(q.Explanation),
+	}
+	if question.Type == "" {
+		question.Type = schemas.QuestionTypeSingleChoice
+	}
+
+	switch question.Type {
+	case schemas.QuestionTypeSingleChoice, schemas.QuestionTypeTrueFalse, schemas.QuestionTypeMultipleSelect, schemas.QuestionTypeOrdering:
+		if len(q.Choices) < MinQuestionChoices {
+			return question, errors.New("At least two choices must be specified for the question: " + q.Content)
+		}
+		if len(q.Choices) > MaxQuestionChoices {
+			return question, errors.New("A maximum of 6 choices can be specified for the question: " + q.Content)
+		}
+		if question.Type == schemas.QuestionTypeTrueFalse && len(q.Choices) != 2 {
+			return question, errors.New("Exactly two choices must be specified for the true/false question: " + q.Content)
+		}
+
+		correctChoices := 0
+		for i, choice := range q.Choices {
+			isCorrect := choice.IsCorrect
+			newChoice := schemas.Choice{Content: choice.Content}
+
+			if question.Type == schemas.QuestionTypeOrdering {
+				// The submitted order is the correct order
+				position := uint8(i)
+				newChoice.Position = &position
+				isCorrect = false
+			} else if isCorrect {
+				correctChoices++
+			}
+
+			newChoice.IsCorrect = &isCorrect
+			question.Choices = append(question.Choices, newChoice)
+		}
+
+		switch question.Type {
+		case schemas.QuestionTypeSingleChoice, schemas.QuestionTypeTrueFalse:
+			if correctChoices > 1 {
+				return question, errors.New("Only one correct choice can be specified for the question: " + q.Content)
+			}
+			if correctChoices == 0 {
+				return question, errors.New("A correct choice must be specified for the question: " + q.Content)
+			}
+		case schemas.QuestionTypeMultipleSelect:
+			if correctChoices == 0 {
+				return question, errors.New("At least one correct choice must be specified for the question: " + q.Content)
+			}
+		}
+	case schemas.QuestionTypeFreeText:
+		if len(q.Choices) > 0 {
+			return question, errors.New("Free-text questions take accepted answers instead of choices: " + q.Content)
+		}
+		if len(q.AcceptedAnswers) == 0 || len(q.AcceptedAnswers) > MaxAcceptedAnswers {
+			return question, errors.New("Between 1 and 10 accepted answers must be specified for the question: " + q.Content)
+		}
+
+		isCorrect := true
+		for _, accepted := range q.AcceptedAnswers {
+			if NormalizeText(accepted) == "" {
+				return question, errors.New("Accepted answers cannot be empty for the question: " + q.Content)
+			}
+			question.Choices = append(question.Choices, schemas.Choice{
+				Content:   strings.TrimSpace(accepted),
+				IsCorrect: &isCorrect,
+			})
+		}
+	case schemas.QuestionTypeNumeric:
+		if len(q.Choices) > 0 {
+			return question, errors.New("Numeric questions take a numeric answer instead of choices: " + q.Content)
+		}
+		if q.NumericAnswer == nil {
+			return question, errors.New("A numeric answer must be specified for the question: " + q.Content)
+		}
+		if q.NumericTolerance != nil && *q.NumericTolerance < 0 {
+			return question, errors.New("The numeric tolerance cannot be negative for the question: " + q.Content)
+		}
+
+		question.NumericAnswer = q.NumericAnswer
+		question.NumericTolerance = q.NumericTolerance
+	default:
+		return question, errors.New("Invalid question type for the question: " + q.Content)
+	}
+
+	return question, nil
+}
+
+// RequiresCorrectChoice reports whether questions of the type must keep at
+// least one choice marked correct to be answerable.
+func RequiresCorrectChoice(questionType string) bool {
+	switch questionType {
+	case schemas.QuestionTypeSingleChoice, schemas.QuestionTypeTrueFalse, schemas.QuestionTypeMultipleSelect:
+		return true
+	}
+	return false
+}
+
+// EvaluateAnswer checks an answer against a question loaded with its choices,
+// including is_correct and position.
+func EvaluateAnswer(question schemas.Question, answer Answer) (bool, error) {
+	switch question.Type {
+	case schemas.QuestionTypeFreeText:
+		text := NormalizeText(answer.Text)
+		if text == "" {
+			return false, ErrAnswerTypeMismatch
+		}
+
+		for _, choice := range question.Choices {
+			if choice.IsCorrect != nil && *choice.IsCorrect && NormalizeText(choice.Content) == text {
+				return true, nil
+			}
+		}
+		return false, nil
+	case schemas.QuestionTypeNumeric:
+		if answer.Number == nil {
+			return false, ErrAnswerTypeMismatch
+		}
+		if question.NumericAnswer == nil {
+			return false, nil
+		}
+
+		tolerance := 0.0
+		if question.NumericTolerance != nil {
+			tolerance = *question.NumericTolerance
+		}
+		return math.Abs(*answer.Number-*question.NumericAnswer) <= tolerance+numericAnswerEpsilon, nil
+	}
+
+	if len(answer.ChoiceIDs) == 0 {
+		return false, ErrAnswerTypeMismatch
+	}
+
+	choicesByID := make(map[string]schemas.Choice, len(question.Choices))
+	for _, choice := range question.Choices {
+		choicesByID[choice.ID] = choice
+	}
+
+	selected := make(map[string]bool, len(answer.ChoiceIDs))
+	for _, choiceID := range answer.ChoiceIDs {
+		if _, exists := choicesByID[choiceID]; !exists {
+			return false, ErrChoiceNotInQuestion
+		}
+		if selected[choiceID] {
+			return false, ErrAnswerTypeMismatch
+		}
+		selected[choiceID] = true
+	}
+
+	switch question.Type {
+	case schemas.QuestionTypeMultipleSelect:
+		for _, choice := range question.Choices {
+			isCorrect := choice.IsCorrect != nil && *choice.IsCorrect
+			if isCorrect != selected[choice.ID] {
+				return false, nil
+			}
+		}
+		return true, nil
+	case schemas.QuestionTypeOrdering:
+		if len(answer.ChoiceIDs) != len(question.Choices) {
+			return false, ErrAnswerTypeMismatch
+		}
+
+		ordered := slices.Clone(question.Choices)
+		slices.SortStableFunc(ordered, func(a, b schemas.Choice) int {
+			return int(choicePosition(a)) - int(choicePosition(b))
+		})
+		for i, choice := range ordered {
+			if answer.ChoiceIDs[i] != choice.ID {
+				return false, nil
+			}
+		}
+		return true, nil
+	default:
+		if len(answer.ChoiceIDs) != 1 {
+			return false, ErrAnswerTypeMismatch
+		}
+
+		choice := choicesByID[answer.ChoiceIDs[0]]
+		return choice.IsCorrect != nil && *choice.IsCorrect, nil
+	}
+}
+
+// AnswerChoiceID returns the choice to store as the answered choice, which is
+// only meaningful for questions answered with a single choice.
+func AnswerChoiceID(questionType string, answer Answer) *string {
+	if questionType != schemas.QuestionTypeSingleChoice && questionType != schemas.QuestionTypeTrueFalse {
+		return nil
+	}
+	if len(answer.ChoiceIDs) != 1 {
+		return nil
+	}
+
+	return &answer.ChoiceIDs[0]
+}
+
+// FormatAnswer serializes the answer of the questions that cannot be stored as
+// a single answered choice.
+func FormatAnswer(questionType string, answer Answer) *string {
+	var formatted string
+	switch questionType {
+	case schemas.QuestionTypeMultipleSelect, schemas.QuestionTypeOrdering:
+		encoded, err := json.Marshal(answer.ChoiceIDs)
+		if err != nil {
+			return nil
+		}
+		formatted = string(encoded)
+	case schemas.QuestionTypeFreeText:
+		formatted = strings.TrimSpace(answer.Text)
+	case schemas.QuestionTypeNumeric:
+		if answer.Number == nil {
+			return nil
+		}
+		formatted = strconv.FormatFloat(*answer.Number, 'f', -1, 64)
+	default:
+		return nil
+	}
+
+	return &formatted
+}
+
+// NormalizeText lowercases the text, strips its accents and collapses its
+// whitespace so free-text answers can be compared.
+func NormalizeText(text string) string {
+	stripAccents := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	stripped, _, err := transform.String(stripAccents, text)
+	if err != nil {
+		stripped = text
+	}
+
+	return strings.Join(strings.Fields(strings.ToLower(stripped)), " ")
+}
+
+func choicePosition(choice schemas.Choice) uint8 {
+	if choice.Position == nil {
+		return math.MaxUint8
+	}
+	return *choice.Position
+}
+
+// PlayerQuestion returns a copy of the question safe to show to players: the
+// choices are shuffled and stripped of the answer, and the choices of the
+// questions that hold their accepted answers are hidden.
+func PlayerQuestion(question schemas.Question) schemas.Question {
+	question.NumericAnswer = nil
+	question.NumericTolerance = nil
+	question.Explanation = ""
+	if question.HasHiddenChoices() {
+		question.Choices = nil
+		return question
+	}
+
+	choices := make([]schemas.Choice, len(question.Choices))
+	for i, choice := range question.Choices {
+		choices[i] = schemas.Choice{
+			ID:         choice.ID,
+			QuestionID: choice.QuestionID,
+			Content:    choice.Content,
+		}
+	}
+	rand.Shuffle(len(choices), func(i, j int) {
+		choices[i], choices[j] = choices[j], choices[i]
+	})
+	question.Choices = choices
+
+	return question
+}
+
+// CorrectChoices returns the choices that make up the correct answer of a
+// question loaded with its choices: the correct ones, or all of them in the
+// correct order for ordering questions. Only their ID and content are kept.
+func CorrectChoices(question schemas.Question) []schemas.Choice {
+	choices := slices.Clone(question.Choices)
+	if question.Type == schemas.QuestionTypeOrdering {
+		slices.SortStableFunc(choices, func(a, b schemas.Choice) int {
+			return int(choicePosition(a)) - int(choicePosition(b))
+		})
+	} else {
+		choices = slices.DeleteFunc(choices, func(choice schemas.Choice) bool {
+			return choice.IsCorrect == nil || !*choice.IsCorrect
+		})
+	}
+
+	correct := make([]schemas.Choice, len(choices))
+	for i, choice := range choices {
+		correct[i] = schemas.Choice{
+			ID:      choice.ID,
+			Content: choice.Content,
+		}
+	}
+
+	return correct
+}
+
+// NewAnswer merges the single choice field accepted for backwards
+// compatibility into the answer choices.
+func NewAnswer(choiceID string, choiceIDs []string, text string, number *float64) Answer {
+	answer := Answer{
+		ChoiceIDs: choiceIDs,
+		Text:      text,
+		Number:    number,
+	}
+	if choiceID != "" && len(choiceIDs) == 0 {
+		answer.ChoiceIDs = []string{choiceID}
+	}
+
+	return answer
+}
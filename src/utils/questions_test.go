@@ -0,0 +1,178 @@
+package utils
+
+import (
+	"errors"
+	"intelliquiz/src/database/schemas"
+	"intelliquiz/src/types"
+	"testing"
+)
+
+func testChoice(id string, isCorrect bool, position uint8) schemas.Choice {
+	return schemas.Choice{ID: id, Content: id, IsCorrect: &isCorrect, Position: &position}
+}
+
+func TestEvaluateAnswer(t *testing.T) {
+	number := func(n float64) *float64 { return &n }
+
+	singleChoice := schemas.Question{
+		Type:    schemas.QuestionTypeSingleChoice,
+		Choices: []schemas.Choice{testChoice("a", true, 0), testChoice("b", false, 0)},
+	}
+	multipleSelect := schemas.Question{
+		Type:    schemas.QuestionTypeMultipleSelect,
+		Choices: []schemas.Choice{testChoice("a", true, 0), testChoice("b", false, 0), testChoice("c", true, 0)},
+	}
+	ordering := schemas.Question{
+		Type:    schemas.QuestionTypeOrdering,
+		Choices: []schemas.Choice{testChoice("c", false, 2), testChoice("a", false, 0), testChoice("b", false, 1)},
+	}
+	freeText := schemas.Question{
+		Type:    schemas.QuestionTypeFreeText,
+		Choices: []schemas.Choice{testChoice("São Paulo", true, 0), testChoice("Sampa", true, 0)},
+	}
+	numeric := schemas.Question{
+		Type:             schemas.QuestionTypeNumeric,
+		NumericAnswer:    number(3.14),
+		NumericTolerance: number(0.01),
+	}
+
+	tests := []struct {
+		name     string
+		question schemas.Question
+		answer   Answer
+		want     bool
+		wantErr  error
+	}{
+		{"single choice correct", singleChoice, Answer{ChoiceIDs: []string{"a"}}, true, nil},
+		{"single choice wrong", singleChoice, Answer{ChoiceIDs: []string{"b"}}, false, nil},
+		{"single choice with two choices", singleChoice, Answer{ChoiceIDs: []string{"a", "b"}}, false, ErrAnswerTypeMismatch},
+		{"single choice without choices", singleChoice, Answer{Text: "a"}, false, ErrAnswerTypeMismatch},
+		{"choice of another question", singleChoice, Answer{ChoiceIDs: []string{"z"}}, false, ErrChoiceNotInQuestion},
+		{"multiple select every correct choice", multipleSelect, Answer{ChoiceIDs: []string{"c", "a"}}, true, nil},
+		{"multiple select missing a correct choice", multipleSelect, Answer{ChoiceIDs: []string{"a"}}, false, nil},
+		{"multiple select with a wrong choice", multipleSelect, Answer{ChoiceIDs: []string{"a", "b", "c"}}, false, nil},
+		{"multiple select repeating a choice", multipleSelect, Answer{ChoiceIDs: []string{"a", "a"}}, false, ErrAnswerTypeMismatch},
+		{"ordering in order", ordering, Answer{ChoiceIDs: []string{"a", "b", "c"}}, true, nil},
+		{"ordering out of order", ordering, Answer{ChoiceIDs: []string{"b", "a", "c"}}, false, nil},
+		{"ordering missing a choice", ordering, Answer{ChoiceIDs: []string{"a", "b"}}, false, ErrAnswerTypeMismatch},
+		{"free text ignoring case, accents and spaces", freeText, Answer{Text: "  sao   PAULO "}, true, nil},
+		{"free text another accepted answer", freeText, Answer{Text: "sampa"}, true, nil},
+		{"free text wrong", freeText, Answer{Text: "Rio"}, false, nil},
+		{"free text empty", freeText, Answer{Text: "   "}, false, ErrAnswerTypeMismatch},
+		{"numeric exact", numeric, Answer{Number: number(3.14)}, true, nil},
+		{"numeric within tolerance", numeric, Answer{Number: number(3.15)}, true, nil},
+		{"numeric out of tolerance", numeric, Answer{Number: number(3.16)}, false, nil},
+		{"numeric without number", numeric, Answer{Text: "3.14"}, false, ErrAnswerTypeMismatch},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := EvaluateAnswer(test.question, test.answer)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("got error %v, want %v", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestBuildQuestion(t *testing.T) {
+	choices := func(correct ...bool) []types.CreateQuizQuestionChoiceStruct {
+		var choices []types.CreateQuizQuestionChoiceStruct
+		for _, isCorrect := range correct {
+			choices = append(choices, types.CreateQuizQuestionChoiceStruct{Content: "choice", IsCorrect: isCorrect})
+		}
+		return choices
+	}
+	answers := func(n int) []string {
+		var answers []string
+		for range n {
+			answers = append(answers, "answer")
+		}
+		return answers
+	}
+	number := func(n float64) *float64 { return &n }
+
+	tests := []struct {
+		name    string
+		input   types.CreateQuizQuestionsStruct
+		wantErr bool
+	}{
+		{"single choice by default", types.CreateQuizQuestionsStruct{Choices: choices(true, false)}, false},
+		{"single choice without a correct choice", types.CreateQuizQuestionsStruct{Choices: choices(false, false)}, true},
+		{"single choice with two correct choices", types.CreateQuizQuestionsStruct{Choices: choices(true, true)}, true},
+		{"too few choices", types.CreateQuizQuestionsStruct{Choices: choices(true)}, true},
+		{"too many choices", types.CreateQuizQuestionsStruct{Choices: choices(true, false, false, false, false, false, false)}, true},
+		{"true/false with three choices", types.CreateQuizQuestionsStruct{Type: schemas.QuestionTypeTrueFalse, Choices: choices(true, false, false)}, true},
+		{"multiple select", types.CreateQuizQuestionsStruct{Type: schemas.QuestionTypeMultipleSelect, Choices: choices(true, true, false)}, false},
+		{"ordering ignores correctness", types.CreateQuizQuestionsStruct{Type: schemas.QuestionTypeOrdering, Choices: choices(false, false)}, false},
+		{"free text with every accepted answer", types.CreateQuizQuestionsStruct{Type: schemas.QuestionTypeFreeText, AcceptedAnswers: answers(MaxAcceptedAnswers)}, false},
+		{"free text with too many accepted answers", types.CreateQuizQuestionsStruct{Type: schemas.QuestionTypeFreeText, AcceptedAnswers: answers(MaxAcceptedAnswers + 1)}, true},
+		{"free text with choices", types.CreateQuizQuestionsStruct{Type: schemas.QuestionTypeFreeText, Choices: choices(true, false)}, true},
+		{"numeric", types.CreateQuizQuestionsStruct{Type: schemas.QuestionTypeNumeric, NumericAnswer: number(1)}, false},
+		{"numeric without answer", types.CreateQuizQuestionsStruct{Type: schemas.QuestionTypeNumeric}, true},
+		{"numeric with negative tolerance", types.CreateQuizQuestionsStruct{Type: schemas.QuestionTypeNumeric, NumericAnswer: number(1), NumericTolerance: number(-1)}, true},
+		{"unknown type", types.CreateQuizQuestionsStruct{Type: "essay"}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := BuildQuestion(test.input)
+			if (err != nil) != test.wantErr {
+				t.Errorf("got error %v, want error: %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildQuestionOrderingPositions(t *testing.T) {
+	question, err := BuildQuestion(types.CreateQuizQuestionsStruct{
+		Type: schemas.QuestionTypeOrdering,
+		Choices: []types.CreateQuizQuestionChoiceStruct{
+			{Content: "first"}, {Content: "second"}, {Content: "third"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("BuildQuestion: %v", err)
+	}
+
+	for i, choice := range question.Choices {
+		if choice.Position == nil || int(*choice.Position) != i {
+			t.Errorf("choice %q has position %v, want %d", choice.Content, choice.Position, i)
+		}
+	}
+}
+
+func TestRequiresCorrectChoice(t *testing.T) {
+	tests := map[string]bool{
+		schemas.QuestionTypeSingleChoice:   true,
+		schemas.QuestionTypeTrueFalse:      true,
+		schemas.QuestionTypeMultipleSelect: true,
+		schemas.QuestionTypeOrdering:       false,
+		schemas.QuestionTypeFreeText:       false,
+		schemas.QuestionTypeNumeric:        false,
+	}
+
+	for questionType, want := range tests {
+		if got := RequiresCorrectChoice(questionType); got != want {
+			t.Errorf("RequiresCorrectChoice(%q) = %v, want %v", questionType, got, want)
+		}
+	}
+}
+
+func TestNormalizeText(t *testing.T) {
+	tests := map[string]string{
+		"São Paulo":       "sao paulo",
+		"  Crème  Brûlée": "creme brulee",
+		"ÀÉÎÕÜ\tç":        "aeiou c",
+		"":                "",
+	}
+
+	for text, want := range tests {
+		if got := NormalizeText(text); got != want {
+			t.Errorf("NormalizeText(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package utils
+
+import (
+	"errors"
+	"intelliquiz/src/database/schemas"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// QuizFilters narrows the quiz listings down. The zero value keeps every quiz.
+type QuizFilters struct {
+	// CategoryIDs keeps the quizzes of any of the categories or of their
+	// subcategories.
+	CategoryIDs   []string
+	CuratorPick   *bool
+	CreatedBy     string
+	MinQuestions  int
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	HasImage      *bool
+}
+
+// ParseQuizFilters reads the filters from the query string. Categories may be
+// given as repeated category_id parameters or separated by commas, and dates
+// as RFC 3339 timestamps or plain days.
+func ParseQuizFilters(c *gin.Context) (QuizFilters, error) {
+	var filters QuizFilters
+
+	for _, param := range c.QueryArray("category_id") {
+		for id := range strings.SplitSeq(param, ",") {
+			id = strings.TrimSpace(id)
+			if id == "" {
+				continue
+			}
+			if _, err := uuid.Parse(id); err != nil {
+				return filters, errors.New("Invalid category ID format: " + id)
+			}
+			filters.CategoryIDs = append(filters.CategoryIDs, id)
+		}
+	}
+
+	if param := c.Query("created_by"); param != "" {
+		if _, err := uuid.Parse(param); err != nil {
+			return filters, errors.New("Invalid created_by format. It must be a user ID.")
+		}
+		filters.CreatedBy = param
+	}
+
+	var err error
+	if filters.CuratorPick, err = parseBoolParam(c, "curator_pick"); err != nil {
+		return filters, err
+	}
+	if filters.HasImage, err = parseBoolParam(c, "has_image"); err != nil {
+		return filters, err
+	}
+	if filters.CreatedAfter, err = parseDateParam(c, "created_after"); err != nil {
+		return filters, err
+	}
+	if filters.CreatedBefore, err = parseDateParam(c, "created_before"); err != nil {
+		return filters, err
+	}
+
+	if param := c.Query("min_questions"); param != "" {
+		filters.MinQuestions, err = strconv.Atoi(param)
+		if err != nil || filters.MinQuestions < 0 {
+			return filters, errors.New("Invalid min_questions. It must be a non-negative number.")
+		}
+	}
+
+	return filters, nil
+}
+
+func parseBoolParam(c *gin.Context, name string) (*bool, error) {
+	param := c.Query(name)
+	if param == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseBool(param)
+	if err != nil {
+		return nil, errors.New("Invalid " + name + ". It must be true or false.")
+	}
+
+	return &value, nil
+}
+
+func parseDateParam(c *gin.Context, name string) (*time.Time, error) {
+	param := c.Query(name)
+	if param == "" {
+		return nil, nil
+	}
+
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if value, err := time.Parse(layout, param); err == nil {
+			return &value, nil
+		}
+	}
+
+	return nil, errors.New("Invalid " + name + ". It must be a date such as 2025-10-25 or 2025-10-25T18:45:00Z.")
+}
+
+// Apply adds the filters to a query on the quizzes table.
+func (f QuizFilters) Apply(query *gorm.DB) *gorm.DB {
+	if len(f.CategoryIDs) > 0 {
+		query = query.Where(
+			"quizzes.category_id IN ? OR quizzes.category_id IN (SELECT id FROM categories WHERE parent_id IN ? AND deleted_at IS NULL)",
+			f.CategoryIDs, f.CategoryIDs,
+		)
+	}
+	if f.CuratorPick != nil {
+		query = query.Where("quizzes.curator_pick = ?", *f.CuratorPick)
+	}
+	if f.CreatedBy != "" {
+		query = query.Where("quizzes.created_by = ?", f.CreatedBy)
+	}
+	if f.MinQuestions > 0 {
+		query = query.Where("quizzes.questions_count >= ?", f.MinQuestions)
+	}
+	if f.CreatedAfter != nil {
+		query = query.Where("quizzes.created_at >= ?", *f.CreatedAfter)
+	}
+	if f.CreatedBefore != nil {
+		query = query.Where("quizzes.created_at < ?", *f.CreatedBefore)
+	}
+	if f.HasImage != nil {
+		if *f.HasImage {
+			query = query.Where("quizzes.image_url <> ''")
+		} else {
+			query = query.Where("quizzes.image_url IS NULL OR quizzes.image_url = ''")
+		}
+	}
+
+	return query
+}
+
+// QuizSorts are the orders the quiz listings can be sorted in. Trending quizzes
+// are the most played in the last seven days, as of the last ReconcileQuizStats.
+var QuizSorts = []string{"newest", "most_liked", "most_played", "trending", "alphabetical"}
+
+// QuizSortKeys gives the sort keys of a quiz sort and the values of a quiz for
+// them, to make its cursor. The relevance sort is only for searches. Quizzes
+// must be fetched with the recent_plays column for the trending sort.
+func QuizSortKeys(sort string, search string) ([]SortKey, func(schemas.Quiz) []any, bool) {
+	id := SortKey{Column: "quizzes.id", Desc: true, Parse: UUIDCursorValue}
+
+	switch sort {
+	case "newest":
+		return []SortKey{{Column: "quizzes.created_at", Desc: true, Parse: TimeCursorValue}, id},
+			func(quiz schemas.Quiz) []any { return []any{quiz.CreatedAt, quiz.ID} }, true
+	case "most_liked":
+		return []SortKey{{Column: "quizzes.likes", Desc: true, Parse: IntCursorValue}, id},
+			func(quiz schemas.Quiz) []any { return []any{quiz.Likes, quiz.ID} }, true
+	case "most_played":
+		return []SortKey{{Column: "quizzes.games_played", Desc: true, Parse: IntCursorValue}, id},
+			func(quiz schemas.Quiz) []any { return []any{quiz.GamesPlayed, quiz.ID} }, true
+	case "trending":
+		return []SortKey{{Column: "quizzes.recent_plays", Desc: true, Parse: IntCursorValue}, id},
+			func(quiz schemas.Quiz) []any { return []any{quiz.RecentPlays, quiz.ID} }, true
+	case "alphabetical":
+		return []SortKey{{Column: "quizzes.name"}, {Column: "quizzes.id", Parse: UUIDCursorValue}},
+			func(quiz schemas.Quiz) []any { return []any{quiz.Name, quiz.ID} }, true
+	case "relevance":
+		if search == "" {
+			return nil, nil, false
+		}
+		return []SortKey{QuizSearchRank(search), id},
+			func(quiz schemas.Quiz) []any { return []any{quiz.Score, quiz.ID} }, true
+	}
+
+	return nil, nil, false
+}
@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"context"
+	"intelliquiz/src/database/schemas"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// The statistics of a quiz are counters kept in its row, updated in the same
+// transaction as the likes and games they count so the listings read them
+// instead of counting every game and like.
+const (
+	QuizStatLikes         = "likes"
+	QuizStatGamesPlayed   = "games_played"
+	QuizStatGamesFinished = "games_finished"
+	QuizStatRecentPlays   = "recent_plays"
+	QuizStatQuestions     = "questions_count"
+)
+
+// RecentPlaysWindow is how far back the games of the recent plays statistic
+// go. The statistic counts new games as they start, and forgets the older ones
+// when the statistics are reconciled.
+const RecentPlaysWindow = "7 days"
+
+// The counts the statistics are reconciled with.
+const (
+	quizLikesCount         = "(SELECT COUNT(*) FROM quiz_user_likes WHERE quiz_user_likes.quiz_id = quizzes.id)"
+	quizGamesCount         = "(SELECT COUNT(*) FROM games WHERE games.quiz_id = quizzes.id AND games.deleted_at IS NULL)"
+	quizFinishedGamesCount = "(SELECT COUNT(*) FROM games WHERE games.quiz_id = quizzes.id AND games.deleted_at IS NULL AND games.finished_at IS NOT NULL)"
+	quizRecentGamesCount   = "(SELECT COUNT(*) FROM games WHERE games.quiz_id = quizzes.id AND games.deleted_at IS NULL AND games.created_at >= now() - interval '" + RecentPlaysWindow + "')"
+	quizQuestionsCount     = "(SELECT COUNT(*) FROM questions WHERE questions.quiz_id = quizzes.id AND questions.deleted_at IS NULL)"
+)
+
+// IncrementQuizStat adds delta to a statistic of the quiz. It goes through the
+// table rather than the model, as the model never writes the statistics.
+func IncrementQuizStat(tx *gorm.DB, quizID string, stat string, delta int) error {
+	return tx.Table("quizzes").
+		Where("id = ?", quizID).
+		UpdateColumn(stat, gorm.Expr(stat+" + ?", delta)).
+		Error
+}
+
+// CountQuizGames counts games started of the quiz, which are both played and
+// recent plays.
+func CountQuizGames(tx *gorm.DB, quizID string, games int) error {
+	return tx.Table("quizzes").
+		Where("id = ?", quizID).
+		UpdateColumns(map[string]any{
+			QuizStatGamesPlayed: gorm.Expr(QuizStatGamesPlayed+" + ?", games),
+			QuizStatRecentPlays: gorm.Expr(QuizStatRecentPlays+" + ?", games),
+		}).
+		Error
+}
+
+// AddQuizLike makes the user like the quiz, counting the like only if the
+// user did not like it yet. It must run in a transaction.
+func AddQuizLike(tx *gorm.DB, quizID string, userID string) error {
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&schemas.QuizUserLike{QuizID: quizID, UserID: userID})
+	if result.Error != nil || result.RowsAffected == 0 {
+		return result.Error
+	}
+
+	return IncrementQuizStat(tx, quizID, QuizStatLikes, 1)
+}
+
+// RemoveQuizLike takes the like of the user off the quiz, if there is one. It
+// must run in a transaction.
+func RemoveQuizLike(tx *gorm.DB, quizID string, userID string) error {
+	result := tx.Where("quiz_id = ? AND user_id = ?", quizID, userID).
+		Delete(&schemas.QuizUserLike{})
+	if result.Error != nil || result.RowsAffected == 0 {
+		return result.Error
+	}
+
+	return IncrementQuizStat(tx, quizID, QuizStatLikes, -1)
+}
+
+// FinishGame sets the finish time of the game, counting it as finished for
+// its quiz unless it was already finished.
+func FinishGame(ctx context.Context, tx *gorm.DB, game schemas.Game) error {
+	rows, err := gorm.G[schemas.Game](tx).
+		Where("id = ? AND finished_at IS NULL", game.ID).
+		Update(ctx, "finished_at", game.FinishedAt)
+	if err != nil || rows == 0 {
+		return err
+	}
+
+	return IncrementQuizStat(tx, game.QuizID, QuizStatGamesFinished, 1)
+}
+
+// ReconcileQuizStats recounts the statistics of every quiz, repairing the ones
+// that drifted from the games, likes and questions they count and moving the
+// recent plays window forward. It returns the number of quizzes updated.
+func ReconcileQuizStats(ctx context.Context, db *gorm.DB) (int64, error) {
+	result := db.WithContext(ctx).Exec(
+		"UPDATE quizzes SET likes = " + quizLikesCount +
+			", games_played = " + quizGamesCount +
+			", games_finished = " + quizFinishedGamesCount +
+			", recent_plays = " + quizRecentGamesCount +
+			", questions_count = " + quizQuestionsCount +
+			" WHERE likes <> " + quizLikesCount +
+			" OR games_played <> " + quizGamesCount +
+			" OR games_finished <> " + quizFinishedGamesCount +
+			" OR recent_plays <> " + quizRecentGamesCount +
+			" OR questions_count <> " + quizQuestionsCount,
+	)
+
+	return result.RowsAffected, result.Error
+}
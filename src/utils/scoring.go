@@ -0,0 +1,38 @@
+package utils
+
+import "time"
+
+const (
+	BaseQuestionPoints = 100
+	MaxSpeedBonus      = 100
+
+	// Without a time limit the speed bonus decays over this window instead
+	speedBonusWindow = 30 * time.Second
+
+	// Tolerance for network latency before an answer counts as late
+	TimeLimitGrace = time.Second
+)
+
+// IsAnswerLate reports whether an answer given after elapsed exceeded the
+// question time limit. A zero limit means the question has no limit.
+func IsAnswerLate(elapsed, limit time.Duration) bool {
+	return limit > 0 && elapsed > limit+TimeLimitGrace
+}
+
+// QuestionPoints scores an answer: correct answers earn the base points plus a
+// bonus that decreases linearly with the time taken to answer.
+func QuestionPoints(isCorrect bool, elapsed, limit time.Duration) uint {
+	if !isCorrect || IsAnswerLate(elapsed, limit) {
+		return 0
+	}
+
+	window := limit
+	if window <= 0 {
+		window = speedBonusWindow
+	}
+
+	remaining := max(0, window-elapsed)
+	bonus := uint(float64(MaxSpeedBonus) * float64(remaining) / float64(window))
+
+	return BaseQuestionPoints + bonus
+}
@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuestionPoints(t *testing.T) {
+	tests := []struct {
+		name      string
+		isCorrect bool
+		elapsed   time.Duration
+		limit     time.Duration
+		want      uint
+	}{
+		{"wrong answer", false, time.Second, 20 * time.Second, 0},
+		{"instant answer", true, 0, 20 * time.Second, BaseQuestionPoints + MaxSpeedBonus},
+		{"halfway through the limit", true, 10 * time.Second, 20 * time.Second, BaseQuestionPoints + MaxSpeedBonus/2},
+		{"at the limit", true, 20 * time.Second, 20 * time.Second, BaseQuestionPoints},
+		{"within the grace period", true, 20*time.Second + TimeLimitGrace, 20 * time.Second, BaseQuestionPoints},
+		{"late", true, 20*time.Second + TimeLimitGrace + time.Millisecond, 20 * time.Second, 0},
+		{"without limit", true, speedBonusWindow / 2, 0, BaseQuestionPoints + MaxSpeedBonus/2},
+		{"without limit after the bonus window", true, time.Hour, 0, BaseQuestionPoints},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := QuestionPoints(test.isCorrect, test.elapsed, test.limit); got != test.want {
+				t.Errorf("got %d points, want %d", got, test.want)
+			}
+		})
+	}
+}
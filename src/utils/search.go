@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"gorm.io/gorm"
+)
+
+// quizSearchQuery parses the search terms with the configurations the quiz
+// search vectors are built with, matching quizzes in any of them. Terms
+// follow the web search syntax: quotes for phrases, OR and -word.
+const quizSearchQuery = "(websearch_to_tsquery('intelliquiz_simple', ?) || " +
+	"websearch_to_tsquery('intelliquiz_portuguese', ?) || " +
+	"websearch_to_tsquery('intelliquiz_english', ?))"
+
+// SearchQuizzes keeps the quizzes matching the search terms in their name,
+// author, category or questions. An empty search keeps every quiz.
+func SearchQuizzes(query *gorm.DB, search string) *gorm.DB {
+	if search == "" {
+		return query
+	}
+
+	return query.Where("quizzes.search_vector @@ "+quizSearchQuery, search, search, search)
+}
+
+// QuizSearchRank is the relevance of the quizzes to the search terms, to
+// sort them by. Matches in the name weigh the most, then the author and
+// category, then the questions.
+func QuizSearchRank(search string) SortKey {
+	return SortKey{
+		Column: "ts_rank_cd(quizzes.search_vector, " + quizSearchQuery + ")",
+		Vars:   []any{search, search, search},
+		Desc:   true,
+		Parse:  FloatCursorValue,
+	}
+}
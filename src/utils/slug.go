@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxSlugLength fits the slug column of the tables using slugs.
+const MaxSlugLength = 60
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Slugify turns a name into a URL slug, lowercasing it, removing its accents
+// and joining its words with hyphens, so "Ciências Gerais" becomes
+// "ciencias-gerais".
+func Slugify(name string) string {
+	name, _, _ = transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), name)
+
+	var slug strings.Builder
+	for _, word := range strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !('a' <= r && r <= 'z' || '0' <= r && r <= '9')
+	}) {
+		if slug.Len()+len(word)+1 > MaxSlugLength {
+			break
+		}
+		if slug.Len() > 0 {
+			slug.WriteByte('-')
+		}
+		slug.WriteString(word)
+	}
+
+	return slug.String()
+}
+
+// IsSlug reports whether the string is a valid slug, as made by Slugify.
+func IsSlug(s string) bool {
+	return len(s) <= MaxSlugLength && slugPattern.MatchString(s)
+}